@@ -0,0 +1,185 @@
+// migrate-shot-types maps the free-form shot types stored in records.ends_data_json
+// onto the standard shot vocabulary and reports every value it cannot map.
+//
+// It runs as a dry run unless -apply is given. Only the type and rotation of a shot are rewritten,
+// every other value is written back as it was stored.
+package main
+
+import (
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/infra"
+	"CurlARC/internal/utils"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// batchSize is how many records are held in memory at once.
+const batchSize = 100
+
+type unmapped struct {
+	recordId string
+	end      int
+	shot     int
+	value    string
+}
+
+type mapped struct {
+	end      int
+	shot     int
+	value    string
+	shotType entity.ShotType
+	rotation entity.Rotation
+}
+
+// storedObject mirrors an end or a shot as it is stored, keeping every value in its stored form
+// rather than decoding it into the current schema.
+type storedObject map[string]json.RawMessage
+
+func main() {
+	apply := flag.Bool("apply", false, "write the mapped shot types back to the database")
+	flag.Parse()
+
+	utils.LoadEnv()
+	sqlHandler := infra.NewSqlHandler()
+
+	var failures []unmapped
+	total, updated := 0, 0
+	var records []infra.Record
+	err := sqlHandler.Conn.Model(&infra.Record{}).Select("id", "ends_data_json").Where("ends_data_json IS NOT NULL").
+		FindInBatches(&records, batchSize, func(tx *gorm.DB, batch int) error {
+			for _, record := range records {
+				total++
+				endsDataJSON, mappings, recordFailures, err := mapShotTypes(record.EndsDataJSON)
+				if err != nil {
+					log.Printf("record %s: cannot decode ends data: %v", record.Id, err)
+					continue
+				}
+				for _, f := range recordFailures {
+					f.recordId = record.Id
+					failures = append(failures, f)
+				}
+				if len(mappings) == 0 {
+					continue
+				}
+				updated++
+
+				for _, m := range mappings {
+					fmt.Printf("record %s end %d shot %d: %q -> %s %s\n", record.Id, m.end, m.shot, m.value, m.shotType, m.rotation)
+				}
+				if *apply {
+					if err := sqlHandler.Conn.Model(&infra.Record{}).Where("id = ?", record.Id).Update("ends_data_json", endsDataJSON).Error; err != nil {
+						return fmt.Errorf("record %s: failed to update: %w", record.Id, err)
+					}
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		log.Fatalf("failed to migrate records: %v", err)
+	}
+
+	if *apply {
+		fmt.Printf("%d of %d records updated\n", updated, total)
+	} else {
+		fmt.Printf("%d of %d records would be updated (dry run, pass -apply to write)\n", updated, total)
+	}
+
+	if len(failures) > 0 {
+		fmt.Printf("%d shots could not be mapped:\n", len(failures))
+		for _, f := range failures {
+			fmt.Printf("  record %s end %d shot %d: %q\n", f.recordId, f.end, f.shot, f.value)
+		}
+	}
+}
+
+// mapShotTypes maps the shot types in the stored ends data of one record. It returns the patched ends data
+// with the shots it mapped, and the shots it could not map without a record ID.
+// Keys other than type and rotation keep their stored values byte for byte.
+func mapShotTypes(endsDataJSON []byte) ([]byte, []mapped, []unmapped, error) {
+	var ends []storedObject
+	err := json.Unmarshal(endsDataJSON, &ends)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var mappings []mapped
+	var failures []unmapped
+	for i, end := range ends {
+		if len(end["shots"]) == 0 {
+			continue
+		}
+		var shots []storedObject
+		if err := json.Unmarshal(end["shots"], &shots); err != nil {
+			return nil, nil, nil, fmt.Errorf("end %d: %w", i+1, err)
+		}
+
+		changed := false
+		for j, shot := range shots {
+			var value, rotation string
+			if err := decodeString(shot["type"], &value); err != nil {
+				return nil, nil, nil, fmt.Errorf("end %d shot %d: %w", i+1, j+1, err)
+			}
+			if value == "" || entity.ShotType(value).IsValid() {
+				continue
+			}
+			if err := decodeString(shot["rotation"], &rotation); err != nil {
+				return nil, nil, nil, fmt.Errorf("end %d shot %d: %w", i+1, j+1, err)
+			}
+
+			shotType, parsedRotation, ok := entity.ParseShotType(value)
+			if !ok {
+				failures = append(failures, unmapped{end: i + 1, shot: j + 1, value: value})
+				continue
+			}
+			if shot["type"], err = encode(shotType); err != nil {
+				return nil, nil, nil, err
+			}
+			if rotation == "" && parsedRotation != "" {
+				rotation = string(parsedRotation)
+				if shot["rotation"], err = encode(parsedRotation); err != nil {
+					return nil, nil, nil, err
+				}
+			}
+			mappings = append(mappings, mapped{end: i + 1, shot: j + 1, value: value, shotType: shotType, rotation: entity.Rotation(rotation)})
+			changed = true
+		}
+		if changed {
+			if end["shots"], err = encode(shots); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+	}
+
+	if len(mappings) == 0 {
+		return endsDataJSON, nil, failures, nil
+	}
+	patched, err := encode(ends)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return patched, mappings, failures, nil
+}
+
+// decodeString decodes a stored string, leaving it empty when the key is missing or null.
+func decodeString(raw json.RawMessage, value *string) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(raw, value)
+}
+
+// encode marshals a value without escaping HTML, so that stored strings are written back unchanged.
+func encode(value interface{}) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
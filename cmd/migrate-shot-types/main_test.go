@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"CurlARC/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stored is ends data as an old record keeps it: free-form shot types, no score_filled or power play keys,
+// numbers written with trailing zeros and a key the current schema does not know about.
+const stored = `[` +
+	`{"score":1,"shots":[` +
+	`{"note":"<in> & out","shooter":"山田","stones":{"enemy_stones":[],"friend_stones":[{"index":1,"r":0.50,"theta":1.0e0}]},"success_rate":0.75,"type":"in-turn draw"},` +
+	`{"rotation":"OUT_TURN","shooter":"","stones":{"enemy_stones":[{"index":1,"r":3.10,"theta":1.57}],"friend_stones":[]},"success_rate":1,"type":"Corner Guard"},` +
+	`{"shooter":"","stones":{"enemy_stones":[],"friend_stones":[]},"success_rate":0,"type":"sweep"}` +
+	`]},` +
+	`{"score":-2,"shots":[{"shooter":"","stones":{"enemy_stones":[],"friend_stones":[]},"success_rate":0.5,"type":"DRAW"}]},` +
+	`{"score":0,"shots":null}` +
+	`]`
+
+func TestMapShotTypes(t *testing.T) {
+	t.Run("正常系: 種類と回転だけを書き換え、それ以外は保存されたまま残す", func(t *testing.T) {
+		patched, mappings, failures, err := mapShotTypes([]byte(stored))
+		assert.NoError(t, err)
+
+		expected := strings.NewReplacer(
+			`"shooter":"山田"`, `"rotation":"IN_TURN","shooter":"山田"`,
+			`"type":"in-turn draw"`, `"type":"DRAW"`,
+			`"type":"Corner Guard"`, `"type":"GUARD"`,
+		).Replace(stored)
+		assert.Equal(t, expected, string(patched))
+
+		assert.Equal(t, []mapped{
+			{end: 1, shot: 1, value: "in-turn draw", shotType: entity.ShotDraw, rotation: entity.InTurn},
+			{end: 1, shot: 2, value: "Corner Guard", shotType: entity.ShotGuard, rotation: entity.OutTurn},
+		}, mappings)
+		assert.Equal(t, []unmapped{{end: 1, shot: 3, value: "sweep"}}, failures)
+	})
+
+	t.Run("正常系: 書き換えたショット以外の値はバイト単位で同じ", func(t *testing.T) {
+		patched, _, _, err := mapShotTypes([]byte(stored))
+		assert.NoError(t, err)
+
+		var before, after []map[string]json.RawMessage
+		assert.NoError(t, json.Unmarshal([]byte(stored), &before))
+		assert.NoError(t, json.Unmarshal(patched, &after))
+		assert.Len(t, after, len(before))
+		for i := range before {
+			assert.Len(t, after[i], len(before[i]), "no keys are added to end %d", i+1)
+			assert.Equal(t, string(before[i]["score"]), string(after[i]["score"]))
+		}
+		assert.Equal(t, string(before[1]["shots"]), string(after[1]["shots"]))
+		assert.Equal(t, string(before[2]["shots"]), string(after[2]["shots"]))
+
+		var beforeShots, afterShots []map[string]json.RawMessage
+		assert.NoError(t, json.Unmarshal(before[0]["shots"], &beforeShots))
+		assert.NoError(t, json.Unmarshal(after[0]["shots"], &afterShots))
+		for i := range beforeShots {
+			for key, value := range beforeShots[i] {
+				if key == "type" || key == "rotation" {
+					continue
+				}
+				assert.Equal(t, string(value), string(afterShots[i][key]), "shot %d %s", i+1, key)
+			}
+		}
+	})
+
+	t.Run("正常系: 書き換えるものがなければ保存されたまま返す", func(t *testing.T) {
+		unchanged := `[{"score":1,"shots":[{"type":"DRAW","success_rate":1.0},{"type":"","success_rate":0}]}]`
+		patched, mappings, failures, err := mapShotTypes([]byte(unchanged))
+		assert.NoError(t, err)
+		assert.Equal(t, unchanged, string(patched))
+		assert.Empty(t, mappings)
+		assert.Empty(t, failures)
+	})
+
+	t.Run("異常系: 読めないends data", func(t *testing.T) {
+		for _, endsData := range []string{`{"score":1}`, `[{"shots":{}}]`, `[{"shots":[{"type":3}]}]`} {
+			_, _, _, err := mapShotTypes([]byte(endsData))
+			assert.Error(t, err, endsData)
+		}
+	})
+}
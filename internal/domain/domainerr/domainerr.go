@@ -0,0 +1,120 @@
+// Package domainerr defines the failures repositories and usecases report to their callers.
+// Each error has a kind, which the handlers turn into an HTTP status, and a stable machine-readable code
+// clients can rely on whatever the wording of the message.
+package domainerr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind is the category of a failure.
+type Kind string
+
+const (
+	KindNotFound   Kind = "NOT_FOUND"  // the resource does not exist, or is hidden from the user
+	KindForbidden  Kind = "FORBIDDEN"  // the user may not act on the resource
+	KindConflict   Kind = "CONFLICT"   // the resource is not in a state allowing the change
+	KindValidation Kind = "VALIDATION" // the input breaks a rule
+)
+
+// CodeValidationFailed is the code of validation errors made without a more specific one.
+const CodeValidationFailed = "VALIDATION_FAILED"
+
+// FieldError tells which field of the input is invalid and why.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is a failure of a known kind. Fields lists the invalid fields of a validation error.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []FieldError
+	cause   error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is matches errors of the same kind and code, so that errors.Is(err, ErrNotTeamMember) holds
+// whatever the message says.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Kind == e.Kind && t.Code == e.Code
+}
+
+// Wrap returns a copy of the error keeping its cause, which errors.Unwrap returns.
+func (e *Error) Wrap(cause error) *Error {
+	wrapped := *e
+	wrapped.cause = cause
+	return &wrapped
+}
+
+// Errorf returns a copy of the error with another message.
+func (e *Error) Errorf(format string, args ...interface{}) *Error {
+	copied := *e
+	copied.Message = fmt.Sprintf(format, args...)
+	return &copied
+}
+
+func NotFound(code, message string) *Error {
+	return &Error{Kind: KindNotFound, Code: code, Message: message}
+}
+
+func Forbidden(code, message string) *Error {
+	return &Error{Kind: KindForbidden, Code: code, Message: message}
+}
+
+func Conflict(code, message string) *Error {
+	return &Error{Kind: KindConflict, Code: code, Message: message}
+}
+
+// Validation reports invalid input. The message sums up the fields, if any.
+func Validation(message string, fields ...FieldError) *Error {
+	return &Error{Kind: KindValidation, Code: CodeValidationFailed, Message: message, Fields: fields}
+}
+
+// Invalid reports a single invalid field.
+func Invalid(field, format string, args ...interface{}) *Error {
+	message := fmt.Sprintf(format, args...)
+	return Validation(message, FieldError{Field: field, Message: message})
+}
+
+// As finds the first domain error in the chain of err.
+func As(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// IsKind tells whether err is, or wraps, a domain error of the kind.
+func IsKind(err error, kind Kind) bool {
+	e, ok := As(err)
+	return ok && e.Kind == kind
+}
+
+// Errors shared across the usecases.
+var (
+	ErrNotTeamMember           = Forbidden("NOT_TEAM_MEMBER", "user is not a member of the team")
+	ErrInsufficientRole        = Forbidden("INSUFFICIENT_ROLE", "user's role in the team does not allow this")
+	ErrOwnerMustTransfer       = Conflict("OWNER_MUST_TRANSFER", "the owner must transfer the team before leaving it or changing role")
+	ErrRecordNotFound          = NotFound("RECORD_NOT_FOUND", "record not found")
+	ErrTeamNotFound            = NotFound("TEAM_NOT_FOUND", "team not found")
+	ErrUserNotFound            = NotFound("USER_NOT_FOUND", "user not found")
+	ErrUserTeamNotFound        = NotFound("USER_TEAM_NOT_FOUND", "user team not found")
+	ErrInvitationNotFound      = NotFound("INVITATION_NOT_FOUND", "invitation not found")
+	ErrInvitationExpired       = Conflict("INVITATION_EXPIRED", "the invitation has expired")
+	ErrEventNotFound           = NotFound("EVENT_NOT_FOUND", "event not found")
+	ErrSeasonNotFound          = NotFound("SEASON_NOT_FOUND", "season not found")
+	ErrPracticeSessionNotFound = NotFound("PRACTICE_SESSION_NOT_FOUND", "practice session not found")
+)
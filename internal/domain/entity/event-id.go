@@ -0,0 +1,21 @@
+package entity
+
+import "reflect"
+
+type EventId struct {
+	value string
+}
+
+func NewEventId(uuid string) *EventId {
+	eventId := new(EventId)
+	eventId.value = uuid
+	return eventId
+}
+
+func (r *EventId) Value() string {
+	return r.value
+}
+
+func (r *EventId) Equals(other *EventId) bool {
+	return reflect.DeepEqual(r.value, other.value)
+}
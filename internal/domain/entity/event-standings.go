@@ -0,0 +1,156 @@
+package entity
+
+import (
+	"sort"
+	"strings"
+)
+
+// StandingsRow is the record of one team over the games of an event.
+// The draw shot challenge is the average of the team's last-stone draws with the worst one dropped
+// when there are two or more; BestLSD is its closest single draw. Both are nil without any measured draw.
+type StandingsRow struct {
+	Rank              int
+	Team              string
+	Friend            bool // our team, as opposed to an opponent
+	Games             int
+	Wins              int
+	Losses            int
+	Draws             int
+	PointsFor         int
+	PointsAgainst     int
+	DrawShotChallenge *float64
+	BestLSD           *float64
+	Eliminated        bool // lost a game of a knockout event
+	lsds              []float64
+}
+
+// Standings ranks the teams of an event from the games we played in it, so opponents only appear with
+// their games against us. Abandoned games are left out and counted apart.
+type Standings struct {
+	Format    EventFormat
+	Rows      []StandingsRow
+	Abandoned int
+}
+
+// Standings computes the standings of the event from its attached records.
+// Teams are ranked on wins, then losses, then draw shot challenge and then best last-stone draw;
+// in a knockout event, teams still in the event come first.
+func (e *Event) Standings(teamName string, records []Record) Standings {
+	games := make(map[string]EventGame, len(e.games))
+	for _, game := range e.games {
+		games[game.RecordId] = game
+	}
+
+	standings := Standings{Format: e.format}
+	friend := &StandingsRow{Team: teamName, Friend: true}
+	var keys []string
+	opponents := map[string]*StandingsRow{}
+	for _, record := range records {
+		game, ok := games[record.GetId().Value()]
+		if !ok {
+			continue
+		}
+		if !record.GetStatus().IsFinished() {
+			standings.Abandoned++
+			continue
+		}
+
+		key := NormalizeTeamName(record.GetEnemyTeamName())
+		opponent, ok := opponents[key]
+		if !ok {
+			opponent = &StandingsRow{Team: strings.TrimSpace(record.GetEnemyTeamName())}
+			opponents[key] = opponent
+			keys = append(keys, key)
+		}
+
+		scoreboard := record.Scoreboard()
+		friend.add(record.GetResult(), scoreboard.FriendTotal, scoreboard.EnemyTotal, game.FriendLSD)
+		opponent.add(record.GetResult().opposite(), scoreboard.EnemyTotal, scoreboard.FriendTotal, game.EnemyLSD)
+	}
+
+	standings.Rows = append(standings.Rows, friend.withTiebreakers())
+	for _, key := range keys {
+		standings.Rows = append(standings.Rows, opponents[key].withTiebreakers())
+	}
+
+	knockout := e.format == EventKnockout
+	less := func(a, b StandingsRow) bool {
+		switch {
+		case knockout && a.Eliminated != b.Eliminated:
+			return !a.Eliminated
+		case a.Wins != b.Wins:
+			return a.Wins > b.Wins
+		case a.Losses != b.Losses:
+			return a.Losses < b.Losses
+		case !equalDistance(a.DrawShotChallenge, b.DrawShotChallenge):
+			return closer(a.DrawShotChallenge, b.DrawShotChallenge)
+		case !equalDistance(a.BestLSD, b.BestLSD):
+			return closer(a.BestLSD, b.BestLSD)
+		}
+		return false
+	}
+	sort.SliceStable(standings.Rows, func(i, j int) bool { return less(standings.Rows[i], standings.Rows[j]) })
+	for i := range standings.Rows {
+		standings.Rows[i].Rank = i + 1
+		// teams tied on every criterion share a rank
+		if i > 0 && !less(standings.Rows[i-1], standings.Rows[i]) {
+			standings.Rows[i].Rank = standings.Rows[i-1].Rank
+		}
+	}
+
+	return standings
+}
+
+func (r *StandingsRow) add(result Result, pointsFor, pointsAgainst int, lsd *float64) {
+	r.Games++
+	switch result {
+	case Win:
+		r.Wins++
+	case Loss:
+		r.Losses++
+		r.Eliminated = true
+	case Draw:
+		r.Draws++
+	}
+	r.PointsFor += pointsFor
+	r.PointsAgainst += pointsAgainst
+	if lsd != nil {
+		r.lsds = append(r.lsds, *lsd)
+	}
+}
+
+func (r *StandingsRow) withTiebreakers() StandingsRow {
+	if len(r.lsds) == 0 {
+		return *r
+	}
+	lsds := append([]float64(nil), r.lsds...)
+	sort.Float64s(lsds)
+	best := lsds[0]
+	r.BestLSD = &best
+
+	if len(lsds) >= 2 {
+		lsds = lsds[:len(lsds)-1]
+	}
+	sum := 0.0
+	for _, lsd := range lsds {
+		sum += lsd
+	}
+	dsc := sum / float64(len(lsds))
+	r.DrawShotChallenge = &dsc
+	return *r
+}
+
+// closer tells whether a distance is smaller than another, a missing one being the largest.
+func closer(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a != nil
+	}
+	return *a < *b
+}
+
+func equalDistance(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
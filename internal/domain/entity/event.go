@@ -0,0 +1,213 @@
+package entity
+
+import (
+	"CurlARC/internal/domain/domainerr"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventFormat is how the games of an event are drawn.
+type EventFormat string
+
+const (
+	EventRoundRobin EventFormat = "ROUND_ROBIN"
+	EventKnockout   EventFormat = "KNOCKOUT"
+)
+
+func (f EventFormat) IsValid() bool {
+	return f == EventRoundRobin || f == EventKnockout
+}
+
+// EventGame attaches a record to an event. Round is free text such as "Draw 3" or "Semi-final".
+// The last-stone draws are the distances to the button, in metres, of the draws thrown before the game
+// to decide hammer; they are nil when not measured.
+type EventGame struct {
+	RecordId  string
+	Round     string
+	FriendLSD *float64
+	EnemyLSD  *float64
+}
+
+func (g EventGame) validate() error {
+	if g.RecordId == "" {
+		return domainerr.Invalid("record_id", "record id is required")
+	}
+	for _, lsd := range []*float64{g.FriendLSD, g.EnemyLSD} {
+		if lsd != nil && *lsd < 0 {
+			return domainerr.Invalid("lsd", "a last-stone draw cannot be negative")
+		}
+	}
+	return nil
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////
+// Event domain model
+//////////////////////////////////////////////////////////////////////////////////////////
+
+// Event is a league or bonspiel a team plays, made of the records attached to it.
+type Event struct {
+	id        EventId
+	teamId    string
+	name      string
+	venue     string
+	startDate time.Time
+	endDate   time.Time
+	format    EventFormat
+	games     []EventGame
+}
+
+// EventOption is a functional option for creating a new Event.
+type EventOption func(*Event) error
+
+func WithEventVenue(venue string) EventOption {
+	return func(e *Event) error {
+		e.SetVenue(venue)
+		return nil
+	}
+}
+
+func WithEventDates(startDate, endDate time.Time) EventOption {
+	return func(e *Event) error {
+		return e.SetDates(startDate, endDate)
+	}
+}
+
+func WithEventGames(games []EventGame) EventOption {
+	return func(e *Event) error {
+		for _, game := range games {
+			if err := e.AttachGame(game); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func NewEvent(teamId, name string, format EventFormat, options ...EventOption) (*Event, error) {
+	event := &Event{
+		id:     *NewEventId(uuid.New().String()),
+		teamId: teamId,
+	}
+	if err := event.SetName(name); err != nil {
+		return nil, err
+	}
+	if err := event.SetFormat(format); err != nil {
+		return nil, err
+	}
+
+	for _, opt := range options {
+		if err := opt(event); err != nil {
+			return nil, err
+		}
+	}
+
+	return event, nil
+}
+
+func NewEventFromDB(id, teamId, name, venue string, startDate, endDate time.Time, format EventFormat, games []EventGame) *Event {
+	return &Event{
+		id:        *NewEventId(id),
+		teamId:    teamId,
+		name:      name,
+		venue:     venue,
+		startDate: startDate,
+		endDate:   endDate,
+		format:    format,
+		games:     games,
+	}
+}
+
+// AttachGame adds a record to the event, or replaces its round and last-stone draws when it is already attached.
+func (e *Event) AttachGame(game EventGame) error {
+	if err := game.validate(); err != nil {
+		return err
+	}
+	for i := range e.games {
+		if e.games[i].RecordId == game.RecordId {
+			e.games[i] = game
+			return nil
+		}
+	}
+	e.games = append(e.games, game)
+	return nil
+}
+
+// DetachGame removes a record from the event.
+func (e *Event) DetachGame(recordId string) error {
+	for i, game := range e.games {
+		if game.RecordId == recordId {
+			e.games = append(e.games[:i], e.games[i+1:]...)
+			return nil
+		}
+	}
+	return domainerr.NotFound("RECORD_NOT_ATTACHED", "record is not attached to the event")
+}
+
+// getter
+
+func (e *Event) GetId() *EventId {
+	return &e.id
+}
+
+func (e *Event) GetTeamId() string {
+	return e.teamId
+}
+
+func (e *Event) GetName() string {
+	return e.name
+}
+
+func (e *Event) GetVenue() string {
+	return e.venue
+}
+
+func (e *Event) GetStartDate() time.Time {
+	return e.startDate
+}
+
+func (e *Event) GetEndDate() time.Time {
+	return e.endDate
+}
+
+func (e *Event) GetFormat() EventFormat {
+	return e.format
+}
+
+func (e *Event) GetGames() []EventGame {
+	return e.games
+}
+
+// setter
+
+func (e *Event) SetName(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return domainerr.Invalid("name", "event name is required")
+	}
+	e.name = name
+	return nil
+}
+
+func (e *Event) SetVenue(venue string) {
+	e.venue = strings.TrimSpace(venue)
+}
+
+// SetDates sets when the event is played. Either date can be left zero while it is not known.
+func (e *Event) SetDates(startDate, endDate time.Time) error {
+	if !startDate.IsZero() && !endDate.IsZero() && endDate.Before(startDate) {
+		return domainerr.Invalid("end_date", "the event cannot end before it starts")
+	}
+	e.startDate = startDate
+	e.endDate = endDate
+	return nil
+}
+
+func (e *Event) SetFormat(format EventFormat) error {
+	if !format.IsValid() {
+		return domainerr.Invalid("format", "unknown event format %q", format)
+	}
+	e.format = format
+	return nil
+}
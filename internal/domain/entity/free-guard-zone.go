@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"CurlARC/internal/domain/domainerr"
+)
+
+// FreeGuardZoneRule protects stones from being removed from play early in an end.
+// In four-player games it is the five-rock rule: an opponent stone in the free guard zone cannot be removed
+// before the sixth stone of the end. In mixed doubles no stone at all can be removed before the fourth stone.
+type FreeGuardZoneRule struct {
+	ProtectedDeliveries int  // deliveries of the end, counting both teams, which may not remove a protected stone
+	AllStones           bool // every stone is protected, not only the opponent stones in the free guard zone
+}
+
+// FreeGuardZoneViolation flags a shot during which a protected stone was removed from play.
+type FreeGuardZoneViolation struct {
+	Delivery            int   `json:"delivery"`  // number of the offending stone in the end, counting both teams
+	ByFriend            bool  `json:"by_friend"` // whether our team delivered the offending stone
+	RemovedFriendStones []int `json:"removed_friend_stones,omitempty"`
+	RemovedEnemyStones  []int `json:"removed_enemy_stones,omitempty"`
+	Restored            bool  `json:"restored,omitempty"` // the positions before the offending delivery were put back
+}
+
+// FreeGuardZonePolicy tells what to do with a shot breaking the free guard zone rule.
+type FreeGuardZonePolicy string
+
+const (
+	FreeGuardZoneFlag    FreeGuardZonePolicy = "FLAG"    // keep the shot as recorded and flag it
+	FreeGuardZoneReject  FreeGuardZonePolicy = "REJECT"  // refuse the ends data
+	FreeGuardZoneRestore FreeGuardZonePolicy = "RESTORE" // take the offending stone out and put the others back
+)
+
+// ParseFreeGuardZonePolicy validates a requested policy. An empty string flags violations.
+func ParseFreeGuardZonePolicy(raw string) (FreeGuardZonePolicy, error) {
+	switch policy := FreeGuardZonePolicy(raw); policy {
+	case "":
+		return FreeGuardZoneFlag, nil
+	case FreeGuardZoneFlag, FreeGuardZoneReject, FreeGuardZoneRestore:
+		return policy, nil
+	}
+	return "", domainerr.Invalid("free_guard_zone", "unknown free guard zone policy %q", raw)
+}
@@ -0,0 +1,85 @@
+package entity
+
+// GameFormat is the kind of game a record was played under.
+type GameFormat string
+
+const (
+	GameFormatEightEnds    GameFormat = "FOUR_PLAYER_8_ENDS"
+	GameFormatTenEnds      GameFormat = "FOUR_PLAYER_10_ENDS"
+	GameFormatMixedDoubles GameFormat = "MIXED_DOUBLES"
+)
+
+// DefaultGameFormat is the format of records created before formats existed.
+const DefaultGameFormat = GameFormatEightEnds
+
+// gameFormatRules holds the rules of every format.
+// In mixed doubles each team throws 5 stones and has one more placed before the end starts,
+// a blank end passes the hammer to the other team, and each team may call one power play per game.
+var gameFormatRules = map[GameFormat]endsDataRules{
+	GameFormatEightEnds: {
+		ends:             8,
+		shotsPerEnd:      8,
+		stonesPerTeam:    8,
+		blankKeepsHammer: true,
+		freeGuardZone:    FreeGuardZoneRule{ProtectedDeliveries: 5},
+	},
+	GameFormatTenEnds: {
+		ends:             10,
+		shotsPerEnd:      8,
+		stonesPerTeam:    8,
+		blankKeepsHammer: true,
+		freeGuardZone:    FreeGuardZoneRule{ProtectedDeliveries: 5},
+	},
+	GameFormatMixedDoubles: {
+		ends:             8,
+		shotsPerEnd:      5,
+		stonesPerTeam:    6,
+		prePlacedStones:  1,
+		blankKeepsHammer: false,
+		powerPlays:       1,
+		freeGuardZone:    FreeGuardZoneRule{ProtectedDeliveries: 3, AllStones: true},
+	},
+}
+
+// GameFormats returns every supported format.
+func GameFormats() []GameFormat {
+	return []GameFormat{GameFormatEightEnds, GameFormatTenEnds, GameFormatMixedDoubles}
+}
+
+func (f GameFormat) IsValid() bool {
+	_, ok := gameFormatRules[f]
+	return ok
+}
+
+func (f GameFormat) rules() endsDataRules {
+	if rules, ok := gameFormatRules[f]; ok {
+		return rules
+	}
+	return gameFormatRules[DefaultGameFormat]
+}
+
+// Ends returns the number of regulation ends.
+func (f GameFormat) Ends() int {
+	return f.rules().ends
+}
+
+// ShotsPerEnd returns the number of stones each team throws in an end.
+func (f GameFormat) ShotsPerEnd() int {
+	return f.rules().shotsPerEnd
+}
+
+// StonesPerTeam returns the number of stones each team can have in play, pre-placed stones included.
+func (f GameFormat) StonesPerTeam() int {
+	return f.rules().stonesPerTeam
+}
+
+// FreeGuardZone returns the rule protecting stones early in an end.
+func (f GameFormat) FreeGuardZone() FreeGuardZoneRule {
+	return f.rules().freeGuardZone
+}
+
+// NextHammer tells whether our team has hammer in the next end given the score of this one.
+// The team scored on gets the hammer; after a blank end it stays or passes depending on the format.
+func (f GameFormat) NextHammer(friendHammer bool, score int) bool {
+	return f.rules().nextHammer(friendHammer, score)
+}
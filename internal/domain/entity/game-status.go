@@ -0,0 +1,22 @@
+package entity
+
+// GameStatus tells how a game ended.
+type GameStatus string
+
+const (
+	// GameCompleted is a game played to the end, extra ends included.
+	GameCompleted GameStatus = "COMPLETED"
+	// GameConceded is a game one team conceded before the last end. The team that lost conceded.
+	GameConceded GameStatus = "CONCEDED"
+	// GameAbandoned is a game stopped without a result, e.g. when the ice time ran out.
+	GameAbandoned GameStatus = "ABANDONED"
+)
+
+func (s GameStatus) IsValid() bool {
+	return s == GameCompleted || s == GameConceded || s == GameAbandoned
+}
+
+// IsFinished tells whether the game reached a result which counts in win/loss records.
+func (s GameStatus) IsFinished() bool {
+	return s != GameAbandoned
+}
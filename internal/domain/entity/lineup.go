@@ -0,0 +1,182 @@
+package entity
+
+import (
+	"CurlARC/internal/domain/domainerr"
+	"fmt"
+	"strings"
+)
+
+// Position is a place in a team's lineup. Lead to fourth are the throwing order,
+// skip and vice are the roles in charge of the house, and alternates are substitutes.
+type Position string
+
+const (
+	PositionLead      Position = "LEAD"
+	PositionSecond    Position = "SECOND"
+	PositionThird     Position = "THIRD"
+	PositionFourth    Position = "FOURTH"
+	PositionSkip      Position = "SKIP"
+	PositionVice      Position = "VICE"
+	PositionAlternate Position = "ALTERNATE"
+)
+
+func (p Position) IsValid() bool {
+	switch p {
+	case PositionLead, PositionSecond, PositionThird, PositionFourth, PositionSkip, PositionVice, PositionAlternate:
+		return true
+	}
+	return false
+}
+
+// LineupPlayer is either a registered user or a guest known only by name.
+type LineupPlayer struct {
+	UserId    string `json:"user_id,omitempty"`
+	GuestName string `json:"guest_name,omitempty"`
+}
+
+// Key identifies the player across records: the user ID for registered users, the normalised name for guests.
+func (p LineupPlayer) Key() string {
+	if p.UserId != "" {
+		return "user:" + p.UserId
+	}
+	return "guest:" + NormalizeTeamName(p.GuestName)
+}
+
+func (p LineupPlayer) validate() error {
+	if (p.UserId == "") == (strings.TrimSpace(p.GuestName) == "") {
+		return domainerr.Invalid("user_id", "a lineup player needs either a user id or a guest name")
+	}
+	return nil
+}
+
+func (p LineupPlayer) matches(shooter string) bool {
+	if p.UserId != "" {
+		return p.UserId == strings.TrimSpace(shooter)
+	}
+	return NormalizeTeamName(p.GuestName) == NormalizeTeamName(shooter)
+}
+
+type LineupEntry struct {
+	Position Position `json:"position"`
+	LineupPlayer
+}
+
+// Substitution replaces the player at a position from the given end and shot onwards.
+// Shot 0 means the substitute plays the whole end.
+type Substitution struct {
+	End      int      `json:"end"`
+	Shot     int      `json:"shot,omitempty"`
+	Position Position `json:"position"`
+	LineupPlayer
+}
+
+func (s Substitution) appliesTo(end, shot int) bool {
+	return s.End < end || (s.End == end && s.Shot <= shot)
+}
+
+// Lineup is the team sheet of a record.
+type Lineup struct {
+	Entries       []LineupEntry  `json:"entries"`
+	Substitutions []Substitution `json:"substitutions,omitempty"`
+}
+
+func (l Lineup) IsEmpty() bool {
+	return len(l.Entries) == 0
+}
+
+// Validate checks every position appears at most once and every player is identified.
+func (l Lineup) Validate() error {
+	positions := map[Position]bool{}
+	for _, entry := range l.Entries {
+		if !entry.Position.IsValid() {
+			return domainerr.Invalid("position", "unknown position %q", entry.Position)
+		}
+		if positions[entry.Position] && entry.Position != PositionAlternate {
+			return domainerr.Invalid("position", "position %s is assigned twice", entry.Position)
+		}
+		positions[entry.Position] = true
+		if err := entry.validate(); err != nil {
+			return fmt.Errorf("%s: %w", entry.Position, err)
+		}
+	}
+
+	for _, sub := range l.Substitutions {
+		if err := l.validateSubstitution(sub, positions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l Lineup) validateSubstitution(sub Substitution, positions map[Position]bool) error {
+	if !positions[sub.Position] {
+		return domainerr.Invalid("substitutions", "substitution at end %d: position %s is not in the lineup", sub.End, sub.Position)
+	}
+	if sub.End < 1 || sub.Shot < 0 {
+		return domainerr.Invalid("substitutions", "substitution at end %d shot %d: end must be at least 1 and shot cannot be negative", sub.End, sub.Shot)
+	}
+	if err := sub.validate(); err != nil {
+		return fmt.Errorf("substitution at end %d: %w", sub.End, err)
+	}
+	return nil
+}
+
+// PlayersAt returns who plays each position at the given end and shot, substitutions included.
+func (l Lineup) PlayersAt(end, shot int) map[Position]LineupPlayer {
+	players := make(map[Position]LineupPlayer, len(l.Entries))
+	for _, entry := range l.Entries {
+		players[entry.Position] = entry.LineupPlayer
+	}
+	for _, sub := range l.Substitutions {
+		if sub.appliesTo(end, shot) {
+			players[sub.Position] = sub.LineupPlayer
+		}
+	}
+	return players
+}
+
+// Resolve finds the player behind a shot's shooter. The shooter can be written as a position ("Lead", "skip"),
+// a user ID or a guest name, and positions are resolved with the substitutions made before the shot.
+func (l Lineup) Resolve(end, shot int, shooter string) (LineupPlayer, bool) {
+	players := l.PlayersAt(end, shot)
+	if player, ok := players[Position(strings.ToUpper(strings.TrimSpace(shooter)))]; ok {
+		return player, true
+	}
+
+	for _, player := range players {
+		if player.matches(shooter) {
+			return player, true
+		}
+	}
+	// a player may have been substituted out, or may come in later in the game
+	for _, entry := range l.Entries {
+		if entry.matches(shooter) {
+			return entry.LineupPlayer, true
+		}
+	}
+	for _, sub := range l.Substitutions {
+		if sub.matches(shooter) {
+			return sub.LineupPlayer, true
+		}
+	}
+	return LineupPlayer{}, false
+}
+
+// UserIds returns the registered users appearing in the lineup or its substitutions.
+func (l Lineup) UserIds() []string {
+	seen := map[string]bool{}
+	var userIds []string
+	add := func(p LineupPlayer) {
+		if p.UserId != "" && !seen[p.UserId] {
+			seen[p.UserId] = true
+			userIds = append(userIds, p.UserId)
+		}
+	}
+	for _, entry := range l.Entries {
+		add(entry.LineupPlayer)
+	}
+	for _, sub := range l.Substitutions {
+		add(sub.LineupPlayer)
+	}
+	return userIds
+}
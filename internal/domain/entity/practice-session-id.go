@@ -0,0 +1,21 @@
+package entity
+
+import "reflect"
+
+type PracticeSessionId struct {
+	value string
+}
+
+func NewPracticeSessionId(uuid string) *PracticeSessionId {
+	practiceSessionId := new(PracticeSessionId)
+	practiceSessionId.value = uuid
+	return practiceSessionId
+}
+
+func (r *PracticeSessionId) Value() string {
+	return r.value
+}
+
+func (r *PracticeSessionId) Equals(other *PracticeSessionId) bool {
+	return reflect.DeepEqual(r.value, other.value)
+}
@@ -0,0 +1,234 @@
+package entity
+
+import (
+	"CurlARC/internal/domain/domainerr"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DrillType is an exercise thrown during practice.
+type DrillType string
+
+const (
+	DrillDrawToButton    DrillType = "DRAW_TO_BUTTON"   // draws measured by how far from the button they stop
+	DrillTakeoutAccuracy DrillType = "TAKEOUT_ACCURACY" // takeouts judged by their success rate
+	DrillWeightControl   DrillType = "WEIGHT_CONTROL"   // draws measured by how far from a target spot they stop
+)
+
+func (t DrillType) IsValid() bool {
+	return t == DrillDrawToButton || t == DrillTakeoutAccuracy || t == DrillWeightControl
+}
+
+// IsPositional tells whether the throws of the drill are measured by where the stone comes to rest.
+func (t DrillType) IsPositional() bool {
+	return t == DrillDrawToButton || t == DrillWeightControl
+}
+
+// PracticeThrow is one delivery of a drill. The shot is recorded as in a game, its stones being the layout
+// after the throw; Stone is where the delivered stone came to rest and is nil when it left play.
+type PracticeThrow struct {
+	Shot
+	Stone *Coordinate `json:"stone,omitempty"`
+}
+
+// Drill is a series of throws of one exercise. Target is the spot aimed at in a weight control drill;
+// a draw to the button aims at the button.
+type Drill struct {
+	Type   DrillType       `json:"type"`
+	Target *Coordinate     `json:"target,omitempty"`
+	Throws []PracticeThrow `json:"throws"`
+}
+
+// TargetOf returns the spot the throws of the drill aim at.
+func (d Drill) TargetOf() Coordinate {
+	if d.Type == DrillWeightControl && d.Target != nil {
+		return *d.Target
+	}
+	return Coordinate{}
+}
+
+// validate checks the drill, numbered from 0 in the session.
+func (d Drill) validate(n int) error {
+	invalid := func(field, format string, args ...interface{}) error {
+		return domainerr.Invalid(fmt.Sprintf("drills[%d].%s", n, field), "drill %d: "+format, append([]interface{}{n + 1}, args...)...)
+	}
+	if !d.Type.IsValid() {
+		return invalid("type", "invalid drill type: %s", d.Type)
+	}
+	if d.Type == DrillWeightControl && d.Target == nil {
+		return invalid("target", "a weight control drill needs a target")
+	}
+	if d.Target != nil && d.Target.R < 0 {
+		return invalid("target", "the target distance cannot be negative")
+	}
+	for i, throw := range d.Throws {
+		switch {
+		case strings.TrimSpace(throw.Shooter) == "":
+			return invalid(fmt.Sprintf("throws[%d].shooter", i), "throw %d: shooter is required", i+1)
+		case throw.Type != "" && !throw.Type.IsValid():
+			return invalid(fmt.Sprintf("throws[%d].type", i), "throw %d: invalid shot type: %s", i+1, throw.Type)
+		case throw.Rotation != "" && !throw.Rotation.IsValid():
+			return invalid(fmt.Sprintf("throws[%d].rotation", i), "throw %d: invalid rotation: %s", i+1, throw.Rotation)
+		case throw.SuccessRate < 0 || throw.SuccessRate > 1 || math.IsNaN(throw.SuccessRate):
+			return invalid(fmt.Sprintf("throws[%d].success_rate", i), "throw %d: success rate must be between 0 and 1, got %v", i+1, throw.SuccessRate)
+		case throw.Stone != nil && throw.Stone.R < 0:
+			return invalid(fmt.Sprintf("throws[%d].stone", i), "throw %d: the stone distance cannot be negative", i+1)
+		}
+	}
+	return nil
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////
+// PracticeSession domain model
+//////////////////////////////////////////////////////////////////////////////////////////
+
+// PracticeSession is a training session of a team: who attended and the drills they threw.
+// It is kept apart from records, which are games against an opponent.
+type PracticeSession struct {
+	id        PracticeSessionId
+	teamId    string
+	date      time.Time
+	place     string
+	attendees []LineupPlayer
+	drills    []Drill
+}
+
+// PracticeSessionOption is a functional option for creating a new PracticeSession.
+type PracticeSessionOption func(*PracticeSession) error
+
+func WithPracticePlace(place string) PracticeSessionOption {
+	return func(s *PracticeSession) error {
+		s.SetPlace(place)
+		return nil
+	}
+}
+
+func WithAttendees(attendees []LineupPlayer) PracticeSessionOption {
+	return func(s *PracticeSession) error {
+		return s.SetAttendees(attendees)
+	}
+}
+
+func WithDrills(drills []Drill) PracticeSessionOption {
+	return func(s *PracticeSession) error {
+		return s.SetDrills(drills)
+	}
+}
+
+func NewPracticeSession(teamId string, date time.Time, opts ...PracticeSessionOption) (*PracticeSession, error) {
+	session := &PracticeSession{
+		id:     *NewPracticeSessionId(uuid.New().String()),
+		teamId: teamId,
+	}
+	if err := session.SetDate(date); err != nil {
+		return nil, err
+	}
+	for _, opt := range opts {
+		if err := opt(session); err != nil {
+			return nil, err
+		}
+	}
+	return session, nil
+}
+
+func NewPracticeSessionFromDB(id, teamId string, date time.Time, place string, attendees []LineupPlayer, drills []Drill) *PracticeSession {
+	return &PracticeSession{
+		id:        *NewPracticeSessionId(id),
+		teamId:    teamId,
+		date:      date,
+		place:     place,
+		attendees: attendees,
+		drills:    drills,
+	}
+}
+
+// ResolveShooter finds the attendee behind a throw's shooter, written as a user ID or a guest name.
+func (s *PracticeSession) ResolveShooter(shooter string) (LineupPlayer, bool) {
+	for _, attendee := range s.attendees {
+		if attendee.matches(shooter) {
+			return attendee, true
+		}
+	}
+	return LineupPlayer{}, false
+}
+
+// AttendeeUserIds returns the registered users who attended the session.
+func (s *PracticeSession) AttendeeUserIds() []string {
+	var userIds []string
+	for _, attendee := range s.attendees {
+		if attendee.UserId != "" {
+			userIds = append(userIds, attendee.UserId)
+		}
+	}
+	return userIds
+}
+
+// getter
+
+func (s *PracticeSession) GetId() *PracticeSessionId {
+	return &s.id
+}
+
+func (s *PracticeSession) GetTeamId() string {
+	return s.teamId
+}
+
+func (s *PracticeSession) GetDate() time.Time {
+	return s.date
+}
+
+func (s *PracticeSession) GetPlace() string {
+	return s.place
+}
+
+func (s *PracticeSession) GetAttendees() []LineupPlayer {
+	return s.attendees
+}
+
+func (s *PracticeSession) GetDrills() []Drill {
+	return s.drills
+}
+
+// setter
+
+func (s *PracticeSession) SetDate(date time.Time) error {
+	if date.IsZero() {
+		return domainerr.Invalid("date", "practice date is required")
+	}
+	s.date = date
+	return nil
+}
+
+func (s *PracticeSession) SetPlace(place string) {
+	s.place = strings.TrimSpace(place)
+}
+
+// SetAttendees replaces who attended the session. Every attendee is listed once.
+func (s *PracticeSession) SetAttendees(attendees []LineupPlayer) error {
+	seen := map[string]bool{}
+	for _, attendee := range attendees {
+		if err := attendee.validate(); err != nil {
+			return err
+		}
+		if seen[attendee.Key()] {
+			return domainerr.Invalid("attendees", "an attendee is listed more than once")
+		}
+		seen[attendee.Key()] = true
+	}
+	s.attendees = attendees
+	return nil
+}
+
+func (s *PracticeSession) SetDrills(drills []Drill) error {
+	for i, drill := range drills {
+		if err := drill.validate(i); err != nil {
+			return err
+		}
+	}
+	s.drills = drills
+	return nil
+}
@@ -0,0 +1,245 @@
+package entity
+
+import (
+	"CurlARC/internal/domain/domainerr"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// EndsDataViolation describes a single rule broken by the ends data of a record.
+// End and Shot are 1-based; Shot is 0 when the violation concerns the whole end.
+type EndsDataViolation struct {
+	End     int    `json:"end"`
+	Shot    int    `json:"shot,omitempty"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// EndsDataValidationError is returned when ends data breaks one or more curling rules.
+type EndsDataValidationError struct {
+	Violations []EndsDataViolation
+}
+
+func (e *EndsDataValidationError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		if v.Shot > 0 {
+			messages = append(messages, fmt.Sprintf("end %d shot %d: %s: %s", v.End, v.Shot, v.Field, v.Message))
+		} else {
+			messages = append(messages, fmt.Sprintf("end %d: %s: %s", v.End, v.Field, v.Message))
+		}
+	}
+	return "invalid ends data: " + strings.Join(messages, "; ")
+}
+
+// Unwrap returns the violations as a validation error of the domain, one field per violation.
+func (e *EndsDataValidationError) Unwrap() error {
+	fields := make([]domainerr.FieldError, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		field := fmt.Sprintf("ends_data[%d].%s", v.End-1, v.Field)
+		if v.Shot > 0 {
+			field = fmt.Sprintf("ends_data[%d].shots[%d].%s", v.End-1, v.Shot-1, v.Field)
+		}
+		fields = append(fields, domainerr.FieldError{Field: field, Message: v.Message})
+	}
+	err := domainerr.Validation(e.Error(), fields...)
+	err.Code = CodeInvalidEndsData
+	return err
+}
+
+// CodeInvalidEndsData is the error code of ends data breaking the rules of the game.
+const CodeInvalidEndsData = "INVALID_ENDS_DATA"
+
+// endsDataRules holds the limits the ends data of a game must satisfy.
+type endsDataRules struct {
+	ends             int  // regulation ends of a game
+	shotsPerEnd      int  // shots thrown by our team in one end
+	stonesPerTeam    int  // stones each team can have in play
+	prePlacedStones  int  // stones each team places before the first delivery
+	blankKeepsHammer bool // whether the team with hammer keeps it after a blank end
+	powerPlays       int  // power plays each team may call in a game
+	freeGuardZone    FreeGuardZoneRule
+}
+
+func (r endsDataRules) nextHammer(friendHammer bool, score int) bool {
+	switch {
+	case score > 0:
+		return false
+	case score < 0:
+		return true
+	case r.blankKeepsHammer:
+		return friendHammer
+	default:
+		return !friendHammer
+	}
+}
+
+// endsDataValidator collects violations while walking through the ends data.
+type endsDataValidator struct {
+	rules        endsDataRules
+	lineup       Lineup
+	stored       []DataPerEnd // the ends data the record holds already
+	friendHammer bool
+	powerPlays   map[bool]int // power plays called, keyed by whether our team called them
+	totals       map[bool]int // points scored so far, keyed by whether our team scored them
+	violations   []EndsDataViolation
+}
+
+func (v *endsDataValidator) add(end, shot int, field, format string, args ...interface{}) {
+	v.violations = append(v.violations, EndsDataViolation{
+		End:     end,
+		Shot:    shot,
+		Field:   field,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// storedShot returns the shot as the record holds it already, if it does.
+func (v *endsDataValidator) storedShot(endNo, shotNo int) (Shot, bool) {
+	if endNo > len(v.stored) || shotNo > len(v.stored[endNo-1].Shots) {
+		return Shot{}, false
+	}
+	return v.stored[endNo-1].Shots[shotNo-1], true
+}
+
+func (v *endsDataValidator) validateEnd(endNo int, end DataPerEnd) {
+	if len(end.Shots) > v.rules.shotsPerEnd {
+		v.add(endNo, 0, "shots", "an end cannot contain more than %d shots, got %d", v.rules.shotsPerEnd, len(end.Shots))
+	}
+	if end.Score > v.rules.stonesPerTeam || end.Score < -v.rules.stonesPerTeam {
+		v.add(endNo, 0, "score", "score must be between %d and %d, got %d", -v.rules.stonesPerTeam, v.rules.stonesPerTeam, end.Score)
+	}
+
+	if endNo > v.rules.ends && v.totals[true] != v.totals[false] {
+		v.add(endNo, 0, "end", "an extra end is only played when the score is tied, it was %d-%d", v.totals[true], v.totals[false])
+	}
+	v.validatePowerPlay(endNo, end.PowerPlay)
+
+	// stones which have left play cannot come back later in the same end
+	removedFriends := map[int]bool{}
+	removedEnemies := map[int]bool{}
+	var prev *Stones
+	if end.PrePlaced != nil {
+		v.validatePrePlaced(endNo, *end.PrePlaced)
+		prev = end.PrePlaced
+	}
+	for i, shot := range end.Shots {
+		shotNo := i + 1
+		// free-form values stored before the standard vocabulary are kept as they are until
+		// cmd/migrate-shot-types maps them; only values sent in this request are checked
+		stored, isStored := v.storedShot(endNo, shotNo)
+		if shot.Type != "" && !shot.Type.IsValid() && !(isStored && shot.Type == stored.Type) {
+			v.add(endNo, shotNo, "type", "unknown shot type %q", shot.Type)
+		}
+		if shot.Rotation != "" && !shot.Rotation.IsValid() && !(isStored && shot.Rotation == stored.Rotation) {
+			v.add(endNo, shotNo, "rotation", "rotation must be %s or %s, got %q", InTurn, OutTurn, shot.Rotation)
+		}
+		if !v.lineup.IsEmpty() && strings.TrimSpace(shot.Shooter) != "" {
+			if _, ok := v.lineup.Resolve(endNo, shotNo, shot.Shooter); !ok {
+				v.add(endNo, shotNo, "shooter", "shooter %q is not in the lineup", shot.Shooter)
+			}
+		}
+		if shot.SuccessRate < 0 || shot.SuccessRate > 1 || math.IsNaN(shot.SuccessRate) {
+			v.add(endNo, shotNo, "success_rate", "success rate must be between 0 and 1, got %v", shot.SuccessRate)
+		}
+		v.validateStones(endNo, shotNo, "friend_stones", shot.Stones.FriendStones, removedFriends)
+		v.validateStones(endNo, shotNo, "enemy_stones", shot.Stones.EnemyStones, removedEnemies)
+		if total := len(shot.Stones.FriendStones) + len(shot.Stones.EnemyStones); total > 2*v.rules.stonesPerTeam {
+			v.add(endNo, shotNo, "stones", "at most %d stones can be in play, got %d", 2*v.rules.stonesPerTeam, total)
+		}
+
+		if prev != nil {
+			markRemoved(prev.FriendStones, shot.Stones.FriendStones, removedFriends)
+			markRemoved(prev.EnemyStones, shot.Stones.EnemyStones, removedEnemies)
+		}
+		prev = &end.Shots[i].Stones
+	}
+
+	// the team which scored must have at least as many stones in play as points
+	if prev != nil {
+		if end.Score > 0 && len(prev.FriendStones) < end.Score {
+			v.add(endNo, 0, "score", "score %d needs at least %d friend stones in the final position, got %d", end.Score, end.Score, len(prev.FriendStones))
+		}
+		if end.Score < 0 && len(prev.EnemyStones) < -end.Score {
+			v.add(endNo, 0, "score", "score %d needs at least %d enemy stones in the final position, got %d", end.Score, -end.Score, len(prev.EnemyStones))
+		}
+	}
+
+	v.friendHammer = v.rules.nextHammer(v.friendHammer, end.Score)
+	if end.Score > 0 {
+		v.totals[true] += end.Score
+	} else {
+		v.totals[false] -= end.Score
+	}
+}
+
+// validatePowerPlay checks a power play is allowed by the format, called in a regulation end
+// and not more often than the format allows. Only the team with hammer can call it.
+func (v *endsDataValidator) validatePowerPlay(endNo int, powerPlay bool) {
+	if !powerPlay {
+		return
+	}
+	if v.rules.powerPlays == 0 {
+		v.add(endNo, 0, "power_play", "the game format has no power play")
+		return
+	}
+	if endNo > v.rules.ends {
+		v.add(endNo, 0, "power_play", "a power play cannot be called in an extra end")
+		return
+	}
+	v.powerPlays[v.friendHammer]++
+	if v.powerPlays[v.friendHammer] > v.rules.powerPlays {
+		v.add(endNo, 0, "power_play", "each team can call at most %d power play per game", v.rules.powerPlays)
+	}
+}
+
+func (v *endsDataValidator) validatePrePlaced(endNo int, stones Stones) {
+	if v.rules.prePlacedStones == 0 {
+		v.add(endNo, 0, "pre_placed", "the game format has no pre-placed stones")
+		return
+	}
+	if len(stones.FriendStones) > v.rules.prePlacedStones || len(stones.EnemyStones) > v.rules.prePlacedStones {
+		v.add(endNo, 0, "pre_placed", "each team places at most %d stone before the end", v.rules.prePlacedStones)
+	}
+	v.validateStones(endNo, 0, "pre_placed.friend_stones", stones.FriendStones, map[int]bool{})
+	v.validateStones(endNo, 0, "pre_placed.enemy_stones", stones.EnemyStones, map[int]bool{})
+}
+
+func (v *endsDataValidator) validateStones(endNo, shotNo int, field string, stones []Coordinate, removed map[int]bool) {
+	if len(stones) > v.rules.stonesPerTeam {
+		v.add(endNo, shotNo, field, "at most %d stones per team, got %d", v.rules.stonesPerTeam, len(stones))
+	}
+
+	seen := map[int]bool{}
+	for _, stone := range stones {
+		if stone.Index < 1 || stone.Index > v.rules.stonesPerTeam {
+			v.add(endNo, shotNo, field, "stone index must be between 1 and %d, got %d", v.rules.stonesPerTeam, stone.Index)
+		} else if seen[stone.Index] {
+			v.add(endNo, shotNo, field, "stone index %d is duplicated", stone.Index)
+		} else if removed[stone.Index] {
+			v.add(endNo, shotNo, field, "stone %d was removed from play earlier in the end", stone.Index)
+		}
+		seen[stone.Index] = true
+
+		if math.IsNaN(stone.R) || stone.R < 0 || stone.R > MaxStoneDistance {
+			v.add(endNo, shotNo, field, "stone %d: r must be between 0 and %.3f, got %v", stone.Index, MaxStoneDistance, stone.R)
+		}
+		if math.IsNaN(stone.Theta) || stone.Theta < -2*math.Pi || stone.Theta > 2*math.Pi {
+			v.add(endNo, shotNo, field, "stone %d: theta must be between -2π and 2π, got %v", stone.Index, stone.Theta)
+		}
+	}
+}
+
+// markRemoved records the indices present in prev but missing from next.
+func markRemoved(prev, next []Coordinate, removed map[int]bool) {
+	present := make(map[int]bool, len(next))
+	for _, stone := range next {
+		present[stone.Index] = true
+	}
+	for _, stone := range prev {
+		if !present[stone.Index] {
+			removed[stone.Index] = true
+		}
+	}
+}
@@ -1,14 +1,17 @@
 package entity
 
 import (
+	"CurlARC/internal/domain/domainerr"
 	"encoding/json"
-	"errors"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/datatypes"
 )
 
+// Coordinate is the position of a stone in polar coordinates around the button.
+// R is the distance from the button in metres. Theta is in radians, counter-clockwise from the direction
+// to the right of the sheet as seen by the thrower, so π/2 points towards the hog line and -π/2 behind the tee line.
 type Coordinate struct {
 	Index int     `json:"index"`
 	R     float64 `json:"r"`
@@ -21,15 +24,53 @@ type Stones struct {
 }
 
 type Shot struct {
-	Type        string  `json:"type"`
-	SuccessRate float64 `json:"success_rate"`
-	Shooter     string  `json:"shooter"`
-	Stones      Stones  `json:"stones"`
+	Type        ShotType `json:"type"`
+	Rotation    Rotation `json:"rotation,omitempty"`
+	SuccessRate float64  `json:"success_rate"`
+	Shooter     string   `json:"shooter"`
+	Stones      Stones   `json:"stones"`
+
+	// FreeGuardZoneViolation is set when the shot broke the free guard zone rule.
+	FreeGuardZoneViolation *FreeGuardZoneViolation `json:"free_guard_zone_violation,omitempty"`
 }
 
 type DataPerEnd struct {
 	Score int    `json:"score"`
 	Shots []Shot `json:"shots"`
+
+	// ScoreFilled is set when no score was entered, so that Score is filled in from the stones once the end
+	// is complete. A score entered as 0 is a blank end and is never replaced.
+	ScoreFilled bool `json:"score_filled,omitempty"`
+
+	// ComputedScore is the score worked out from the final stone positions of a complete end.
+	// ScoreMismatch is set when it disagrees with the entered Score.
+	ComputedScore *int `json:"computed_score,omitempty"`
+	ScoreMismatch bool `json:"score_mismatch,omitempty"`
+
+	// PrePlaced holds the stones positioned before the first delivery in mixed doubles.
+	// PowerPlay is set when the team with hammer called a power play for the end.
+	PrePlaced *Stones `json:"pre_placed,omitempty"`
+	PowerPlay bool    `json:"power_play,omitempty"`
+}
+
+// UnmarshalJSON marks an end given without a score, or with a null one, as to be filled in from the stones.
+func (d *DataPerEnd) UnmarshalJSON(data []byte) error {
+	type plain DataPerEnd
+	var decoded struct {
+		plain
+		Score *int `json:"score"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*d = DataPerEnd(decoded.plain)
+	if decoded.Score == nil {
+		d.ScoreFilled = true
+	} else {
+		d.Score = *decoded.Score
+	}
+	return nil
 }
 
 type Result string
@@ -40,6 +81,20 @@ const (
 	Draw Result = "DRAW"
 )
 
+// opposite returns the result of the same game seen from the other team.
+func (r Result) opposite() Result {
+	switch r {
+	case Win:
+		return Loss
+	case Loss:
+		return Win
+	}
+	return r
+}
+
+// ErrPositionNotFound is returned when an end or shot does not exist in the record.
+var ErrPositionNotFound = domainerr.NotFound("POSITION_NOT_FOUND", "no such end or shot in the record")
+
 //////////////////////////////////////////////////////////////////////////////////////////
 // Record domain model
 //////////////////////////////////////////////////////////////////////////////////////////
@@ -55,6 +110,9 @@ type Record struct {
 	isRed         bool
 	isFirst       bool
 	isPublic      bool
+	lineup        Lineup
+	gameFormat    GameFormat
+	status        GameStatus
 }
 
 // RecordOption is a functional option for creating a new Record.
@@ -84,11 +142,43 @@ func WithDate(date time.Time) RecordOption {
 	}
 }
 
+func WithLineup(lineup Lineup) RecordOption {
+	return func(r *Record) error {
+		if err := lineup.Validate(); err != nil {
+			return err
+		}
+		r.lineup = lineup
+		return nil
+	}
+}
+
+// WithGameFormat sets the format of the game. An empty format selects the default one.
+func WithGameFormat(format GameFormat) RecordOption {
+	return func(r *Record) error {
+		if format == "" {
+			format = DefaultGameFormat
+		}
+		if !format.IsValid() {
+			return domainerr.Invalid("game_format", "unknown game format %q", format)
+		}
+		r.gameFormat = format
+		return nil
+	}
+}
+
+func WithStatus(status GameStatus) RecordOption {
+	return func(r *Record) error {
+		return r.SetStatus(status)
+	}
+}
+
 func NewRecord(teamId string, options ...RecordOption) (*Record, error) {
 	recordId := NewRecordId(uuid.New().String())
 	record := &Record{
-		id:     *recordId,
-		teamId: teamId,
+		id:         *recordId,
+		teamId:     teamId,
+		gameFormat: DefaultGameFormat,
+		status:     GameCompleted,
 	}
 
 	for _, opt := range options {
@@ -100,8 +190,10 @@ func NewRecord(teamId string, options ...RecordOption) (*Record, error) {
 	return record, nil
 }
 
-func NewRecordFromDB(id, teamId, enemyTeamName, place string, result Result, date time.Time, endsData []DataPerEnd, isRed, isFirst, isPublic bool) *Record {
-	return &Record{
+// NewRecordFromDB restores a record from the database. Options restore the optional parts of the record;
+// the stored data has already been validated, so their errors are ignored.
+func NewRecordFromDB(id, teamId, enemyTeamName, place string, result Result, date time.Time, endsData []DataPerEnd, isRed, isFirst, isPublic bool, options ...RecordOption) *Record {
+	record := &Record{
 		id:            *NewRecordId(id),
 		teamId:        teamId,
 		result:        result,
@@ -113,17 +205,76 @@ func NewRecordFromDB(id, teamId, enemyTeamName, place string, result Result, dat
 		isFirst:       isFirst,
 		isPublic:      isPublic,
 	}
+	for _, opt := range options {
+		_ = opt(record)
+	}
+	return record
 }
 
+// ValidateEndsData checks the ends data against curling rules and reports every violation found.
 func (r *Record) ValidateEndsData(endsData []DataPerEnd) error {
-	// for _, end := range endsData {
-	// 	if len(end.Shots) != 8 {
-	// 		return errors.New("each end must contains 8 shots")
-	// 	}
-	// }
+	validator := endsDataValidator{
+		rules:        r.GetGameFormat().rules(),
+		lineup:       r.lineup,
+		stored:       r.endsData,
+		friendHammer: !r.isFirst,
+		powerPlays:   map[bool]int{},
+		totals:       map[bool]int{},
+	}
+	for i, end := range endsData {
+		validator.validateEnd(i+1, end)
+	}
+	if len(validator.violations) > 0 {
+		return &EndsDataValidationError{Violations: validator.violations}
+	}
 	return nil
 }
 
+// RegulationEnds returns the number of ends of a game, without extra ends.
+func (r *Record) RegulationEnds() int {
+	return r.GetGameFormat().Ends()
+}
+
+// ExtraEnds returns the number of ends played after the regulation ends.
+func (r *Record) ExtraEnds() int {
+	if extra := len(r.endsData) - r.RegulationEnds(); extra > 0 {
+		return extra
+	}
+	return 0
+}
+
+// ShotsPerEnd returns the number of shots our team throws in a complete end.
+func (r *Record) ShotsPerEnd() int {
+	return r.GetGameFormat().ShotsPerEnd()
+}
+
+// End returns the data of an end, numbered from 1.
+func (r *Record) End(end int) (DataPerEnd, error) {
+	if end < 1 || end > len(r.endsData) {
+		return DataPerEnd{}, ErrPositionNotFound
+	}
+	return r.endsData[end-1], nil
+}
+
+// StonesAt returns the stones in play after a shot, numbered from 1.
+// Shot 0 is the position before the first delivery: the pre-placed stones, if any.
+func (r *Record) StonesAt(end, shot int) (Stones, error) {
+	data, err := r.End(end)
+	if err != nil {
+		return Stones{}, err
+	}
+	if shot == 0 {
+		if data.PrePlaced != nil {
+			return *data.PrePlaced, nil
+		}
+		return Stones{}, nil
+	}
+	if shot < 0 || shot > len(data.Shots) {
+		return Stones{}, ErrPositionNotFound
+	}
+	return data.Shots[shot-1].Stones, nil
+}
+
 // getter
 
 func (r *Record) GetId() *RecordId {
@@ -176,6 +327,26 @@ func (r *Record) IsPublic() bool {
 	return r.isPublic
 }
 
+func (r *Record) GetLineup() Lineup {
+	return r.lineup
+}
+
+// GetGameFormat returns the format of the game; records stored before formats existed use the default.
+func (r *Record) GetGameFormat() GameFormat {
+	if r.gameFormat == "" {
+		return DefaultGameFormat
+	}
+	return r.gameFormat
+}
+
+// GetStatus returns how the game ended; records stored before statuses existed are completed games.
+func (r *Record) GetStatus() GameStatus {
+	if r.status == "" {
+		return GameCompleted
+	}
+	return r.status
+}
+
 // setter
 
 func (r *Record) SetEnemyTeamName(name string) error {
@@ -196,7 +367,7 @@ func (r *Record) SetPlace(place string) error {
 // SetDate sets the date of the match. Future dates are not allowed.
 func (r *Record) SetDate(date time.Time) error {
 	if date.After(time.Now()) {
-		return errors.New("the match date cannot be in the future")
+		return domainerr.Invalid("date", "the match date cannot be in the future")
 	}
 	r.date = date
 	return nil
@@ -211,6 +382,18 @@ func (r *Record) SetEndsData(endsData []DataPerEnd) error {
 	return nil
 }
 
+// SetStatus sets how the game ended. A conceded game must have been won or lost.
+func (r *Record) SetStatus(status GameStatus) error {
+	if !status.IsValid() {
+		return domainerr.Invalid("status", "unknown game status %q", status)
+	}
+	if status == GameConceded && r.result != Win && r.result != Loss {
+		return domainerr.Invalid("status", "a conceded game must be won or lost")
+	}
+	r.status = status
+	return nil
+}
+
 func (r *Record) SetIsRed(isRed bool) {
 	r.isRed = isRed
 }
@@ -222,3 +405,27 @@ func (r *Record) SetIsFirst(isFirst bool) {
 func (r *Record) SetVisibility(isPublic bool) {
 	r.isPublic = isPublic
 }
+
+// SetLineup sets the lineup of the match. Every shooter already recorded must resolve against the new lineup.
+func (r *Record) SetLineup(lineup Lineup) error {
+	if err := lineup.Validate(); err != nil {
+		return err
+	}
+	previous := r.lineup
+	r.lineup = lineup
+	if err := r.ValidateEndsData(r.endsData); err != nil {
+		r.lineup = previous
+		return err
+	}
+	return nil
+}
+
+// AddSubstitution records a player coming in mid-game.
+func (r *Record) AddSubstitution(sub Substitution) error {
+	if r.lineup.IsEmpty() {
+		return domainerr.Conflict("LINEUP_NOT_SET", "a lineup must be set before recording substitutions")
+	}
+	lineup := r.lineup
+	lineup.Substitutions = append(append([]Substitution(nil), lineup.Substitutions...), sub)
+	return r.SetLineup(lineup)
+}
@@ -0,0 +1,71 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"CurlARC/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordStonesAt(t *testing.T) {
+	prePlaced := entity.Stones{FriendStones: []entity.Coordinate{{Index: 6, R: 1, Theta: 1}}}
+	first := entity.Stones{FriendStones: []entity.Coordinate{{Index: 1, R: 0.5, Theta: 0}}}
+	second := entity.Stones{
+		FriendStones: []entity.Coordinate{{Index: 1, R: 0.5, Theta: 0}},
+		EnemyStones:  []entity.Coordinate{{Index: 1, R: 0.2, Theta: 2}},
+	}
+	endsData := []entity.DataPerEnd{
+		{Shots: []entity.Shot{{Stones: first}, {Stones: second}}},
+		{PrePlaced: &prePlaced, Shots: []entity.Shot{{Stones: first}}},
+	}
+	record := entity.NewRecordFromDB("record-123", "team-123", "Team B", "Place", entity.Win, time.Now(), endsData, true, true, false)
+
+	tests := []struct {
+		name     string
+		end      int
+		shot     int
+		expected entity.Stones
+		wantErr  bool
+	}{
+		{name: "正常系: ショットの後の配置を返す", end: 1, shot: 2, expected: second},
+		{name: "正常系: 最初のショットの後の配置を返す", end: 1, shot: 1, expected: first},
+		{name: "正常系: 事前に置かれた石がなければショット0は空", end: 1, shot: 0, expected: entity.Stones{}},
+		{name: "正常系: ショット0は事前に置かれた石", end: 2, shot: 0, expected: prePlaced},
+		{name: "異常系: エンド0はない", end: 0, shot: 1, wantErr: true},
+		{name: "異常系: 記録より後のエンドはない", end: 3, shot: 1, wantErr: true},
+		{name: "異常系: 負のショットはない", end: 1, shot: -1, wantErr: true},
+		{name: "異常系: 投げられたより後のショットはない", end: 1, shot: 3, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stones, err := record.StonesAt(tt.end, tt.shot)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, entity.ErrPositionNotFound)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, stones)
+		})
+	}
+}
+
+func TestRecordEnd(t *testing.T) {
+	endsData := []entity.DataPerEnd{{Score: 1}, {Score: -2}}
+	record := entity.NewRecordFromDB("record-123", "team-123", "Team B", "Place", entity.Win, time.Now(), endsData, true, true, false)
+
+	t.Run("正常系: エンドは1から数える", func(t *testing.T) {
+		end, err := record.End(2)
+		assert.NoError(t, err)
+		assert.Equal(t, -2, end.Score)
+	})
+
+	t.Run("異常系: 範囲外のエンド", func(t *testing.T) {
+		for _, end := range []int{-1, 0, 3} {
+			_, err := record.End(end)
+			assert.ErrorIs(t, err, entity.ErrPositionNotFound, "end %d", end)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package entity
+
+// ScoreboardEnd is one column of a linescore.
+type ScoreboardEnd struct {
+	End          int
+	FriendPoints int
+	EnemyPoints  int
+	FriendTotal  int // running total after this end
+	EnemyTotal   int // running total after this end
+	FriendHammer bool
+	PowerPlay    bool // the team with hammer called a power play
+	Extra        bool // played after the regulation ends to break a tie
+	Blank        bool
+	Steal        bool // points were scored by the team without hammer
+}
+
+// Scoreboard is the linescore of a record computed from its ends data.
+// For an abandoned game the result is not checked against the totals.
+type Scoreboard struct {
+	Status         GameStatus
+	RegulationEnds int
+	EndsPlayed     int
+	ExtraEnds      int
+	ConcededAfter  int // ends played before the concession, 0 unless the game was conceded
+	Ends           []ScoreboardEnd
+	FriendTotal    int
+	EnemyTotal     int
+	BlankEnds      int
+	FriendSteals   int
+	EnemySteals    int
+	Result         Result // result stored on the record
+	ComputedResult Result // result derived from the final totals
+	ResultMatches  bool
+}
+
+// Scoreboard builds the linescore of the record.
+// The team throwing first in the first end does not have hammer, and afterwards hammer goes to the team
+// that was scored on. What happens after a blank end depends on the game format.
+func (r *Record) Scoreboard() Scoreboard {
+	board := Scoreboard{
+		Status:         r.GetStatus(),
+		RegulationEnds: r.RegulationEnds(),
+		EndsPlayed:     len(r.endsData),
+		ExtraEnds:      r.ExtraEnds(),
+		Ends:           make([]ScoreboardEnd, 0, len(r.endsData)),
+		Result:         r.result,
+	}
+	if board.Status == GameConceded {
+		board.ConcededAfter = len(r.endsData)
+	}
+
+	format := r.GetGameFormat()
+	friendHammer := !r.isFirst
+	for i, end := range r.endsData {
+		line := ScoreboardEnd{
+			End:          i + 1,
+			FriendHammer: friendHammer,
+			PowerPlay:    end.PowerPlay,
+			Extra:        i >= board.RegulationEnds,
+		}
+
+		switch {
+		case end.Score > 0:
+			line.FriendPoints = end.Score
+			line.Steal = !friendHammer
+			if line.Steal {
+				board.FriendSteals++
+			}
+		case end.Score < 0:
+			line.EnemyPoints = -end.Score
+			line.Steal = friendHammer
+			if line.Steal {
+				board.EnemySteals++
+			}
+		default:
+			line.Blank = true
+			board.BlankEnds++
+		}
+
+		friendHammer = format.NextHammer(friendHammer, end.Score)
+
+		board.FriendTotal += line.FriendPoints
+		board.EnemyTotal += line.EnemyPoints
+		line.FriendTotal = board.FriendTotal
+		line.EnemyTotal = board.EnemyTotal
+		board.Ends = append(board.Ends, line)
+	}
+
+	switch {
+	case board.FriendTotal > board.EnemyTotal:
+		board.ComputedResult = Win
+	case board.FriendTotal < board.EnemyTotal:
+		board.ComputedResult = Loss
+	default:
+		board.ComputedResult = Draw
+	}
+	board.ResultMatches = board.Status == GameAbandoned || board.Result == board.ComputedResult
+
+	return board
+}
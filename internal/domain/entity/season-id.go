@@ -0,0 +1,21 @@
+package entity
+
+import "reflect"
+
+type SeasonId struct {
+	value string
+}
+
+func NewSeasonId(uuid string) *SeasonId {
+	seasonId := new(SeasonId)
+	seasonId.value = uuid
+	return seasonId
+}
+
+func (r *SeasonId) Value() string {
+	return r.value
+}
+
+func (r *SeasonId) Equals(other *SeasonId) bool {
+	return reflect.DeepEqual(r.value, other.value)
+}
@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"CurlARC/internal/domain/domainerr"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Season is a named period of a team's games. Both dates are days: a record belongs to the season
+// when it was played from the start of the first day to the end of the last one.
+type Season struct {
+	id        SeasonId
+	teamId    string
+	name      string
+	startDate time.Time
+	endDate   time.Time
+}
+
+func NewSeason(teamId, name string, startDate, endDate time.Time) (*Season, error) {
+	season := &Season{
+		id:     *NewSeasonId(uuid.New().String()),
+		teamId: teamId,
+	}
+	if err := season.SetName(name); err != nil {
+		return nil, err
+	}
+	if err := season.SetDates(startDate, endDate); err != nil {
+		return nil, err
+	}
+	return season, nil
+}
+
+func NewSeasonFromDB(id, teamId, name string, startDate, endDate time.Time) *Season {
+	return &Season{
+		id:        *NewSeasonId(id),
+		teamId:    teamId,
+		name:      name,
+		startDate: startDate,
+		endDate:   endDate,
+	}
+}
+
+// Contains tells whether a date falls within the season.
+func (s *Season) Contains(date time.Time) bool {
+	return !date.Before(s.startDate) && date.Before(s.endDate.AddDate(0, 0, 1))
+}
+
+// getter
+
+func (s *Season) GetId() *SeasonId {
+	return &s.id
+}
+
+func (s *Season) GetTeamId() string {
+	return s.teamId
+}
+
+func (s *Season) GetName() string {
+	return s.name
+}
+
+func (s *Season) GetStartDate() time.Time {
+	return s.startDate
+}
+
+func (s *Season) GetEndDate() time.Time {
+	return s.endDate
+}
+
+// setter
+
+func (s *Season) SetName(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return domainerr.Invalid("name", "season name is required")
+	}
+	s.name = name
+	return nil
+}
+
+// SetDates sets the first and last days of the season; the time of day is dropped.
+func (s *Season) SetDates(startDate, endDate time.Time) error {
+	if startDate.IsZero() || endDate.IsZero() {
+		return domainerr.Invalid("start_date", "a season needs a start and an end date")
+	}
+	startDate = truncateToDay(startDate)
+	endDate = truncateToDay(endDate)
+	if endDate.Before(startDate) {
+		return domainerr.Invalid("end_date", "the season cannot end before it starts")
+	}
+	s.startDate = startDate
+	s.endDate = endDate
+	return nil
+}
+
+func truncateToDay(date time.Time) time.Time {
+	year, month, day := date.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, date.Location())
+}
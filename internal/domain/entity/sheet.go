@@ -0,0 +1,17 @@
+package entity
+
+import "math"
+
+// Sheet dimensions in metres, following the World Curling rules.
+// Stone positions are stored as a Coordinate relative to the button, so R is measured in the same unit.
+const (
+	HouseRadius      = 1.829 // 12-foot circle
+	StoneRadius      = 0.145
+	HogLineDistance  = 6.401 // tee line to hog line
+	BackLineDistance = 1.829 // tee line to back line
+	SheetWidth       = 4.75
+)
+
+// MaxStoneDistance is the farthest a stone in play can be from the button,
+// i.e. the corner where the hog line meets a side line.
+var MaxStoneDistance = math.Hypot(HogLineDistance, SheetWidth/2)
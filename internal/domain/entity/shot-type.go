@@ -0,0 +1,139 @@
+package entity
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ShotType is a standard curling shot.
+type ShotType string
+
+const (
+	ShotDraw       ShotType = "DRAW"
+	ShotGuard      ShotType = "GUARD"
+	ShotComeAround ShotType = "COME_AROUND"
+	ShotFreeze     ShotType = "FREEZE"
+	ShotTapBack    ShotType = "TAP_BACK"
+	ShotRaise      ShotType = "RAISE"
+	ShotTakeout    ShotType = "TAKEOUT"
+	ShotHitAndStay ShotType = "HIT_AND_STAY"
+	ShotHitAndRoll ShotType = "HIT_AND_ROLL"
+	ShotDouble     ShotType = "DOUBLE"
+	ShotPeel       ShotType = "PEEL"
+	ShotWick       ShotType = "WICK"
+	ShotThrough    ShotType = "THROUGH"
+)
+
+var shotTypes = []ShotType{
+	ShotDraw, ShotGuard, ShotComeAround, ShotFreeze, ShotTapBack, ShotRaise, ShotTakeout,
+	ShotHitAndStay, ShotHitAndRoll, ShotDouble, ShotPeel, ShotWick, ShotThrough,
+}
+
+// ShotTypes returns every standard shot type.
+func ShotTypes() []ShotType {
+	return append([]ShotType(nil), shotTypes...)
+}
+
+func (t ShotType) IsValid() bool {
+	for _, shotType := range shotTypes {
+		if t == shotType {
+			return true
+		}
+	}
+	return false
+}
+
+// Rotation is the turn given to the stone.
+type Rotation string
+
+const (
+	InTurn  Rotation = "IN_TURN"
+	OutTurn Rotation = "OUT_TURN"
+)
+
+func (r Rotation) IsValid() bool {
+	return r == InTurn || r == OutTurn
+}
+
+// shotTypeAliases maps free-form spellings, lower-cased and without spaces, hyphens or underscores, to a shot type.
+var shotTypeAliases = map[string]ShotType{
+	"draw":          ShotDraw,
+	"ドロー":           ShotDraw,
+	"guard":         ShotGuard,
+	"centerguard":   ShotGuard,
+	"cornerguard":   ShotGuard,
+	"ガード":           ShotGuard,
+	"センターガード":       ShotGuard,
+	"コーナーガード":       ShotGuard,
+	"comearound":    ShotComeAround,
+	"カムアラウンド":       ShotComeAround,
+	"freeze":        ShotFreeze,
+	"フリーズ":          ShotFreeze,
+	"tapback":       ShotTapBack,
+	"tap":           ShotTapBack,
+	"タップバック":        ShotTapBack,
+	"タップ":           ShotTapBack,
+	"raise":         ShotRaise,
+	"promote":       ShotRaise,
+	"レイズ":           ShotRaise,
+	"takeout":       ShotTakeout,
+	"hit":           ShotTakeout,
+	"テイクアウト":        ShotTakeout,
+	"テークアウト":        ShotTakeout,
+	"ヒット":           ShotTakeout,
+	"hitandstay":    ShotHitAndStay,
+	"hitandstick":   ShotHitAndStay,
+	"ヒットアンドステイ":     ShotHitAndStay,
+	"hitandroll":    ShotHitAndRoll,
+	"ヒットアンドロール":     ShotHitAndRoll,
+	"double":        ShotDouble,
+	"doubletakeout": ShotDouble,
+	"ダブル":           ShotDouble,
+	"ダブルテイクアウト":     ShotDouble,
+	"peel":          ShotPeel,
+	"ピール":           ShotPeel,
+	"wick":          ShotWick,
+	"ウィック":          ShotWick,
+	"through":       ShotThrough,
+	"スルー":           ShotThrough,
+}
+
+var rotationAliases = []struct {
+	alias    string
+	rotation Rotation
+}{
+	{"outturn", OutTurn},
+	{"アウトターン", OutTurn},
+	{"inturn", InTurn},
+	{"インターン", InTurn},
+}
+
+// ParseShotType maps a free-form shot name such as "Draw", "hit-and-roll" or "ドロー" onto the standard vocabulary.
+// A rotation written as part of the name ("in-turn draw") is returned separately.
+// ok is false when the name cannot be mapped.
+func ParseShotType(raw string) (shotType ShotType, rotation Rotation, ok bool) {
+	if t := ShotType(strings.ToUpper(strings.TrimSpace(raw))); t.IsValid() {
+		return t, "", true
+	}
+
+	key := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '-' || r == '_' || r == '・' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, raw)
+
+	for _, a := range rotationAliases {
+		if strings.Contains(key, a.alias) {
+			rotation = a.rotation
+			key = strings.Replace(key, a.alias, "", 1)
+			break
+		}
+	}
+
+	shotType, ok = shotTypeAliases[key]
+	if !ok {
+		return "", "", false
+	}
+	return shotType, rotation, true
+}
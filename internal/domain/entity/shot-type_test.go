@@ -0,0 +1,57 @@
+package entity_test
+
+import (
+	"testing"
+
+	"CurlARC/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseShotType(t *testing.T) {
+	tests := []struct {
+		raw      string
+		shotType entity.ShotType
+		rotation entity.Rotation
+	}{
+		// the standard vocabulary, in any case
+		{"DRAW", entity.ShotDraw, ""},
+		{"hit_and_roll", entity.ShotHitAndRoll, ""},
+		{" TAP_BACK ", entity.ShotTapBack, ""},
+		// legacy spellings
+		{"draw", entity.ShotDraw, ""},
+		{"Draw", entity.ShotDraw, ""},
+		{"ドロー", entity.ShotDraw, ""},
+		{"Come Around", entity.ShotComeAround, ""},
+		{"hit-and-roll", entity.ShotHitAndRoll, ""},
+		{"Hit and Stick", entity.ShotHitAndStay, ""},
+		{"double takeout", entity.ShotDouble, ""},
+		{"Corner Guard", entity.ShotGuard, ""},
+		{"テイクアウト", entity.ShotTakeout, ""},
+		{"ヒット・アンド・ロール", entity.ShotHitAndRoll, ""},
+		// rotations written as part of the name
+		{"in-turn draw", entity.ShotDraw, entity.InTurn},
+		{"draw out turn", entity.ShotDraw, entity.OutTurn},
+		{"OutTurn Takeout", entity.ShotTakeout, entity.OutTurn},
+		{"インターンドロー", entity.ShotDraw, entity.InTurn},
+		{"アウトターン ガード", entity.ShotGuard, entity.OutTurn},
+	}
+
+	for _, tt := range tests {
+		t.Run("正常系: "+tt.raw, func(t *testing.T) {
+			shotType, rotation, ok := entity.ParseShotType(tt.raw)
+			assert.Equal(t, tt.shotType != "", ok)
+			assert.Equal(t, tt.shotType, shotType)
+			assert.Equal(t, tt.rotation, rotation)
+		})
+	}
+
+	t.Run("異常系: 対応する種類のない名前", func(t *testing.T) {
+		for _, raw := range []string{"", "   ", "sweep", "ドローショット", "in-turn", "draw takeout", "drawdraw", "Draw (out-turn)"} {
+			shotType, rotation, ok := entity.ParseShotType(raw)
+			assert.False(t, ok, raw)
+			assert.Empty(t, shotType, raw)
+			assert.Empty(t, rotation, raw)
+		}
+	})
+}
@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NormalizeTeamName returns the key used to compare free-text team names.
+// Case and whitespace (including full-width spaces) are ignored, so "Team B", "team b" and "TeamB" are the same team.
+func NormalizeTeamName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, name)
+}
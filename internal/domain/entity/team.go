@@ -1,7 +1,7 @@
 package entity
 
 import (
-	"errors"
+	"CurlARC/internal/domain/domainerr"
 
 	"github.com/google/uuid"
 )
@@ -33,7 +33,7 @@ func NewTeamFromDB(id string, name string) *Team {
 func (t *Team) AddRecord(record Record) error {
 	// Example rule: Limit the number of records to 100
 	if len(t.records) >= 100 {
-		return errors.New("cannot add more than 100 records")
+		return domainerr.Conflict("TOO_MANY_RECORDS", "cannot add more than 100 records")
 	}
 	t.records = append(t.records, record)
 	return nil
@@ -47,14 +47,14 @@ func (t *Team) RemoveRecord(recordId RecordId) error {
 			return nil
 		}
 	}
-	return errors.New("record not found")
+	return domainerr.ErrRecordNotFound
 }
 
 // AddUser with business rule
 func (t *Team) AddUser(user User) error {
 	for _, u := range t.users {
 		if u.GetId().Equals(user.GetId()) {
-			return errors.New("user already in team")
+			return domainerr.Conflict("ALREADY_MEMBER", "user already in team")
 		}
 	}
 	t.users = append(t.users, user)
@@ -69,7 +69,7 @@ func (t *Team) RemoveUser(userId UserId) error {
 			return nil
 		}
 	}
-	return errors.New("user not found")
+	return domainerr.ErrUserNotFound
 }
 
 // getter
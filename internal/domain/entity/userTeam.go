@@ -1,23 +1,85 @@
 package entity
 
+import "time"
+
 type UserTeamState string
 
 const (
-	Invited UserTeamState = "INVITED"
-	Member  UserTeamState = "MEMBER"
+	Invited  UserTeamState = "INVITED"
+	Member   UserTeamState = "MEMBER"
+	Declined UserTeamState = "DECLINED" // the user turned the invitation down
+	Revoked  UserTeamState = "REVOKED"  // the team withdrew the invitation
+)
+
+// TeamRole is what a member may do in the team. Each role may do whatever the roles below it may.
+type TeamRole string
+
+const (
+	RoleOwner  TeamRole = "OWNER"  // deletes the team and hands it over
+	RoleAdmin  TeamRole = "ADMIN"  // renames the team, invites and removes members and sets their roles
+	RoleMember TeamRole = "MEMBER" // records games and edits the team's data
+	RoleViewer TeamRole = "VIEWER" // only reads the team's data, e.g. parents or sponsors
+	RoleNone   TeamRole = ""       // not a member of the team
 )
 
+var roleRanks = map[TeamRole]int{RoleViewer: 1, RoleMember: 2, RoleAdmin: 3, RoleOwner: 4}
+
+func (r TeamRole) IsValid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// AtLeast tells whether the role may do whatever the given role may.
+func (r TeamRole) AtLeast(role TeamRole) bool {
+	return r.IsValid() && roleRanks[r] >= roleRanks[role]
+}
+
+// InvitationTTL is how long an invitation may be accepted for after it was sent.
+const InvitationTTL = 7 * 24 * time.Hour
+
+// UserTeam is the membership of a user in a team. An invited user gets the role when they accept.
+// The membership keeps who invited the user and when; an invitation left unaccepted past its expiry is void.
+// A declined or revoked invitation is kept, with the time it was closed, until the user is invited again.
 type UserTeam struct {
-	userId UserId
-	teamId TeamId
-	state  UserTeamState
+	userId    UserId
+	teamId    TeamId
+	state     UserTeamState
+	role      TeamRole
+	invitedBy string // empty for creators and for invitations sent before inviters were recorded
+	invitedAt time.Time
+	expiresAt time.Time
+	closedAt  time.Time
 }
 
+// NewUserTeam makes a membership with the member role.
 func NewUserTeam(userId UserId, teamId TeamId, state UserTeamState) *UserTeam {
 	return &UserTeam{
 		userId: userId,
 		teamId: teamId,
 		state:  state,
+		role:   RoleMember,
+	}
+}
+
+// NewInvitation makes the invitation of a user to a team, which expires InvitationTTL after it was sent.
+func NewInvitation(userId UserId, teamId TeamId, invitedBy string, invitedAt time.Time) *UserTeam {
+	userTeam := NewUserTeam(userId, teamId, Invited)
+	userTeam.invitedBy = invitedBy
+	userTeam.invitedAt = invitedAt
+	userTeam.expiresAt = invitedAt.Add(InvitationTTL)
+	return userTeam
+}
+
+func NewUserTeamFromDB(userId, teamId string, state UserTeamState, role TeamRole, invitedBy string, invitedAt, expiresAt, closedAt time.Time) *UserTeam {
+	return &UserTeam{
+		userId:    *NewUserId(userId),
+		teamId:    *NewTeamId(teamId),
+		state:     state,
+		role:      role,
+		invitedBy: invitedBy,
+		invitedAt: invitedAt,
+		expiresAt: expiresAt,
+		closedAt:  closedAt,
 	}
 }
 
@@ -35,8 +97,49 @@ func (u *UserTeam) GetState() UserTeamState {
 	return u.state
 }
 
+func (u *UserTeam) GetRole() TeamRole {
+	return u.role
+}
+
+func (u *UserTeam) GetInvitedBy() string {
+	return u.invitedBy
+}
+
+func (u *UserTeam) GetInvitedAt() time.Time {
+	return u.invitedAt
+}
+
+func (u *UserTeam) GetExpiresAt() time.Time {
+	return u.expiresAt
+}
+
+func (u *UserTeam) GetClosedAt() time.Time {
+	return u.closedAt
+}
+
+// Decline closes the invitation on behalf of the invited user.
+func (u *UserTeam) Decline(at time.Time) {
+	u.state = Declined
+	u.closedAt = at
+}
+
+// Revoke closes the invitation on behalf of the team.
+func (u *UserTeam) Revoke(at time.Time) {
+	u.state = Revoked
+	u.closedAt = at
+}
+
+// IsExpired tells whether the invitation can no longer be accepted. Memberships never expire.
+func (u *UserTeam) IsExpired(now time.Time) bool {
+	return u.state == Invited && !u.expiresAt.IsZero() && !now.Before(u.expiresAt)
+}
+
 // setter
 
 func (u *UserTeam) SetState(state UserTeamState) {
 	u.state = state
 }
+
+func (u *UserTeam) SetRole(role TeamRole) {
+	u.role = role
+}
@@ -0,0 +1,45 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"CurlARC/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserTeamIsExpired(t *testing.T) {
+	invitedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := invitedAt.Add(entity.InvitationTTL)
+	invitation := entity.NewInvitation(*entity.NewUserId("user-123"), *entity.NewTeamId("team-123"), "user-456", invitedAt)
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected bool
+	}{
+		{name: "正常系: 期限の直前は有効", now: expiresAt.Add(-time.Second), expected: false},
+		{name: "正常系: 期限ちょうどで切れる", now: expiresAt, expected: true},
+		{name: "正常系: 期限の直後は切れている", now: expiresAt.Add(time.Second), expected: true},
+		{name: "正常系: 別のタイムゾーンでも同じ時刻に切れる", now: expiresAt.Add(-time.Second).In(tokyo), expected: false},
+		{name: "正常系: 別のタイムゾーンで期限の直後", now: expiresAt.Add(time.Second).In(tokyo), expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, invitation.IsExpired(tt.now))
+		})
+	}
+
+	t.Run("正常系: 期限のない招待は切れない", func(t *testing.T) {
+		legacy := entity.NewUserTeamFromDB("user-123", "team-123", entity.Invited, entity.RoleMember, "", time.Time{}, time.Time{}, time.Time{})
+		assert.False(t, legacy.IsExpired(expiresAt))
+	})
+
+	t.Run("正常系: 招待中でなければ切れない", func(t *testing.T) {
+		member := entity.NewUserTeamFromDB("user-123", "team-123", entity.Member, entity.RoleMember, "user-456", invitedAt, expiresAt, time.Time{})
+		assert.False(t, member.IsExpired(expiresAt.Add(time.Hour)))
+	})
+}
@@ -0,0 +1,12 @@
+package geometry
+
+import (
+	"CurlARC/internal/domain/entity"
+	"math"
+)
+
+// ToCartesian converts a stone position to metres from the button, following the convention of entity.Coordinate.
+// Stones behind the tee line have a negative y. Use Sheet.ToCartesian for coordinates on the sheet.
+func ToCartesian(stone entity.Coordinate) (x, y float64) {
+	return stone.R * math.Cos(stone.Theta), stone.R * math.Sin(stone.Theta)
+}
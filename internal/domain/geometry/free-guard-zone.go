@@ -0,0 +1,174 @@
+package geometry
+
+import "CurlARC/internal/domain/entity"
+
+// InFreeGuardZone reports whether a stone lies in the free guard zone of the default sheet.
+func InFreeGuardZone(stone entity.Coordinate) bool {
+	return DefaultSheet.InFreeGuardZone(stone)
+}
+
+// CheckFreeGuardZone sets or clears the free guard zone flag of every shot of an end.
+//
+// The snapshot after each of our shots also contains the opponent's delivery just before it, so a removal is
+// put down to the team the stone was protected from: an enemy stone to our delivery and, in four-player games,
+// a friend stone to the opponent's. In mixed doubles removing one's own stone is also forbidden, so a friend
+// stone removed after the opponent's delivery was out of protection is put down to us.
+func CheckFreeGuardZone(rule entity.FreeGuardZoneRule, end *entity.DataPerEnd, friendHammer bool) {
+	var before entity.Stones
+	if end.PrePlaced != nil {
+		before = *end.PrePlaced
+	}
+
+	for i := range end.Shots {
+		shot := &end.Shots[i]
+		previous := before
+		before = shot.Stones
+
+		// a restored shot no longer shows the removal, so its flag is kept as it is
+		if shot.FreeGuardZoneViolation != nil && shot.FreeGuardZoneViolation.Restored {
+			continue
+		}
+		shot.FreeGuardZoneViolation = nil
+
+		// the team without hammer delivers the first stone of the end
+		ourDelivery := 2*(i+1) - 1
+		if friendHammer {
+			ourDelivery++
+		}
+		theirDelivery := ourDelivery - 1
+		protected := func(delivery int) bool { return delivery >= 1 && delivery <= rule.ProtectedDeliveries }
+
+		ours := &entity.FreeGuardZoneViolation{Delivery: ourDelivery, ByFriend: true}
+		theirs := &entity.FreeGuardZoneViolation{Delivery: theirDelivery}
+		for _, m := range DiffStones(previous, shot.Stones) {
+			if m.Movement != MovementRemoved || !(rule.AllStones || InFreeGuardZone(*m.From)) {
+				continue
+			}
+
+			offender := ours
+			if m.Side == SideFriend && (!rule.AllStones || protected(theirDelivery)) {
+				offender = theirs
+			}
+			if !protected(offender.Delivery) {
+				continue
+			}
+			if m.Side == SideFriend {
+				offender.RemovedFriendStones = append(offender.RemovedFriendStones, m.Index)
+			} else {
+				offender.RemovedEnemyStones = append(offender.RemovedEnemyStones, m.Index)
+			}
+		}
+
+		// our own offence takes precedence when both teams removed protected stones
+		for _, violation := range []*entity.FreeGuardZoneViolation{ours, theirs} {
+			if len(violation.RemovedFriendStones)+len(violation.RemovedEnemyStones) > 0 {
+				shot.FreeGuardZoneViolation = violation
+				break
+			}
+		}
+	}
+}
+
+// RestoreFreeGuardZone applies the penalty for a flagged shot, numbered from 1: the stone delivered by the
+// offending team is taken out of play for the rest of the end, and every stone which moved or left play
+// is put back where it was before the shot. The later shots are replayed on the restored position, so that
+// the stones put back stay in play until a later shot moves or removes them.
+func RestoreFreeGuardZone(end *entity.DataPerEnd, shotNo int) {
+	shot := &end.Shots[shotNo-1]
+	violation := shot.FreeGuardZoneViolation
+	if violation == nil || violation.Restored {
+		return
+	}
+
+	var before entity.Stones
+	if shotNo > 1 {
+		before = end.Shots[shotNo-2].Stones
+	} else if end.PrePlaced != nil {
+		before = *end.PrePlaced
+	}
+
+	offenderSide := SideEnemy
+	if violation.ByFriend {
+		offenderSide = SideFriend
+	}
+	delivered := map[int]bool{}
+	var restored entity.Stones
+	for _, m := range DiffStones(before, shot.Stones) {
+		var stone entity.Coordinate
+		switch {
+		case m.Movement == MovementPlaced && m.Side == offenderSide:
+			delivered[m.Index] = true
+			continue
+		case m.Movement == MovementPlaced:
+			stone = *m.To
+		default:
+			stone = *m.From
+		}
+		if m.Side == SideFriend {
+			restored.FriendStones = append(restored.FriendStones, stone)
+		} else {
+			restored.EnemyStones = append(restored.EnemyStones, stone)
+		}
+	}
+
+	recorded := shot.Stones
+	shot.Stones = restored
+	for i := shotNo; i < len(end.Shots); i++ {
+		next := end.Shots[i].Stones
+		end.Shots[i].Stones = replay(end.Shots[i-1].Stones, DiffStones(recorded, next), offenderSide, delivered)
+		recorded = next
+	}
+	violation.Restored = true
+}
+
+// replay applies the movements recorded during a shot to another position. Stones the shot left untouched
+// keep their place in the position, and the stones of the offending team taken out of play stay out.
+func replay(position entity.Stones, movements []StoneMovement, offenderSide Side, takenOut map[int]bool) entity.Stones {
+	type key struct {
+		side  Side
+		index int
+	}
+	changed := map[key]*entity.Coordinate{}
+	var arrived []StoneMovement // stones which end up in play, added unless the position holds them already
+	for _, m := range movements {
+		if m.Side == offenderSide && takenOut[m.Index] {
+			continue
+		}
+		switch m.Movement {
+		case MovementMoved:
+			changed[key{m.Side, m.Index}] = m.To
+			arrived = append(arrived, m)
+		case MovementRemoved:
+			changed[key{m.Side, m.Index}] = nil
+		case MovementPlaced:
+			arrived = append(arrived, m)
+		}
+	}
+
+	apply := func(side Side, stones []entity.Coordinate) []entity.Coordinate {
+		kept := make([]entity.Coordinate, 0, len(stones))
+		present := map[int]bool{}
+		for _, stone := range stones {
+			to, ok := changed[key{side, stone.Index}]
+			switch {
+			case !ok:
+				kept = append(kept, stone)
+			case to != nil:
+				kept = append(kept, *to)
+			default:
+				continue
+			}
+			present[stone.Index] = true
+		}
+		for _, m := range arrived {
+			if m.Side == side && !present[m.Index] {
+				kept = append(kept, *m.To)
+			}
+		}
+		return kept
+	}
+	return entity.Stones{
+		FriendStones: apply(SideFriend, position.FriendStones),
+		EnemyStones:  apply(SideEnemy, position.EnemyStones),
+	}
+}
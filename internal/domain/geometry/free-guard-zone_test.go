@@ -0,0 +1,328 @@
+package geometry_test
+
+import (
+	"math"
+	"testing"
+
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/geometry"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	fourPlayer    = entity.GameFormatEightEnds.FreeGuardZone()
+	mixedDoubles  = entity.GameFormatMixedDoubles.FreeGuardZone()
+	restoredShot3 = &entity.FreeGuardZoneViolation{Delivery: 5, ByFriend: true, RemovedEnemyStones: []int{1}, Restored: true}
+)
+
+func stones(friends []entity.Coordinate, enemies []entity.Coordinate) entity.Stones {
+	return entity.Stones{FriendStones: friends, EnemyStones: enemies}
+}
+
+func shots(positions ...entity.Stones) []entity.Shot {
+	shots := make([]entity.Shot, 0, len(positions))
+	for _, position := range positions {
+		shots = append(shots, entity.Shot{Stones: position})
+	}
+	return shots
+}
+
+func at(index int, r, theta float64) entity.Coordinate {
+	return entity.Coordinate{Index: index, R: r, Theta: theta}
+}
+
+func TestInFreeGuardZone(t *testing.T) {
+	edge := entity.HouseRadius + entity.StoneRadius
+
+	tests := []struct {
+		name     string
+		stone    entity.Coordinate
+		expected bool
+	}{
+		{name: "正常系: ハウスとホッグラインの間の石", stone: at(1, 3, math.Pi/2), expected: true},
+		{name: "正常系: ハウスにぎりぎり触れない石", stone: at(1, edge+0.001, math.Pi/2), expected: true},
+		{name: "正常系: ハウスに触れている石は含まない", stone: at(1, edge, math.Pi/2), expected: false},
+		{name: "正常系: ハウスの中の石は含まない", stone: at(1, 0.5, math.Pi/2), expected: false},
+		{name: "正常系: ティーラインより後ろの石は含まない", stone: at(1, 2.5, -math.Pi/2), expected: false},
+		{name: "正常系: ティーライン上の石は含まない", stone: at(1, 2.5, 0), expected: false},
+		{name: "正常系: ホッグラインを越えた石は含まない", stone: at(1, entity.HogLineDistance+0.1, math.Pi/2), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, geometry.InFreeGuardZone(tt.stone))
+		})
+	}
+}
+
+func TestCheckFreeGuardZone(t *testing.T) {
+	ourGuard := at(1, 3, math.Pi/2)
+	theirGuard := at(1, 3.5, 1.3)
+	prePlacedFriend := at(6, 1, math.Pi/2)
+	prePlacedEnemy := at(6, 4, math.Pi/2)
+
+	tests := []struct {
+		name         string
+		rule         entity.FreeGuardZoneRule
+		friendHammer bool
+		end          entity.DataPerEnd
+		expected     []*entity.FreeGuardZoneViolation
+	}{
+		{
+			// we throw first: our stones are the 1st, 3rd and 5th of the end
+			name: "正常系: 自チームが5投目で相手のガードを出す",
+			rule: fourPlayer,
+			end: entity.DataPerEnd{Shots: shots(
+				stones([]entity.Coordinate{ourGuard}, nil),
+				stones([]entity.Coordinate{ourGuard, at(2, 0.3, 0)}, []entity.Coordinate{theirGuard}),
+				stones([]entity.Coordinate{ourGuard, at(2, 0.3, 0), at(3, 3.5, 1.3)}, []entity.Coordinate{at(2, 0.5, 3)}),
+			)},
+			expected: []*entity.FreeGuardZoneViolation{
+				nil,
+				nil,
+				{Delivery: 5, ByFriend: true, RemovedEnemyStones: []int{1}},
+			},
+		},
+		{
+			// they throw first: their stones are the 1st, 3rd and 5th of the end
+			name:         "正常系: 相手チームが3投目で自チームのガードを出す",
+			rule:         fourPlayer,
+			friendHammer: true,
+			end: entity.DataPerEnd{Shots: shots(
+				stones([]entity.Coordinate{ourGuard}, []entity.Coordinate{theirGuard}),
+				stones([]entity.Coordinate{at(2, 0.3, 0)}, []entity.Coordinate{theirGuard, at(2, 0.8, 1.5)}),
+			)},
+			expected: []*entity.FreeGuardZoneViolation{
+				nil,
+				{Delivery: 3, RemovedFriendStones: []int{1}},
+			},
+		},
+		{
+			name: "正常系: 6投目以降は出してよい",
+			rule: fourPlayer,
+			// they have hammer, so our third shot is the 6th stone
+			friendHammer: true,
+			end: entity.DataPerEnd{Shots: shots(
+				stones(nil, []entity.Coordinate{theirGuard}),
+				stones(nil, []entity.Coordinate{theirGuard, at(2, 0.5, 3)}),
+				stones([]entity.Coordinate{at(3, 3.5, 1.3)}, []entity.Coordinate{at(2, 0.5, 3), at(3, 0.9, 2)}),
+			)},
+			expected: []*entity.FreeGuardZoneViolation{nil, nil, nil},
+		},
+		{
+			name: "正常系: ハウスの中の石は4人制では守られない",
+			rule: fourPlayer,
+			end: entity.DataPerEnd{Shots: shots(
+				stones([]entity.Coordinate{ourGuard}, nil),
+				stones([]entity.Coordinate{ourGuard}, []entity.Coordinate{at(1, 0.4, 2)}),
+				stones([]entity.Coordinate{ourGuard, at(2, 0.4, 2)}, nil),
+			)},
+			expected: []*entity.FreeGuardZoneViolation{nil, nil, nil},
+		},
+		{
+			name: "正常系: ミックスダブルスでは3投目までハウスの中の石も守られる",
+			rule: mixedDoubles,
+			end: entity.DataPerEnd{PrePlaced: &entity.Stones{
+				FriendStones: []entity.Coordinate{prePlacedFriend},
+				EnemyStones:  []entity.Coordinate{prePlacedEnemy},
+			}, Shots: shots(
+				stones([]entity.Coordinate{prePlacedFriend, at(1, 0.5, 2)}, []entity.Coordinate{prePlacedEnemy}),
+				stones([]entity.Coordinate{at(1, 0.5, 2), at(2, 0.9, 1.5)}, []entity.Coordinate{prePlacedEnemy, at(1, 0.2, 0)}),
+			)},
+			expected: []*entity.FreeGuardZoneViolation{
+				nil,
+				// their 2nd stone of the end took out our pre-placed stone
+				{Delivery: 2, RemovedFriendStones: []int{6}},
+			},
+		},
+		{
+			name: "正常系: ミックスダブルスでは自分の石を出しても違反",
+			rule: mixedDoubles,
+			end: entity.DataPerEnd{PrePlaced: &entity.Stones{
+				FriendStones: []entity.Coordinate{prePlacedFriend},
+				EnemyStones:  []entity.Coordinate{prePlacedEnemy},
+			}, Shots: shots(
+				stones([]entity.Coordinate{at(1, 0.5, 2)}, []entity.Coordinate{prePlacedEnemy}),
+			)},
+			expected: []*entity.FreeGuardZoneViolation{
+				{Delivery: 1, ByFriend: true, RemovedFriendStones: []int{6}},
+			},
+		},
+		{
+			name: "正常系: ミックスダブルスでは4投目以降は出してよい",
+			rule: mixedDoubles,
+			end: entity.DataPerEnd{PrePlaced: &entity.Stones{
+				FriendStones: []entity.Coordinate{prePlacedFriend},
+				EnemyStones:  []entity.Coordinate{prePlacedEnemy},
+			}, Shots: shots(
+				stones([]entity.Coordinate{prePlacedFriend, at(1, 0.5, 2)}, []entity.Coordinate{prePlacedEnemy}),
+				stones([]entity.Coordinate{prePlacedFriend, at(1, 0.5, 2), at(2, 1.1, 1)}, []entity.Coordinate{prePlacedEnemy, at(1, 3, 1.6)}),
+				// their 4th and our 5th stones of the end
+				stones([]entity.Coordinate{at(1, 0.5, 2), at(2, 1.1, 1), at(3, 0.3, 0)}, []entity.Coordinate{at(1, 3, 1.6), at(2, 0.6, 1)}),
+			)},
+			expected: []*entity.FreeGuardZoneViolation{nil, nil, nil},
+		},
+		{
+			name: "正常系: 戻されたショットは再び検査されない",
+			rule: fourPlayer,
+			end: entity.DataPerEnd{Shots: []entity.Shot{
+				{Stones: stones([]entity.Coordinate{ourGuard}, nil)},
+				{Stones: stones([]entity.Coordinate{ourGuard}, []entity.Coordinate{theirGuard})},
+				{
+					Stones:                 stones([]entity.Coordinate{ourGuard}, []entity.Coordinate{theirGuard, at(2, 0.5, 3)}),
+					FreeGuardZoneViolation: restoredShot3,
+				},
+			}},
+			expected: []*entity.FreeGuardZoneViolation{nil, nil, restoredShot3},
+		},
+		{
+			name: "正常系: 違反がなくなれば以前の記録は消される",
+			rule: fourPlayer,
+			end: entity.DataPerEnd{Shots: []entity.Shot{
+				{
+					Stones:                 stones([]entity.Coordinate{ourGuard}, nil),
+					FreeGuardZoneViolation: &entity.FreeGuardZoneViolation{Delivery: 1, ByFriend: true},
+				},
+			}},
+			expected: []*entity.FreeGuardZoneViolation{nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			end := tt.end
+			geometry.CheckFreeGuardZone(tt.rule, &end, tt.friendHammer)
+
+			actual := make([]*entity.FreeGuardZoneViolation, 0, len(end.Shots))
+			for _, shot := range end.Shots {
+				actual = append(actual, shot.FreeGuardZoneViolation)
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestRestoreFreeGuardZone(t *testing.T) {
+	ourGuard := at(1, 3, math.Pi/2)
+	theirGuard := at(1, 3.5, 1.3)
+	prePlacedFriend := at(6, 1, math.Pi/2)
+	prePlacedEnemy := at(6, 4, math.Pi/2)
+
+	tests := []struct {
+		name         string
+		rule         entity.FreeGuardZoneRule
+		friendHammer bool
+		end          entity.DataPerEnd
+		shotNo       int
+		expected     []entity.Stones
+	}{
+		{
+			name: "正常系: 自チームの違反では投げた石を除き相手のガードを戻す",
+			rule: fourPlayer,
+			end: entity.DataPerEnd{Shots: shots(
+				stones([]entity.Coordinate{ourGuard}, nil),
+				stones([]entity.Coordinate{ourGuard}, []entity.Coordinate{theirGuard}),
+				// their 4th stone of the end, then our 5th peels their guard and stays in its place
+				stones([]entity.Coordinate{ourGuard, at(3, 3.5, 1.3)}, []entity.Coordinate{at(2, 0.5, 3)}),
+				stones([]entity.Coordinate{ourGuard, at(3, 3.5, 1.3), at(4, 0.2, 0)}, []entity.Coordinate{at(2, 0.5, 3), at(3, 1.5, 2)}),
+				stones([]entity.Coordinate{ourGuard, at(3, 3.5, 1.3), at(4, 0.2, 0), at(5, 1, 1)}, []entity.Coordinate{at(2, 0.5, 3), at(3, 1.5, 2), at(4, 0.7, 2.5)}),
+			)},
+			shotNo: 3,
+			expected: []entity.Stones{
+				stones([]entity.Coordinate{ourGuard}, nil),
+				stones([]entity.Coordinate{ourGuard}, []entity.Coordinate{theirGuard}),
+				stones([]entity.Coordinate{ourGuard}, []entity.Coordinate{theirGuard, at(2, 0.5, 3)}),
+				stones([]entity.Coordinate{ourGuard, at(4, 0.2, 0)}, []entity.Coordinate{theirGuard, at(2, 0.5, 3), at(3, 1.5, 2)}),
+				stones([]entity.Coordinate{ourGuard, at(4, 0.2, 0), at(5, 1, 1)}, []entity.Coordinate{theirGuard, at(2, 0.5, 3), at(3, 1.5, 2), at(4, 0.7, 2.5)}),
+			},
+		},
+		{
+			name:         "正常系: 相手の違反で戻した自チームのガードは後のショットでも残る",
+			rule:         fourPlayer,
+			friendHammer: true,
+			end: entity.DataPerEnd{Shots: shots(
+				stones([]entity.Coordinate{ourGuard}, []entity.Coordinate{theirGuard}),
+				// their 3rd stone of the end peels our guard and rolls into the house
+				stones([]entity.Coordinate{at(2, 0.3, 0)}, []entity.Coordinate{theirGuard, at(2, 0.8, 1.5)}),
+				stones([]entity.Coordinate{at(2, 0.3, 0), at(3, 1.2, 0.5)}, []entity.Coordinate{theirGuard, at(2, 0.8, 1.5), at(3, 1, 2.5)}),
+				stones([]entity.Coordinate{at(2, 0.3, 0), at(3, 1.2, 0.5), at(4, 0.6, 1)}, []entity.Coordinate{theirGuard, at(2, 0.8, 1.5), at(3, 1, 2.5), at(4, 2.5, 1.7)}),
+			)},
+			shotNo: 2,
+			expected: []entity.Stones{
+				stones([]entity.Coordinate{ourGuard}, []entity.Coordinate{theirGuard}),
+				stones([]entity.Coordinate{ourGuard, at(2, 0.3, 0)}, []entity.Coordinate{theirGuard}),
+				stones([]entity.Coordinate{ourGuard, at(2, 0.3, 0), at(3, 1.2, 0.5)}, []entity.Coordinate{theirGuard, at(3, 1, 2.5)}),
+				stones([]entity.Coordinate{ourGuard, at(2, 0.3, 0), at(3, 1.2, 0.5), at(4, 0.6, 1)}, []entity.Coordinate{theirGuard, at(3, 1, 2.5), at(4, 2.5, 1.7)}),
+			},
+		},
+		{
+			name:         "正常系: 戻した石も後のショットで動いた分は動かす",
+			rule:         fourPlayer,
+			friendHammer: true,
+			end: entity.DataPerEnd{Shots: shots(
+				stones([]entity.Coordinate{ourGuard}, []entity.Coordinate{theirGuard}),
+				stones([]entity.Coordinate{at(2, 0.3, 0)}, []entity.Coordinate{theirGuard, at(2, 0.8, 1.5)}),
+				// their 5th stone of the end pushes our draw back, which is allowed
+				stones([]entity.Coordinate{at(2, 0.6, -1.2)}, []entity.Coordinate{theirGuard, at(2, 0.8, 1.5), at(3, 0.3, 0)}),
+			)},
+			shotNo: 2,
+			expected: []entity.Stones{
+				stones([]entity.Coordinate{ourGuard}, []entity.Coordinate{theirGuard}),
+				stones([]entity.Coordinate{ourGuard, at(2, 0.3, 0)}, []entity.Coordinate{theirGuard}),
+				stones([]entity.Coordinate{ourGuard, at(2, 0.6, -1.2)}, []entity.Coordinate{theirGuard, at(3, 0.3, 0)}),
+			},
+		},
+		{
+			name: "正常系: ミックスダブルスで自分の石を出した場合",
+			rule: mixedDoubles,
+			end: entity.DataPerEnd{PrePlaced: &entity.Stones{
+				FriendStones: []entity.Coordinate{prePlacedFriend},
+				EnemyStones:  []entity.Coordinate{prePlacedEnemy},
+			}, Shots: shots(
+				stones([]entity.Coordinate{at(1, 0.5, 2)}, []entity.Coordinate{prePlacedEnemy}),
+				stones([]entity.Coordinate{at(1, 0.5, 2), at(2, 0.9, 1.5)}, []entity.Coordinate{prePlacedEnemy, at(1, 2.5, 1.6)}),
+				stones([]entity.Coordinate{at(1, 0.5, 2), at(2, 0.9, 1.5), at(3, 0.1, 0)}, []entity.Coordinate{prePlacedEnemy, at(1, 2.5, 1.6), at(2, 1.3, 2)}),
+			)},
+			shotNo: 1,
+			expected: []entity.Stones{
+				stones([]entity.Coordinate{prePlacedFriend}, []entity.Coordinate{prePlacedEnemy}),
+				stones([]entity.Coordinate{prePlacedFriend, at(2, 0.9, 1.5)}, []entity.Coordinate{prePlacedEnemy, at(1, 2.5, 1.6)}),
+				stones([]entity.Coordinate{prePlacedFriend, at(2, 0.9, 1.5), at(3, 0.1, 0)}, []entity.Coordinate{prePlacedEnemy, at(1, 2.5, 1.6), at(2, 1.3, 2)}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			end := tt.end
+			geometry.CheckFreeGuardZone(tt.rule, &end, tt.friendHammer)
+			assert.NotNil(t, end.Shots[tt.shotNo-1].FreeGuardZoneViolation)
+
+			geometry.RestoreFreeGuardZone(&end, tt.shotNo)
+
+			actual := make([]entity.Stones, 0, len(end.Shots))
+			for _, shot := range end.Shots {
+				actual = append(actual, shot.Stones)
+			}
+			assert.Equal(t, tt.expected, actual)
+			assert.True(t, end.Shots[tt.shotNo-1].FreeGuardZoneViolation.Restored)
+
+			// the restored position breaks the rule nowhere else, so no penalty follows from it
+			geometry.CheckFreeGuardZone(tt.rule, &end, tt.friendHammer)
+			for i, shot := range end.Shots {
+				if i != tt.shotNo-1 {
+					assert.Nil(t, shot.FreeGuardZoneViolation, "shot %d", i+1)
+				}
+			}
+		})
+	}
+
+	t.Run("正常系: 戻されたショットは二度は戻さない", func(t *testing.T) {
+		end := entity.DataPerEnd{Shots: []entity.Shot{
+			{Stones: stones([]entity.Coordinate{ourGuard}, nil), FreeGuardZoneViolation: restoredShot3},
+		}}
+		geometry.RestoreFreeGuardZone(&end, 1)
+		assert.Equal(t, stones([]entity.Coordinate{ourGuard}, nil), end.Shots[0].Stones)
+	})
+}
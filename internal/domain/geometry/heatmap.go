@@ -0,0 +1,97 @@
+package geometry
+
+import (
+	"CurlARC/internal/domain/domainerr"
+	"CurlARC/internal/domain/entity"
+	"math"
+)
+
+// GridKind is the shape of the cells positions are binned into.
+type GridKind string
+
+const (
+	GridPolar     GridKind = "POLAR"     // rings around the button split into sectors
+	GridCartesian GridKind = "CARTESIAN" // square cells over the sheet from the back line to the hog line
+)
+
+// Grid splits the sheet into cells. CellSize is the side of a cell in a cartesian grid and the width of a ring
+// in a polar one, in metres. Sectors is the number of sectors each ring is split into.
+type Grid struct {
+	Kind     GridKind `json:"kind"`
+	CellSize float64  `json:"cell_size"`
+	Sectors  int      `json:"sectors,omitempty"`
+	Sheet    Sheet    `json:"sheet"`
+}
+
+// DefaultPolarGrid has rings a foot wide, so the 4-foot, 8-foot and 12-foot circles fall on ring boundaries,
+// split into eight sectors.
+var DefaultPolarGrid = Grid{Kind: GridPolar, CellSize: 0.3048, Sectors: 8, Sheet: DefaultSheet}
+
+// DefaultCartesianGrid has cells half a metre wide.
+var DefaultCartesianGrid = Grid{Kind: GridCartesian, CellSize: 0.5, Sheet: DefaultSheet}
+
+// Cell is one cell of a grid: a ring and a sector of a polar grid, or a row and a column of a cartesian one.
+// Rings are numbered from the button and sectors counter-clockwise from the right of the button;
+// rows are numbered from the back line and columns from the left edge of the sheet.
+type Cell struct {
+	Row    int
+	Column int
+}
+
+// CellBounds are the limits of a cell, in metres and radians for a polar grid and in sheet coordinates for a cartesian one.
+type CellBounds struct {
+	MinR, MaxR         float64
+	MinTheta, MaxTheta float64
+	Min, Max           Point
+}
+
+func (g Grid) Validate() error {
+	if err := g.Sheet.Validate(); err != nil {
+		return err
+	}
+	if g.CellSize <= 0 {
+		return domainerr.Invalid("cell_size", "the cell size must be positive")
+	}
+	if g.Kind == GridPolar && g.Sectors <= 0 {
+		return domainerr.Invalid("sectors", "a polar grid needs at least one sector")
+	}
+	if g.Kind != GridPolar && g.Kind != GridCartesian {
+		return domainerr.Invalid("grid", "the grid must be POLAR or CARTESIAN")
+	}
+	return nil
+}
+
+// Locate returns the cell a stone lies in. Stones off the sheet between the back line and the hog line are not in any cell.
+func (g Grid) Locate(stone entity.Coordinate) (Cell, bool) {
+	p := g.Sheet.ToCartesian(stone)
+	if p.Y < 0 || p.Y > g.Sheet.TeeToBackLine+g.Sheet.TeeToHogLine || math.Abs(p.X) > g.Sheet.Width/2 {
+		return Cell{}, false
+	}
+
+	if g.Kind == GridPolar {
+		theta := math.Mod(stone.Theta, 2*math.Pi)
+		if theta < 0 {
+			theta += 2 * math.Pi
+		}
+		sector := int(theta / (2 * math.Pi / float64(g.Sectors)))
+		return Cell{Row: int(stone.R / g.CellSize), Column: min(sector, g.Sectors-1)}, true
+	}
+	return Cell{Row: int(p.Y / g.CellSize), Column: int((p.X + g.Sheet.Width/2) / g.CellSize)}, true
+}
+
+// Bounds returns the limits of a cell.
+func (g Grid) Bounds(cell Cell) CellBounds {
+	if g.Kind == GridPolar {
+		sector := 2 * math.Pi / float64(g.Sectors)
+		return CellBounds{
+			MinR:     float64(cell.Row) * g.CellSize,
+			MaxR:     float64(cell.Row+1) * g.CellSize,
+			MinTheta: float64(cell.Column) * sector,
+			MaxTheta: float64(cell.Column+1) * sector,
+		}
+	}
+	return CellBounds{
+		Min: Point{X: float64(cell.Column)*g.CellSize - g.Sheet.Width/2, Y: float64(cell.Row) * g.CellSize},
+		Max: Point{X: float64(cell.Column+1)*g.CellSize - g.Sheet.Width/2, Y: float64(cell.Row+1) * g.CellSize},
+	}
+}
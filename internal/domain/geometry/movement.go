@@ -0,0 +1,122 @@
+package geometry
+
+import (
+	"CurlARC/internal/domain/entity"
+	"math"
+	"sort"
+)
+
+// MovementTolerance is the distance in metres under which a stone is considered not to have moved,
+// so that rounding in the recorded positions is not reported as movement.
+const MovementTolerance = 0.01
+
+// Movement is what a shot did to one stone.
+type Movement string
+
+const (
+	MovementPlaced    Movement = "PLACED"    // the stone came into play
+	MovementMoved     Movement = "MOVED"     // the stone was in play before and after, at another position
+	MovementRemoved   Movement = "REMOVED"   // the stone left play
+	MovementUnchanged Movement = "UNCHANGED" // the stone did not move
+)
+
+// Side tells which team a stone belongs to.
+type Side string
+
+const (
+	SideFriend Side = "FRIEND"
+	SideEnemy  Side = "ENEMY"
+)
+
+// StoneMovement is the change of one stone between two snapshots. From is nil for a placed stone
+// and To is nil for a removed one. The displacement is only set for moved stones.
+type StoneMovement struct {
+	Index    int
+	Side     Side
+	Movement Movement
+	From     *entity.Coordinate
+	To       *entity.Coordinate
+	DX       float64 // metres, positive to the right as seen by the thrower
+	DY       float64 // metres, positive towards the hog line
+	Distance float64
+}
+
+// ShotDiff is what one shot did to the stones in play.
+type ShotDiff struct {
+	Shot      int
+	Placed    int
+	Moved     int
+	Removed   int
+	Movements []StoneMovement
+}
+
+// DiffStones compares two snapshots stone by stone, matching stones of the same side by index.
+// Movements are ordered friend stones first, then by index.
+func DiffStones(before, after entity.Stones) []StoneMovement {
+	movements := diffSide(SideFriend, before.FriendStones, after.FriendStones)
+	return append(movements, diffSide(SideEnemy, before.EnemyStones, after.EnemyStones)...)
+}
+
+// DiffEnd compares every shot of an end with the position before it.
+// The first shot is compared with the pre-placed stones, or with an empty sheet.
+func DiffEnd(end entity.DataPerEnd) []ShotDiff {
+	var before entity.Stones
+	if end.PrePlaced != nil {
+		before = *end.PrePlaced
+	}
+
+	diffs := make([]ShotDiff, 0, len(end.Shots))
+	for i, shot := range end.Shots {
+		diff := ShotDiff{Shot: i + 1, Movements: DiffStones(before, shot.Stones)}
+		for _, m := range diff.Movements {
+			switch m.Movement {
+			case MovementPlaced:
+				diff.Placed++
+			case MovementMoved:
+				diff.Moved++
+			case MovementRemoved:
+				diff.Removed++
+			}
+		}
+		diffs = append(diffs, diff)
+		before = shot.Stones
+	}
+	return diffs
+}
+
+func diffSide(side Side, before, after []entity.Coordinate) []StoneMovement {
+	previous := make(map[int]entity.Coordinate, len(before))
+	for _, stone := range before {
+		previous[stone.Index] = stone
+	}
+
+	movements := make([]StoneMovement, 0, len(before)+len(after))
+	seen := make(map[int]bool, len(after))
+	for i := range after {
+		to := after[i]
+		seen[to.Index] = true
+		from, ok := previous[to.Index]
+		if !ok {
+			movements = append(movements, StoneMovement{Index: to.Index, Side: side, Movement: MovementPlaced, To: &to})
+			continue
+		}
+
+		fromX, fromY := ToCartesian(from)
+		toX, toY := ToCartesian(to)
+		m := StoneMovement{Index: to.Index, Side: side, Movement: MovementUnchanged, From: &from, To: &to}
+		if distance := math.Hypot(toX-fromX, toY-fromY); distance > MovementTolerance {
+			m.Movement = MovementMoved
+			m.DX, m.DY, m.Distance = toX-fromX, toY-fromY, distance
+		}
+		movements = append(movements, m)
+	}
+	for i := range before {
+		from := before[i]
+		if !seen[from.Index] {
+			movements = append(movements, StoneMovement{Index: from.Index, Side: side, Movement: MovementRemoved, From: &from})
+		}
+	}
+
+	sort.SliceStable(movements, func(i, j int) bool { return movements[i].Index < movements[j].Index })
+	return movements
+}
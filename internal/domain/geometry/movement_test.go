@@ -0,0 +1,168 @@
+package geometry_test
+
+import (
+	"math"
+	"testing"
+
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/geometry"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffStones(t *testing.T) {
+	stone := func(index int, r, theta float64) entity.Coordinate {
+		return entity.Coordinate{Index: index, R: r, Theta: theta}
+	}
+
+	tests := []struct {
+		name     string
+		before   entity.Stones
+		after    entity.Stones
+		expected []geometry.Movement
+	}{
+		{
+			name:     "正常系: 新しい石は置かれたものとして扱う",
+			after:    entity.Stones{FriendStones: []entity.Coordinate{stone(1, 0.5, 0)}},
+			expected: []geometry.Movement{geometry.MovementPlaced},
+		},
+		{
+			name:     "正常系: なくなった石は取り除かれたものとして扱う",
+			before:   entity.Stones{EnemyStones: []entity.Coordinate{stone(1, 0.5, 0)}},
+			expected: []geometry.Movement{geometry.MovementRemoved},
+		},
+		{
+			name:     "正常系: 位置の変わらない石は動いていない",
+			before:   entity.Stones{FriendStones: []entity.Coordinate{stone(1, 0.5, 0)}},
+			after:    entity.Stones{FriendStones: []entity.Coordinate{stone(1, 0.5, 0)}},
+			expected: []geometry.Movement{geometry.MovementUnchanged},
+		},
+		{
+			name:     "正常系: 許容誤差ちょうどの移動は動いていない",
+			before:   entity.Stones{FriendStones: []entity.Coordinate{stone(1, 0, 0)}},
+			after:    entity.Stones{FriendStones: []entity.Coordinate{stone(1, geometry.MovementTolerance, 0)}},
+			expected: []geometry.Movement{geometry.MovementUnchanged},
+		},
+		{
+			name:     "正常系: 許容誤差を超える移動は動いたものとして扱う",
+			before:   entity.Stones{FriendStones: []entity.Coordinate{stone(1, 0, 0)}},
+			after:    entity.Stones{FriendStones: []entity.Coordinate{stone(1, geometry.MovementTolerance*1.01, 0)}},
+			expected: []geometry.Movement{geometry.MovementMoved},
+		},
+		{
+			name:   "正常系: 同じ番号の石もチームごとに比べる",
+			before: entity.Stones{FriendStones: []entity.Coordinate{stone(1, 0.5, 0)}},
+			after:  entity.Stones{EnemyStones: []entity.Coordinate{stone(1, 0.5, 0)}},
+			// the friend stone left play and an enemy stone with the same index came in at the same place
+			expected: []geometry.Movement{geometry.MovementRemoved, geometry.MovementPlaced},
+		},
+		{
+			name:     "正常系: 空の配置どうしには変化がない",
+			expected: []geometry.Movement{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movements := geometry.DiffStones(tt.before, tt.after)
+
+			actual := make([]geometry.Movement, 0, len(movements))
+			for _, m := range movements {
+				actual = append(actual, m.Movement)
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+
+	t.Run("正常系: 移動量は投げる側から見た右と前を正とする", func(t *testing.T) {
+		before := entity.Stones{FriendStones: []entity.Coordinate{stone(2, 1, 0)}}
+		after := entity.Stones{FriendStones: []entity.Coordinate{stone(2, 1, math.Pi/2)}}
+
+		m := geometry.DiffStones(before, after)[0]
+		assert.Equal(t, geometry.SideFriend, m.Side)
+		assert.Equal(t, 2, m.Index)
+		assert.InDelta(t, -1, m.DX, 1e-9)
+		assert.InDelta(t, 1, m.DY, 1e-9)
+		assert.InDelta(t, math.Sqrt2, m.Distance, 1e-9)
+		assert.Equal(t, before.FriendStones[0], *m.From)
+		assert.Equal(t, after.FriendStones[0], *m.To)
+	})
+
+	t.Run("正常系: 動いていない石と置かれた石、取り除かれた石には移動量がない", func(t *testing.T) {
+		before := entity.Stones{FriendStones: []entity.Coordinate{stone(1, 1, 0), stone(2, 2, 0)}}
+		after := entity.Stones{FriendStones: []entity.Coordinate{stone(1, 1, 0), stone(3, 1, 1)}}
+
+		movements := geometry.DiffStones(before, after)
+		assert.Len(t, movements, 3)
+		for _, m := range movements {
+			assert.Zero(t, m.DX)
+			assert.Zero(t, m.DY)
+			assert.Zero(t, m.Distance)
+		}
+		assert.Nil(t, movements[1].To, "removed stone 2")
+		assert.Nil(t, movements[2].From, "placed stone 3")
+	})
+
+	t.Run("正常系: 味方の石が先に番号順で並ぶ", func(t *testing.T) {
+		after := entity.Stones{
+			FriendStones: []entity.Coordinate{stone(3, 1, 0), stone(1, 1, 1)},
+			EnemyStones:  []entity.Coordinate{stone(2, 1, 2)},
+		}
+
+		movements := geometry.DiffStones(entity.Stones{}, after)
+		assert.Equal(t, []int{1, 3, 2}, []int{movements[0].Index, movements[1].Index, movements[2].Index})
+		assert.Equal(t, []geometry.Side{geometry.SideFriend, geometry.SideFriend, geometry.SideEnemy},
+			[]geometry.Side{movements[0].Side, movements[1].Side, movements[2].Side})
+	})
+}
+
+func TestDiffEnd(t *testing.T) {
+	stone := func(index int, r float64) entity.Coordinate {
+		return entity.Coordinate{Index: index, R: r, Theta: math.Pi / 2}
+	}
+
+	t.Run("正常系: 最初のショットは空のシートと比べる", func(t *testing.T) {
+		end := entity.DataPerEnd{Shots: []entity.Shot{
+			{Stones: entity.Stones{FriendStones: []entity.Coordinate{stone(1, 0.5)}}},
+		}}
+
+		diffs := geometry.DiffEnd(end)
+		assert.Len(t, diffs, 1)
+		assert.Equal(t, 1, diffs[0].Shot)
+		assert.Equal(t, 1, diffs[0].Placed)
+		assert.Zero(t, diffs[0].Moved+diffs[0].Removed)
+	})
+
+	t.Run("正常系: 最初のショットは事前に置かれた石と比べる", func(t *testing.T) {
+		prePlaced := entity.Stones{FriendStones: []entity.Coordinate{stone(6, 1)}, EnemyStones: []entity.Coordinate{stone(6, 3)}}
+		end := entity.DataPerEnd{PrePlaced: &prePlaced, Shots: []entity.Shot{
+			{Stones: entity.Stones{FriendStones: []entity.Coordinate{stone(6, 1), stone(1, 0.2)}, EnemyStones: []entity.Coordinate{stone(6, 3)}}},
+		}}
+
+		diffs := geometry.DiffEnd(end)
+		assert.Equal(t, 1, diffs[0].Placed)
+		assert.Zero(t, diffs[0].Moved+diffs[0].Removed)
+	})
+
+	t.Run("正常系: 各ショットを直前の配置と比べて数える", func(t *testing.T) {
+		end := entity.DataPerEnd{Shots: []entity.Shot{
+			{Stones: entity.Stones{FriendStones: []entity.Coordinate{stone(1, 2)}}},
+			{Stones: entity.Stones{FriendStones: []entity.Coordinate{stone(1, 1)}, EnemyStones: []entity.Coordinate{stone(1, 0.5)}}},
+			{Stones: entity.Stones{EnemyStones: []entity.Coordinate{stone(1, 0.5)}}},
+			// stone 1 of the friend team is back in play with the same index after it was removed
+			{Stones: entity.Stones{FriendStones: []entity.Coordinate{stone(1, 3)}, EnemyStones: []entity.Coordinate{stone(1, 0.5)}}},
+		}}
+
+		diffs := geometry.DiffEnd(end)
+		counts := make([][3]int, 0, len(diffs))
+		for _, diff := range diffs {
+			counts = append(counts, [3]int{diff.Placed, diff.Moved, diff.Removed})
+		}
+		assert.Equal(t, [][3]int{{1, 0, 0}, {1, 1, 0}, {0, 0, 1}, {1, 0, 0}}, counts)
+		assert.Equal(t, []int{1, 2, 3, 4}, []int{diffs[0].Shot, diffs[1].Shot, diffs[2].Shot, diffs[3].Shot})
+	})
+
+	t.Run("正常系: ショットのないエンドには差分がない", func(t *testing.T) {
+		assert.Empty(t, geometry.DiffEnd(entity.DataPerEnd{}))
+	})
+}
@@ -0,0 +1,64 @@
+package geometry
+
+import (
+	"CurlARC/internal/domain/entity"
+	"sort"
+)
+
+// InHouse reports whether a stone touches the house of the default sheet, i.e. any part of it is within the 12-foot circle.
+func InHouse(stone entity.Coordinate) bool {
+	return DefaultSheet.InHouse(stone)
+}
+
+// StonesInHouse returns the friend and enemy stones in the house, each sorted from the closest to the button.
+func StonesInHouse(stones entity.Stones) (friends, enemies []entity.Coordinate) {
+	return sortedInHouse(stones.FriendStones), sortedInHouse(stones.EnemyStones)
+}
+
+func sortedInHouse(stones []entity.Coordinate) []entity.Coordinate {
+	inHouse := make([]entity.Coordinate, 0, len(stones))
+	for _, stone := range stones {
+		if InHouse(stone) {
+			inHouse = append(inHouse, stone)
+		}
+	}
+	sort.SliceStable(inHouse, func(i, j int) bool { return inHouse[i].R < inHouse[j].R })
+	return inHouse
+}
+
+// CountScore works out the score of an end from the final stone positions.
+// The side with the stone closest to the button scores one point for each of its stones
+// that is closer than the opponent's nearest stone in the house.
+// The result follows DataPerEnd.Score: positive when the friend team scores, negative when the enemy team does.
+func CountScore(stones entity.Stones) int {
+	friends, enemies := StonesInHouse(stones)
+
+	switch {
+	case len(friends) == 0 && len(enemies) == 0:
+		return 0
+	case len(enemies) == 0:
+		return len(friends)
+	case len(friends) == 0:
+		return -len(enemies)
+	}
+
+	if friends[0].R == enemies[0].R {
+		// the shot stone cannot be decided without a measure
+		return 0
+	}
+	if friends[0].R < enemies[0].R {
+		return countCloserThan(friends, enemies[0].R)
+	}
+	return -countCloserThan(enemies, friends[0].R)
+}
+
+func countCloserThan(stones []entity.Coordinate, r float64) int {
+	count := 0
+	for _, stone := range stones {
+		if stone.R >= r {
+			break
+		}
+		count++
+	}
+	return count
+}
@@ -0,0 +1,93 @@
+package geometry_test
+
+import (
+	"testing"
+
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/geometry"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountScore(t *testing.T) {
+	edge := entity.HouseRadius + entity.StoneRadius
+
+	tests := []struct {
+		name     string
+		friends  []entity.Coordinate
+		enemies  []entity.Coordinate
+		expected int
+	}{
+		{
+			name:     "正常系: ハウスに石がなければ0点",
+			expected: 0,
+		},
+		{
+			name:     "正常系: ハウスの外の石は数えない",
+			friends:  []entity.Coordinate{at(1, 3, 1.5)},
+			enemies:  []entity.Coordinate{at(1, edge+0.001, 0)},
+			expected: 0,
+		},
+		{
+			name:     "正常系: ハウスに触れている石は数える",
+			friends:  []entity.Coordinate{at(1, edge, 0)},
+			expected: 1,
+		},
+		{
+			name:     "正常系: ハウスにぎりぎり触れない石は数えない",
+			friends:  []entity.Coordinate{at(1, 0.5, 0), at(2, edge+0.001, 2)},
+			expected: 1,
+		},
+		{
+			name:     "正常系: 自チームの石だけならハウスの石をすべて数える",
+			friends:  []entity.Coordinate{at(1, 0.2, 0), at(2, 1.2, 1), at(3, edge, 3)},
+			expected: 3,
+		},
+		{
+			name:     "正常系: 相手の石だけなら相手の得点",
+			enemies:  []entity.Coordinate{at(1, 1.5, 0), at(2, 0.4, 2)},
+			expected: -2,
+		},
+		{
+			name:     "正常系: 相手の一番近い石より内側の石だけを数える",
+			friends:  []entity.Coordinate{at(1, 0.1, 0), at(2, 0.6, 1), at(3, 1.4, 2)},
+			enemies:  []entity.Coordinate{at(1, 0.9, 3), at(2, 0.3, 4)},
+			expected: 1,
+		},
+		{
+			name:     "正常系: 相手がナンバーワンなら負の得点",
+			friends:  []entity.Coordinate{at(1, 0.9, 0)},
+			enemies:  []entity.Coordinate{at(1, 0.3, 3), at(2, 0.5, 4), at(3, 1.5, 5)},
+			expected: -2,
+		},
+		{
+			name:     "正常系: 一番近い石が同じ距離なら0点",
+			friends:  []entity.Coordinate{at(1, 0.5, 0), at(2, 1.2, 1)},
+			enemies:  []entity.Coordinate{at(1, 0.5, 3)},
+			expected: 0,
+		},
+		{
+			name:     "正常系: 相手の石と同じ距離の石は数えない",
+			friends:  []entity.Coordinate{at(1, 0.2, 0), at(2, 0.8, 1)},
+			enemies:  []entity.Coordinate{at(1, 0.8, 3)},
+			expected: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, geometry.CountScore(stones(tt.friends, tt.enemies)))
+		})
+	}
+}
+
+func TestStonesInHouse(t *testing.T) {
+	t.Run("正常系: ハウスの石をボタンに近い順に返す", func(t *testing.T) {
+		friends, enemies := geometry.StonesInHouse(stones(
+			[]entity.Coordinate{at(1, 1.2, 0), at(2, 3, 1.5), at(3, 0.4, 2)},
+			[]entity.Coordinate{at(1, 2.5, 0)},
+		))
+		assert.Equal(t, []entity.Coordinate{at(3, 0.4, 2), at(1, 1.2, 0)}, friends)
+		assert.Empty(t, enemies)
+	})
+}
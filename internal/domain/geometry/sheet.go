@@ -0,0 +1,71 @@
+package geometry
+
+import (
+	"CurlARC/internal/domain/domainerr"
+	"CurlARC/internal/domain/entity"
+	"math"
+)
+
+// Sheet describes the dimensions of a sheet of ice in metres. Distances along the sheet are measured from the tee line.
+type Sheet struct {
+	HouseRadius   float64 `json:"house_radius"`
+	StoneRadius   float64 `json:"stone_radius"`
+	TeeToHogLine  float64 `json:"tee_to_hog_line"`
+	TeeToBackLine float64 `json:"tee_to_back_line"`
+	Width         float64 `json:"width"`
+}
+
+// DefaultSheet follows the World Curling rules.
+var DefaultSheet = Sheet{
+	HouseRadius:   entity.HouseRadius,
+	StoneRadius:   entity.StoneRadius,
+	TeeToHogLine:  entity.HogLineDistance,
+	TeeToBackLine: entity.BackLineDistance,
+	Width:         entity.SheetWidth,
+}
+
+// Validate checks every dimension is positive and the stones fit in the house.
+func (s Sheet) Validate() error {
+	if s.HouseRadius <= 0 || s.StoneRadius <= 0 || s.TeeToHogLine <= 0 || s.TeeToBackLine <= 0 || s.Width <= 0 {
+		return domainerr.Invalid("sheet", "sheet dimensions must be positive")
+	}
+	if s.StoneRadius >= s.HouseRadius {
+		return domainerr.Invalid("stone_radius", "the stone radius must be smaller than the house radius")
+	}
+	return nil
+}
+
+// Point is a position on the sheet in metres. The origin is where the centre line meets the back line,
+// x points to the right as seen by the thrower and y towards the hog line, so the button is at (0, TeeToBackLine).
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// ToCartesian converts a stone position to sheet coordinates.
+func (s Sheet) ToCartesian(stone entity.Coordinate) Point {
+	x, y := ToCartesian(stone)
+	return Point{X: x, Y: y + s.TeeToBackLine}
+}
+
+// FromCartesian converts sheet coordinates back to a stone position around the button.
+func (s Sheet) FromCartesian(index int, p Point) entity.Coordinate {
+	x, y := p.X, p.Y-s.TeeToBackLine
+	return entity.Coordinate{Index: index, R: math.Hypot(x, y), Theta: math.Atan2(y, x)}
+}
+
+// Contains reports whether a point lies on the sheet between the back line and the hog line.
+func (s Sheet) Contains(p Point) bool {
+	return math.Abs(p.X) <= s.Width/2 && p.Y >= 0 && p.Y <= s.TeeToBackLine+s.TeeToHogLine
+}
+
+// InHouse reports whether a stone touches the house.
+func (s Sheet) InHouse(stone entity.Coordinate) bool {
+	return stone.R <= s.HouseRadius+s.StoneRadius
+}
+
+// InFreeGuardZone reports whether a stone lies between the tee line and the hog line without touching the house.
+func (s Sheet) InFreeGuardZone(stone entity.Coordinate) bool {
+	_, y := ToCartesian(stone)
+	return y > 0 && y < s.TeeToHogLine && !s.InHouse(stone)
+}
@@ -0,0 +1,127 @@
+package geometry_test
+
+import (
+	"math"
+	"testing"
+
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/geometry"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSheetToCartesian(t *testing.T) {
+	sheet := geometry.DefaultSheet
+
+	tests := []struct {
+		name     string
+		stone    entity.Coordinate
+		expected geometry.Point
+	}{
+		{
+			name:     "正常系: ボタンはバックラインからティーラインまでの距離にある",
+			stone:    entity.Coordinate{R: 0, Theta: 0},
+			expected: geometry.Point{X: 0, Y: sheet.TeeToBackLine},
+		},
+		{
+			name:     "正常系: 角度0は投げる側から見て右",
+			stone:    entity.Coordinate{R: 1, Theta: 0},
+			expected: geometry.Point{X: 1, Y: sheet.TeeToBackLine},
+		},
+		{
+			name:     "正常系: 角度π/2はホッグライン側",
+			stone:    entity.Coordinate{R: 1, Theta: math.Pi / 2},
+			expected: geometry.Point{X: 0, Y: sheet.TeeToBackLine + 1},
+		},
+		{
+			name:     "正常系: 角度πは投げる側から見て左",
+			stone:    entity.Coordinate{R: 1, Theta: math.Pi},
+			expected: geometry.Point{X: -1, Y: sheet.TeeToBackLine},
+		},
+		{
+			name:     "正常系: 角度-π/2はティーラインより後ろ",
+			stone:    entity.Coordinate{R: 1, Theta: -math.Pi / 2},
+			expected: geometry.Point{X: 0, Y: sheet.TeeToBackLine - 1},
+		},
+		{
+			name:     "正常系: バックライン上の石はyが0",
+			stone:    entity.Coordinate{R: sheet.TeeToBackLine, Theta: -math.Pi / 2},
+			expected: geometry.Point{X: 0, Y: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := sheet.ToCartesian(tt.stone)
+			assert.InDelta(t, tt.expected.X, actual.X, 1e-9)
+			assert.InDelta(t, tt.expected.Y, actual.Y, 1e-9)
+		})
+	}
+
+	t.Run("正常系: バックラインまでの距離はシートの寸法に従う", func(t *testing.T) {
+		custom := geometry.DefaultSheet
+		custom.TeeToBackLine = 2.5
+
+		actual := custom.ToCartesian(entity.Coordinate{R: 1, Theta: math.Pi / 2})
+		assert.InDelta(t, 0, actual.X, 1e-9)
+		assert.InDelta(t, 3.5, actual.Y, 1e-9)
+	})
+}
+
+func TestSheetFromCartesian(t *testing.T) {
+	custom := geometry.DefaultSheet
+	custom.TeeToBackLine = 2.5
+
+	stones := []entity.Coordinate{
+		{Index: 1, R: 0, Theta: 0},
+		{Index: 2, R: 0.5, Theta: 0.3},
+		{Index: 3, R: 1.8, Theta: 2.5},
+		{Index: 4, R: 1.2, Theta: -1},
+		{Index: 5, R: 6, Theta: math.Pi / 2},
+	}
+
+	for _, sheet := range []geometry.Sheet{geometry.DefaultSheet, custom} {
+		for _, stone := range stones {
+			actual := sheet.FromCartesian(stone.Index, sheet.ToCartesian(stone))
+			assert.Equal(t, stone.Index, actual.Index)
+			assert.InDelta(t, stone.R, actual.R, 1e-9)
+			if stone.R > 0 {
+				assert.InDelta(t, stone.Theta, actual.Theta, 1e-9)
+			}
+		}
+	}
+
+	t.Run("正常系: ボタンの位置は中心からの距離が0になる", func(t *testing.T) {
+		actual := custom.FromCartesian(7, geometry.Point{X: 0, Y: 2.5})
+		assert.Equal(t, entity.Coordinate{Index: 7, R: 0, Theta: 0}, actual)
+	})
+
+	t.Run("正常系: 投げる側から見て左前の点", func(t *testing.T) {
+		actual := geometry.DefaultSheet.FromCartesian(1, geometry.Point{X: -1, Y: geometry.DefaultSheet.TeeToBackLine + 1})
+		assert.InDelta(t, math.Sqrt2, actual.R, 1e-9)
+		assert.InDelta(t, 3*math.Pi/4, actual.Theta, 1e-9)
+	})
+}
+
+func TestSheetContains(t *testing.T) {
+	sheet := geometry.DefaultSheet
+
+	tests := []struct {
+		name     string
+		point    geometry.Point
+		expected bool
+	}{
+		{name: "正常系: ボタン", point: geometry.Point{X: 0, Y: sheet.TeeToBackLine}, expected: true},
+		{name: "正常系: バックライン上の角", point: geometry.Point{X: -sheet.Width / 2, Y: 0}, expected: true},
+		{name: "正常系: ホッグライン上", point: geometry.Point{X: 1, Y: sheet.TeeToBackLine + sheet.TeeToHogLine}, expected: true},
+		{name: "正常系: サイドラインの外", point: geometry.Point{X: sheet.Width/2 + 0.01, Y: 1}, expected: false},
+		{name: "正常系: バックラインの後ろ", point: geometry.Point{X: 0, Y: -0.01}, expected: false},
+		{name: "正常系: ホッグラインの手前", point: geometry.Point{X: 0, Y: sheet.TeeToBackLine + sheet.TeeToHogLine + 0.01}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, sheet.Contains(tt.point))
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package geometry
+
+import (
+	"CurlARC/internal/domain/entity"
+	"math"
+)
+
+// UnmatchedStonePenalty is the distance in metres charged for a stone in play in one position
+// with no counterpart in the other, about the width of the house.
+const UnmatchedStonePenalty = 2.0
+
+// PositionDistance measures how far apart two positions are. The stones of each side are paired so that
+// the total distance between paired stones is the smallest possible, whatever their indices, and every
+// stone left over costs UnmatchedStonePenalty. Identical positions are at distance 0.
+func PositionDistance(a, b entity.Stones) float64 {
+	return sideDistance(a.FriendStones, b.FriendStones) + sideDistance(a.EnemyStones, b.EnemyStones)
+}
+
+// sideDistance finds the best pairing with a dynamic programme over the subsets of the larger side,
+// which stays small as a side never has more than eight stones in play.
+func sideDistance(a, b []entity.Coordinate) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	points := func(stones []entity.Coordinate) []Point {
+		converted := make([]Point, 0, len(stones))
+		for _, stone := range stones {
+			converted = append(converted, DefaultSheet.ToCartesian(stone))
+		}
+		return converted
+	}
+	small, large := points(a), points(b)
+
+	// best[mask] is the smallest cost of pairing the first stones of the smaller side with the stones in mask
+	best := make([]float64, 1<<len(large))
+	for mask := range best {
+		best[mask] = math.Inf(1)
+	}
+	best[0] = 0
+	for mask := range best {
+		i := popCount(mask)
+		if i >= len(small) || math.IsInf(best[mask], 1) {
+			continue
+		}
+		for j, p := range large {
+			if mask&(1<<j) != 0 {
+				continue
+			}
+			cost := best[mask] + math.Hypot(small[i].X-p.X, small[i].Y-p.Y)
+			if next := mask | 1<<j; cost < best[next] {
+				best[next] = cost
+			}
+		}
+	}
+
+	distance := math.Inf(1)
+	for mask, cost := range best {
+		if popCount(mask) == len(small) && cost < distance {
+			distance = cost
+		}
+	}
+	return distance + float64(len(large)-len(small))*UnmatchedStonePenalty
+}
+
+func popCount(mask int) int {
+	n := 0
+	for ; mask != 0; mask &= mask - 1 {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,13 @@
+package repository
+
+import "CurlARC/internal/domain/entity"
+
+type EventRepository interface {
+	Save(event *entity.Event) (*entity.Event, error)
+	FindById(id string) (*entity.Event, error)
+	FindByTeamId(teamId string) ([]*entity.Event, error)
+	// FindByRecordId returns the event a record is attached to, or nil when it is not attached to any.
+	FindByRecordId(recordId string) (*entity.Event, error)
+	Update(event *entity.Event) (*entity.Event, error)
+	Delete(id string) error
+}
@@ -0,0 +1,11 @@
+package repository
+
+import "CurlARC/internal/domain/entity"
+
+type PracticeSessionRepository interface {
+	Save(session *entity.PracticeSession) (*entity.PracticeSession, error)
+	FindById(id string) (*entity.PracticeSession, error)
+	FindByTeamId(teamId string) ([]*entity.PracticeSession, error)
+	Update(session *entity.PracticeSession) (*entity.PracticeSession, error)
+	Delete(id string) error
+}
@@ -3,13 +3,90 @@ package repository
 import (
 	"CurlARC/internal/domain/entity"
 	"CurlARC/internal/handler/response"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
 )
 
 type RecordRepository interface {
 	Save(entity.Record) (*entity.Record, error)
 	FindByRecordId(recordId string) (*entity.Record, error)
-	FindIndicesByTeamId(teamId string) (*[]response.RecordIndex, error)
+	// FindIndicesByTeamId returns one page of the team's records matching the query.
+	FindIndicesByTeamId(teamId string, query RecordQuery) (*RecordIndexPage, error)
 	FindByTeamId(teamId string) (*[]entity.Record, error)
+	FindByIds(recordIds []string) (*[]entity.Record, error)
+	// EachWithEndsData calls fn with every record of the team that has ends data, and with every public one
+	// when includePublic is set. Records are loaded in batches and iteration stops at the first error.
+	EachWithEndsData(teamId string, includePublic bool, fn func(entity.Record) error) error
 	Update(record entity.Record) (*entity.Record, error)
 	Delete(recordId string) error
 }
+
+// RecordSort is the order records are listed in. Records with the same sort key are ordered by ID.
+type RecordSort string
+
+const (
+	SortDateDesc     RecordSort = "-date"
+	SortDateAsc      RecordSort = "date"
+	SortOpponentAsc  RecordSort = "opponent"
+	SortOpponentDesc RecordSort = "-opponent"
+	SortPlaceAsc     RecordSort = "place"
+	SortPlaceDesc    RecordSort = "-place"
+)
+
+func (s RecordSort) IsValid() bool {
+	switch s {
+	case SortDateDesc, SortDateAsc, SortOpponentAsc, SortOpponentDesc, SortPlaceAsc, SortPlaceDesc:
+		return true
+	}
+	return false
+}
+
+// RecordQuery selects and orders the records of a listing. Zero values mean no restriction.
+// Opponent is compared like team names elsewhere, ignoring case and whitespace, and Place matches any part of the place.
+type RecordQuery struct {
+	From        time.Time
+	To          time.Time
+	Result      entity.Result
+	Opponent    string
+	Place       string
+	IsPublic    *bool
+	HasEndsData *bool
+	Sort        RecordSort
+	Cursor      *RecordCursor
+	Limit       int
+}
+
+// RecordCursor points just after the last record of a page: its sort key, written as text, and its ID.
+// It is only valid for the sort it was made for.
+type RecordCursor struct {
+	Sort  RecordSort `json:"s"`
+	Value string     `json:"v"`
+	Id    string     `json:"id"`
+}
+
+// Encode turns the cursor into an opaque token to hand to clients.
+func (c RecordCursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeRecordCursor reads a token made by Encode.
+func DecodeRecordCursor(token string) (*RecordCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	var cursor RecordCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.Id == "" || !cursor.Sort.IsValid() {
+		return nil, errors.New("invalid cursor")
+	}
+	return &cursor, nil
+}
+
+// RecordIndexPage is one page of a listing. NextCursor is nil on the last page.
+type RecordIndexPage struct {
+	Indices    []response.RecordIndex
+	NextCursor *RecordCursor
+}
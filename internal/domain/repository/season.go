@@ -0,0 +1,11 @@
+package repository
+
+import "CurlARC/internal/domain/entity"
+
+type SeasonRepository interface {
+	Save(season *entity.Season) (*entity.Season, error)
+	FindById(id string) (*entity.Season, error)
+	FindByTeamId(teamId string) ([]*entity.Season, error)
+	Update(season *entity.Season) (*entity.Season, error)
+	Delete(id string) error
+}
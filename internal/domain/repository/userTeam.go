@@ -4,13 +4,19 @@ import "CurlARC/internal/domain/entity"
 
 type UserTeamRepository interface {
 	Save(userTeam *entity.UserTeam) (*entity.UserTeam, error)
-	FindUsersByTeamId(teamId string) ([]string, error)        // All users including INVITED users
-	FindMembersByTeamId(teamId string) ([]string, error)      // Only MEMBERS
-	FindInvitedUsersByTeamId(teamId string) ([]string, error) // Only INVITED users
+	SaveInvitation(userTeam *entity.UserTeam) (*entity.UserTeam, error) // Reopens the invitation if the user was already invited
+	Find(userId, teamId string) (*entity.UserTeam, error)
+	FindUsersByTeamId(teamId string) ([]string, error)                 // All users including INVITED users
+	FindMembersByTeamId(teamId string) ([]string, error)               // Only MEMBERS
+	FindInvitationsByTeamId(teamId string) ([]*entity.UserTeam, error) // Only INVITED users, expired ones included
 	FindTeamsByUserId(userId string) ([]string, error)
-	FindInvitedTeamsByUserId(userId string) ([]string, error) // Only INVITED teams
-	UpdateState(userTeam *entity.UserTeam) (*entity.UserTeam, error)
+	FindInvitationsByUserId(userId string) ([]*entity.UserTeam, error) // Only INVITED teams, expired ones included
+	FindMembershipsByTeamId(teamId string) ([]*entity.UserTeam, error) // Only MEMBERS, with their roles
+	UpdateState(userTeam *entity.UserTeam) (*entity.UserTeam, error)   // Answers a pending invitation; accepting also needs it unexpired
+	UpdateRole(userTeam *entity.UserTeam) (*entity.UserTeam, error)
+	TransferOwnership(teamId, fromUserId, toUserId string) error // The old owner becomes an ADMIN
 	Delete(userId, teamId string) error
 
 	IsMember(userId, teamId string) (bool, error)
+	FindRole(userId, teamId string) (entity.TeamRole, error) // RoleNone unless the user is a MEMBER
 }
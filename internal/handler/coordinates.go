@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"CurlARC/internal/domain/domainerr"
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/geometry"
+	"CurlARC/internal/handler/request"
+	"CurlARC/internal/handler/response"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/datatypes"
+)
+
+// coordinateSystem is how stone positions are written in record requests and responses.
+type coordinateSystem struct {
+	cartesian bool
+	sheet     geometry.Sheet
+}
+
+// sheetParams are the query parameters overriding the dimensions of the default sheet, in metres.
+var sheetParams = []struct {
+	name  string
+	field func(*geometry.Sheet) *float64
+}{
+	{"house_radius", func(s *geometry.Sheet) *float64 { return &s.HouseRadius }},
+	{"stone_radius", func(s *geometry.Sheet) *float64 { return &s.StoneRadius }},
+	{"tee_to_hog_line", func(s *geometry.Sheet) *float64 { return &s.TeeToHogLine }},
+	{"tee_to_back_line", func(s *geometry.Sheet) *float64 { return &s.TeeToBackLine }},
+	{"sheet_width", func(s *geometry.Sheet) *float64 { return &s.Width }},
+}
+
+// parseCoordinateSystem reads ?coords=polar|cartesian. Cartesian positions use the default sheet,
+// whose dimensions can be overridden with the sheet parameters.
+func parseCoordinateSystem(c echo.Context) (coordinateSystem, error) {
+	system := coordinateSystem{sheet: geometry.DefaultSheet}
+	switch c.QueryParam("coords") {
+	case "", "polar":
+		return system, nil
+	case "cartesian":
+		system.cartesian = true
+	default:
+		return system, fmt.Errorf("coords must be polar or cartesian, got %q", c.QueryParam("coords"))
+	}
+
+	sheet, err := parseSheet(c)
+	system.sheet = sheet
+	return system, err
+}
+
+// parseSheet returns the default sheet with the dimensions given in the sheet parameters.
+func parseSheet(c echo.Context) (geometry.Sheet, error) {
+	sheet := geometry.DefaultSheet
+	for _, param := range sheetParams {
+		raw := c.QueryParam(param.name)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return sheet, fmt.Errorf("%s must be a number of metres", param.name)
+		}
+		*param.field(&sheet) = value
+	}
+	return sheet, sheet.Validate()
+}
+
+func (s coordinateSystem) response() response.Coordinates {
+	if !s.cartesian {
+		return response.Coordinates{System: "POLAR"}
+	}
+	sheet := s.sheet
+	return response.Coordinates{System: "CARTESIAN", Sheet: &sheet}
+}
+
+// position converts a stone position to the coordinate system. A missing stone stays an untyped nil,
+// so that omitempty leaves it out of the response.
+func (s coordinateSystem) position(stone *entity.Coordinate) interface{} {
+	if stone == nil {
+		return nil
+	}
+	if !s.cartesian {
+		return stone
+	}
+	converted := s.stone(*stone)
+	return &converted
+}
+
+func (s coordinateSystem) stone(stone entity.Coordinate) response.CartesianStone {
+	p := s.sheet.ToCartesian(stone)
+	return response.CartesianStone{Index: stone.Index, X: p.X, Y: p.Y, InHouse: s.sheet.InHouse(stone)}
+}
+
+func (s coordinateSystem) stones(stones entity.Stones) response.CartesianStones {
+	converted := response.CartesianStones{
+		FriendStones: make([]response.CartesianStone, 0, len(stones.FriendStones)),
+		EnemyStones:  make([]response.CartesianStone, 0, len(stones.EnemyStones)),
+	}
+	for _, stone := range stones.FriendStones {
+		converted.FriendStones = append(converted.FriendStones, s.stone(stone))
+	}
+	for _, stone := range stones.EnemyStones {
+		converted.EnemyStones = append(converted.EnemyStones, s.stone(stone))
+	}
+	return converted
+}
+
+// endsData returns the ends data of a record as JSON in the coordinate system.
+func (s coordinateSystem) endsData(record *entity.Record) (datatypes.JSON, error) {
+	if !s.cartesian {
+		return record.GetEndsDataAsJSON(), nil
+	}
+
+	ends := make([]response.CartesianEnd, 0, len(record.GetEndsData()))
+	for _, end := range record.GetEndsData() {
+		converted := response.CartesianEnd{DataPerEnd: end, Shots: make([]response.CartesianShot, 0, len(end.Shots))}
+		if end.PrePlaced != nil {
+			prePlaced := s.stones(*end.PrePlaced)
+			converted.PrePlaced = &prePlaced
+		}
+		for _, shot := range end.Shots {
+			converted.Shots = append(converted.Shots, response.CartesianShot{Shot: shot, Stones: s.stones(shot.Stones)})
+		}
+		ends = append(ends, converted)
+	}
+
+	endsDataJSON, err := json.Marshal(ends)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal ends data: %w", err)
+	}
+	return endsDataJSON, nil
+}
+
+// bind binds a request which may carry ends data. With cartesian coordinates the stones in ends_data are
+// positions on the sheet, so they are read from the body a second time and returned for fromCartesian.
+func (s coordinateSystem) bind(c echo.Context, req interface{}) ([]request.CartesianEnd, error) {
+	if !s.cartesian {
+		if err := c.Bind(req); err != nil {
+			return nil, badRequest("invalid request")
+		}
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return nil, badRequest("invalid request")
+	}
+	c.Request().Body = io.NopCloser(bytes.NewReader(body))
+	if err := c.Bind(req); err != nil {
+		return nil, badRequest("invalid request")
+	}
+
+	var cartesian struct {
+		EndsData []request.CartesianEnd `json:"ends_data"`
+	}
+	if err := json.Unmarshal(body, &cartesian); err != nil {
+		return nil, badRequest("invalid request")
+	}
+	return cartesian.EndsData, nil
+}
+
+// fromCartesian puts the stones read by bind into the ends data as positions around the button.
+// Every stone must lie on the sheet between the back line and the hog line.
+func (s coordinateSystem) fromCartesian(ends []entity.DataPerEnd, cartesian []request.CartesianEnd) error {
+	if !s.cartesian {
+		return nil
+	}
+
+	// both were read from the same ends_data, so the ends and shots line up
+	for i := range ends {
+		if prePlaced := cartesian[i].PrePlaced; prePlaced != nil {
+			stones, err := s.polarStones(*prePlaced, i+1, 0)
+			if err != nil {
+				return err
+			}
+			ends[i].PrePlaced = &stones
+		}
+		for j := range ends[i].Shots {
+			stones, err := s.polarStones(cartesian[i].Shots[j].Stones, i+1, j+1)
+			if err != nil {
+				return err
+			}
+			ends[i].Shots[j].Stones = stones
+		}
+	}
+	return nil
+}
+
+// polarStones converts the stones after a shot, numbered from 1 with 0 for the pre-placed stones.
+func (s coordinateSystem) polarStones(stones request.CartesianStones, end, shot int) (entity.Stones, error) {
+	convert := func(cartesian []request.CartesianStone) ([]entity.Coordinate, error) {
+		converted := make([]entity.Coordinate, 0, len(cartesian))
+		for _, stone := range cartesian {
+			p := geometry.Point{X: stone.X, Y: stone.Y}
+			if !s.sheet.Contains(p) {
+				return nil, domainerr.Invalid("ends_data", "stone %d after shot %d of end %d is off the sheet at (%g, %g)", stone.Index, shot, end, p.X, p.Y)
+			}
+			converted = append(converted, s.sheet.FromCartesian(stone.Index, p))
+		}
+		return converted, nil
+	}
+
+	friends, err := convert(stones.FriendStones)
+	if err != nil {
+		return entity.Stones{}, err
+	}
+	enemies, err := convert(stones.EnemyStones)
+	if err != nil {
+		return entity.Stones{}, err
+	}
+	return entity.Stones{FriendStones: friends, EnemyStones: enemies}, nil
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"CurlARC/internal/domain/domainerr"
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/geometry"
+	"CurlARC/internal/handler/request"
+	"CurlARC/internal/handler/response"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCoordinateSystem(t *testing.T) {
+	e := echo.New()
+	newContext := func(query string) echo.Context {
+		req := httptest.NewRequest(http.MethodGet, "/records?"+query, nil)
+		return e.NewContext(req, httptest.NewRecorder())
+	}
+
+	custom := geometry.DefaultSheet
+	custom.HouseRadius = 2
+	custom.TeeToBackLine = 2.5
+
+	tests := []struct {
+		name     string
+		query    string
+		expected coordinateSystem
+		wantErr  bool
+	}{
+		{
+			name:     "正常系: 指定がなければ極座標",
+			query:    "",
+			expected: coordinateSystem{sheet: geometry.DefaultSheet},
+		},
+		{
+			name:     "正常系: 極座標を指定する",
+			query:    "coords=polar",
+			expected: coordinateSystem{sheet: geometry.DefaultSheet},
+		},
+		{
+			name:     "正常系: 極座標ではシートの寸法を無視する",
+			query:    "coords=polar&house_radius=abc",
+			expected: coordinateSystem{sheet: geometry.DefaultSheet},
+		},
+		{
+			name:     "正常系: 直交座標は標準のシートを使う",
+			query:    "coords=cartesian",
+			expected: coordinateSystem{cartesian: true, sheet: geometry.DefaultSheet},
+		},
+		{
+			name:     "正常系: 直交座標でシートの寸法を指定する",
+			query:    "coords=cartesian&house_radius=2&tee_to_back_line=2.5",
+			expected: coordinateSystem{cartesian: true, sheet: custom},
+		},
+		{
+			name:    "異常系: 不明な座標系",
+			query:   "coords=spherical",
+			wantErr: true,
+		},
+		{
+			name:    "異常系: 数値でないシートの寸法",
+			query:   "coords=cartesian&sheet_width=wide",
+			wantErr: true,
+		},
+		{
+			name:    "異常系: 正でないシートの寸法",
+			query:   "coords=cartesian&tee_to_hog_line=0",
+			wantErr: true,
+		},
+		{
+			name:    "異常系: ハウスより大きい石",
+			query:   "coords=cartesian&stone_radius=2",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseCoordinateSystem(newContext(tt.query))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestCoordinateSystemPosition(t *testing.T) {
+	stone := &entity.Coordinate{Index: 1, R: 1, Theta: 0}
+
+	t.Run("正常系: 石がなければ型のないnilを返す", func(t *testing.T) {
+		assert.True(t, coordinateSystem{}.position(nil) == nil)
+		assert.True(t, coordinateSystem{cartesian: true, sheet: geometry.DefaultSheet}.position(nil) == nil)
+	})
+
+	t.Run("正常系: 極座標ではそのまま返す", func(t *testing.T) {
+		assert.Equal(t, stone, coordinateSystem{}.position(stone))
+	})
+
+	t.Run("正常系: 直交座標ではシート上の位置に変換する", func(t *testing.T) {
+		actual := coordinateSystem{cartesian: true, sheet: geometry.DefaultSheet}.position(stone)
+		assert.Equal(t, geometry.DefaultSheet.ToCartesian(*stone).X, actual.(*response.CartesianStone).X)
+	})
+}
+
+func TestCoordinateSystemBind(t *testing.T) {
+	e := echo.New()
+	newContext := func(body string) echo.Context {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		return e.NewContext(req, httptest.NewRecorder())
+	}
+	sheet := geometry.DefaultSheet
+	cartesian := coordinateSystem{cartesian: true, sheet: sheet}
+
+	t.Run("正常系: 極座標はそのまま読む", func(t *testing.T) {
+		var req request.AppendEndDataRequest
+		c := newContext(`{"ends_data": [{"score": 1, "shots": [{"type": "DRAW", "stones": {"friend_stones": [{"index": 1, "r": 0.5, "theta": 1}]}}]}]}`)
+
+		ends, err := coordinateSystem{sheet: sheet}.bind(c, &req)
+		assert.NoError(t, err)
+		assert.Nil(t, ends)
+		assert.NoError(t, coordinateSystem{sheet: sheet}.fromCartesian(req.EndsData, ends))
+		assert.Equal(t, []entity.Coordinate{{Index: 1, R: 0.5, Theta: 1}}, req.EndsData[0].Shots[0].Stones.FriendStones)
+	})
+
+	t.Run("正常系: 直交座標の石をボタンからの位置に変換する", func(t *testing.T) {
+		var req request.AppendEndDataRequest
+		c := newContext(`{"free_guard_zone": "REJECT", "ends_data": [{"score": 2, "pre_placed": {"friend_stones": [{"index": 6, "x": 0, "y": 2.829}]}, "shots": [
+			{"type": "DRAW", "rotation": "IN_TURN", "stones": {"friend_stones": [{"index": 1, "x": 1, "y": 1.829}], "enemy_stones": [{"index": 1, "x": 0, "y": 4.829}]}}
+		]}]}`)
+
+		ends, err := cartesian.bind(c, &req)
+		assert.NoError(t, err)
+		assert.NoError(t, cartesian.fromCartesian(req.EndsData, ends))
+
+		end := req.EndsData[0]
+		assert.Equal(t, 2, end.Score)
+		assert.Equal(t, "REJECT", req.FreeGuardZone)
+		assert.Equal(t, entity.ShotDraw, end.Shots[0].Type)
+		assert.Equal(t, entity.InTurn, end.Shots[0].Rotation)
+
+		expected := []entity.Coordinate{
+			sheet.FromCartesian(6, geometry.Point{X: 0, Y: 2.829}),
+			sheet.FromCartesian(1, geometry.Point{X: 1, Y: 1.829}),
+			sheet.FromCartesian(1, geometry.Point{X: 0, Y: 4.829}),
+		}
+		actual := []entity.Coordinate{end.PrePlaced.FriendStones[0], end.Shots[0].Stones.FriendStones[0], end.Shots[0].Stones.EnemyStones[0]}
+		for i := range expected {
+			assert.Equal(t, expected[i].Index, actual[i].Index)
+			assert.InDelta(t, expected[i].R, actual[i].R, 1e-9)
+			assert.InDelta(t, expected[i].Theta, actual[i].Theta, 1e-9)
+		}
+		// the first stone is a metre to the right of the button
+		assert.InDelta(t, 1.0, actual[1].R, 1e-9)
+		assert.InDelta(t, 0.0, actual[1].Theta, 1e-9)
+	})
+
+	t.Run("異常系: シートの外の石", func(t *testing.T) {
+		for _, stone := range []string{
+			`{"index": 1, "x": 3, "y": 1}`,
+			`{"index": 1, "x": 0, "y": -0.1}`,
+			`{"index": 1, "x": 0, "y": 10}`,
+		} {
+			var req request.AppendEndDataRequest
+			c := newContext(`{"ends_data": [{"shots": [{"stones": {"enemy_stones": [` + stone + `]}}]}]}`)
+
+			ends, err := cartesian.bind(c, &req)
+			assert.NoError(t, err)
+			err = cartesian.fromCartesian(req.EndsData, ends)
+			assert.True(t, domainerr.IsKind(err, domainerr.KindValidation), stone)
+		}
+	})
+
+	t.Run("異常系: 読めないリクエスト", func(t *testing.T) {
+		var req request.AppendEndDataRequest
+		_, err := cartesian.bind(newContext(`{"ends_data": [{"shots": [{"stones": {"friend_stones": [{"x": "left"}]}}]}]}`), &req)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"CurlARC/internal/domain/domainerr"
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/handler/response"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// codeInternal is the code of unexpected failures. Their cause is logged and never sent to the client,
+// since it may carry SQL or driver details.
+const codeInternal = "INTERNAL"
+
+// badRequest reports a request the handler cannot read, such as a malformed body or parameter.
+func badRequest(message string) error {
+	return echo.NewHTTPError(http.StatusBadRequest, message)
+}
+
+// codeOfStatus names the errors reported as HTTP errors after their status, e.g. BAD_REQUEST.
+func codeOfStatus(status int) string {
+	return strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+}
+
+var statusOfKind = map[domainerr.Kind]int{
+	domainerr.KindNotFound:   http.StatusNotFound,
+	domainerr.KindForbidden:  http.StatusForbidden,
+	domainerr.KindConflict:   http.StatusConflict,
+	domainerr.KindValidation: http.StatusUnprocessableEntity,
+}
+
+// ErrorHandler writes the errors returned by the handlers. Domain errors get the status of their kind and
+// their code, invalid ends data lists its violations, HTTP errors are named after their status,
+// and anything else is an internal server error.
+func ErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	detail := response.ErrorDetail{Code: http.StatusInternalServerError, ErrorCode: codeInternal, Message: "internal server error"}
+	var validationErr *entity.EndsDataValidationError
+	var domainErr *domainerr.Error
+	var httpErr *echo.HTTPError
+	switch {
+	case errors.As(err, &validationErr):
+		detail.Code = http.StatusUnprocessableEntity
+		detail.ErrorCode = entity.CodeInvalidEndsData
+		detail.Message = "invalid ends data"
+		detail.Details = validationErr.Violations
+	case errors.As(err, &domainErr):
+		detail.Code = statusOfKind[domainErr.Kind]
+		detail.ErrorCode = domainErr.Code
+		detail.Message = domainErr.Message
+		if len(domainErr.Fields) > 0 {
+			detail.Details = domainErr.Fields
+		}
+	case errors.As(err, &httpErr):
+		detail.Code = httpErr.Code
+		detail.ErrorCode = codeOfStatus(httpErr.Code)
+		detail.Message = fmt.Sprint(httpErr.Message)
+	default:
+		c.Logger().Error(err)
+	}
+
+	var writeErr error
+	if c.Request().Method == http.MethodHead {
+		writeErr = c.NoContent(detail.Code)
+	} else {
+		writeErr = c.JSON(detail.Code, response.ErrorResponse{Status: "error", Error: detail})
+	}
+	if writeErr != nil {
+		c.Logger().Error(writeErr)
+	}
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"CurlARC/internal/domain/domainerr"
+	"CurlARC/internal/handler/response"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorHandler(t *testing.T) {
+	e := echo.New()
+
+	tests := []struct {
+		name      string
+		err       error
+		status    int
+		errorCode string
+		message   string
+	}{
+		{
+			name:      "正常系: 読めないリクエストは400",
+			err:       badRequest("invalid request"),
+			status:    http.StatusBadRequest,
+			errorCode: "BAD_REQUEST",
+			message:   "invalid request",
+		},
+		{
+			name:      "正常系: echoのエラーもステータスに応じたコードを持つ",
+			err:       echo.ErrNotFound,
+			status:    http.StatusNotFound,
+			errorCode: "NOT_FOUND",
+			message:   "Not Found",
+		},
+		{
+			name:      "正常系: ドメインのエラーは種類に応じたステータスとそのコード",
+			err:       domainerr.ErrTeamNotFound,
+			status:    http.StatusNotFound,
+			errorCode: "TEAM_NOT_FOUND",
+			message:   "team not found",
+		},
+		{
+			name:      "正常系: 検証エラーは422",
+			err:       domainerr.Invalid("name", "name is required"),
+			status:    http.StatusUnprocessableEntity,
+			errorCode: domainerr.CodeValidationFailed,
+			message:   "name is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+			ErrorHandler(tt.err, c)
+
+			var res response.ErrorResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, "error", res.Status)
+			assert.Equal(t, tt.status, res.Error.Code)
+			assert.Equal(t, tt.errorCode, res.Error.ErrorCode)
+			assert.Equal(t, tt.message, res.Error.Message)
+		})
+	}
+
+	t.Run("正常系: 想定外のエラーの内容はクライアントに返さない", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+		ErrorHandler(errors.New(`pq: relation "records" does not exist`), c)
+
+		var res response.ErrorResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, codeInternal, res.Error.ErrorCode)
+		assert.Equal(t, "internal server error", res.Error.Message)
+		assert.NotContains(t, rec.Body.String(), "records")
+	})
+}
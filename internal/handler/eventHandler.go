@@ -0,0 +1,347 @@
+package handler
+
+import (
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/handler/request"
+	"CurlARC/internal/handler/response"
+	"CurlARC/internal/usecase"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// EventHandler handles requests related to the events a team plays.
+type EventHandler struct {
+	eventUsecase usecase.EventUsecase
+}
+
+// NewEventHandler creates a new EventHandler instance.
+func NewEventHandler(eventUsecase usecase.EventUsecase) EventHandler {
+	return EventHandler{eventUsecase: eventUsecase}
+}
+
+func newEventResponse(event *entity.Event) response.Event {
+	res := response.Event{
+		Id:        event.GetId().Value(),
+		TeamId:    event.GetTeamId(),
+		Name:      event.GetName(),
+		Venue:     event.GetVenue(),
+		Format:    event.GetFormat(),
+		StartDate: event.GetStartDate(),
+		EndDate:   event.GetEndDate(),
+		Games:     make([]response.EventGame, 0, len(event.GetGames())),
+	}
+	for _, game := range event.GetGames() {
+		res.Games = append(res.Games, response.EventGame{
+			RecordId:  game.RecordId,
+			Round:     game.Round,
+			FriendLSD: game.FriendLSD,
+			EnemyLSD:  game.EnemyLSD,
+		})
+	}
+	return res
+}
+
+// CreateEvent godoc
+// @Summary Create an event
+// @Description Create a league or bonspiel of the team. The format is ROUND_ROBIN or KNOCKOUT
+// @Tags Events
+// @Accept json
+// @Produce json
+// @Param teamId path string true "Team ID"
+// @Param request body request.CreateEventRequest true "Event"
+// @Success 201 {object} response.SuccessResponse{data=response.Event}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/events [post]
+func (h *EventHandler) CreateEvent() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		userId := c.Get("uid").(string)
+
+		var req request.CreateEventRequest
+		if err := c.Bind(&req); err != nil {
+			return badRequest("invalid request")
+		}
+
+		event, err := h.eventUsecase.CreateEvent(userId, teamId, req.Name, req.Venue, req.Format, req.StartDate, req.EndDate)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusCreated, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Event response.Event `json:"event"`
+			}{
+				Event: newEventResponse(event),
+			},
+		})
+	}
+}
+
+// GetEvents godoc
+// @Summary Get the events of a team
+// @Description Get every event of the team, the most recent first
+// @Tags Events
+// @Produce json
+// @Param teamId path string true "Team ID"
+// @Success 200 {object} response.SuccessResponse{data=[]response.Event}
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/events [get]
+func (h *EventHandler) GetEvents() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		userId := c.Get("uid").(string)
+
+		events, err := h.eventUsecase.GetEventsByTeamId(userId, teamId)
+		if err != nil {
+			return err
+		}
+
+		res := make([]response.Event, 0, len(events))
+		for _, event := range events {
+			res = append(res, newEventResponse(event))
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Events []response.Event `json:"events"`
+			}{
+				Events: res,
+			},
+		})
+	}
+}
+
+// GetEvent godoc
+// @Summary Get an event
+// @Description Get an event of the team with the records attached to it
+// @Tags Events
+// @Produce json
+// @Param teamId path string true "Team ID"
+// @Param eventId path string true "Event ID"
+// @Success 200 {object} response.SuccessResponse{data=response.Event}
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/events/{eventId} [get]
+func (h *EventHandler) GetEvent() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		eventId := c.Param("eventId")
+		userId := c.Get("uid").(string)
+
+		event, err := h.eventUsecase.GetEvent(userId, teamId, eventId)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Event response.Event `json:"event"`
+			}{
+				Event: newEventResponse(event),
+			},
+		})
+	}
+}
+
+// UpdateEvent godoc
+// @Summary Update an event
+// @Description Update the given fields of an event and keep the others
+// @Tags Events
+// @Accept json
+// @Produce json
+// @Param teamId path string true "Team ID"
+// @Param eventId path string true "Event ID"
+// @Param request body request.UpdateEventRequest true "Fields to update"
+// @Success 200 {object} response.SuccessResponse{data=response.Event}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/events/{eventId} [patch]
+func (h *EventHandler) UpdateEvent() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		eventId := c.Param("eventId")
+		userId := c.Get("uid").(string)
+
+		var req request.UpdateEventRequest
+		if err := c.Bind(&req); err != nil {
+			return badRequest("invalid request")
+		}
+
+		event, err := h.eventUsecase.UpdateEvent(userId, teamId, eventId, req.Name, req.Venue, req.Format, req.StartDate, req.EndDate)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Event response.Event `json:"event"`
+			}{
+				Event: newEventResponse(event),
+			},
+		})
+	}
+}
+
+// DeleteEvent godoc
+// @Summary Delete an event
+// @Description Delete an event. The records attached to it are kept
+// @Tags Events
+// @Param teamId path string true "Team ID"
+// @Param eventId path string true "Event ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/events/{eventId} [delete]
+func (h *EventHandler) DeleteEvent() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		eventId := c.Param("eventId")
+		userId := c.Get("uid").(string)
+
+		if err := h.eventUsecase.DeleteEvent(userId, teamId, eventId); err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data:   nil,
+		})
+	}
+}
+
+// AttachRecord godoc
+// @Summary Attach a record to an event
+// @Description Attach a record of the team to an event, or update its round and last-stone draws. A record can be part of one event only
+// @Tags Events
+// @Accept json
+// @Produce json
+// @Param teamId path string true "Team ID"
+// @Param eventId path string true "Event ID"
+// @Param recordId path string true "Record ID"
+// @Param request body request.AttachRecordRequest true "Round and last-stone draws"
+// @Success 200 {object} response.SuccessResponse{data=response.Event}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/events/{eventId}/records/{recordId} [put]
+func (h *EventHandler) AttachRecord() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		eventId := c.Param("eventId")
+		userId := c.Get("uid").(string)
+
+		var req request.AttachRecordRequest
+		if err := c.Bind(&req); err != nil {
+			return badRequest("invalid request")
+		}
+
+		event, err := h.eventUsecase.AttachRecord(userId, teamId, eventId, entity.EventGame{
+			RecordId:  c.Param("recordId"),
+			Round:     req.Round,
+			FriendLSD: req.FriendLSD,
+			EnemyLSD:  req.EnemyLSD,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Event response.Event `json:"event"`
+			}{
+				Event: newEventResponse(event),
+			},
+		})
+	}
+}
+
+// DetachRecord godoc
+// @Summary Detach a record from an event
+// @Description Remove a record from an event. The record itself is kept
+// @Tags Events
+// @Produce json
+// @Param teamId path string true "Team ID"
+// @Param eventId path string true "Event ID"
+// @Param recordId path string true "Record ID"
+// @Success 200 {object} response.SuccessResponse{data=response.Event}
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/events/{eventId}/records/{recordId} [delete]
+func (h *EventHandler) DetachRecord() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		eventId := c.Param("eventId")
+		userId := c.Get("uid").(string)
+
+		event, err := h.eventUsecase.DetachRecord(userId, teamId, eventId, c.Param("recordId"))
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Event response.Event `json:"event"`
+			}{
+				Event: newEventResponse(event),
+			},
+		})
+	}
+}
+
+// GetStandings godoc
+// @Summary Get the standings of an event
+// @Description Rank our team and its opponents on the games attached to the event: wins, losses, then draw shot challenge and best last-stone draw. Opponents only appear with their games against us
+// @Tags Events
+// @Produce json
+// @Param teamId path string true "Team ID"
+// @Param eventId path string true "Event ID"
+// @Success 200 {object} response.SuccessResponse{data=response.Standings}
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/events/{eventId}/standings [get]
+func (h *EventHandler) GetStandings() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		eventId := c.Param("eventId")
+		userId := c.Get("uid").(string)
+
+		standings, err := h.eventUsecase.GetStandings(userId, teamId, eventId)
+		if err != nil {
+			return err
+		}
+
+		res := response.Standings{
+			Format:    standings.Format,
+			Rows:      make([]response.StandingsRow, 0, len(standings.Rows)),
+			Abandoned: standings.Abandoned,
+		}
+		for _, row := range standings.Rows {
+			res.Rows = append(res.Rows, response.StandingsRow{
+				Rank:              row.Rank,
+				Team:              row.Team,
+				Friend:            row.Friend,
+				Games:             row.Games,
+				Wins:              row.Wins,
+				Losses:            row.Losses,
+				Draws:             row.Draws,
+				PointsFor:         row.PointsFor,
+				PointsAgainst:     row.PointsAgainst,
+				DrawShotChallenge: row.DrawShotChallenge,
+				BestLSD:           row.BestLSD,
+				Eliminated:        row.Eliminated,
+			})
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Standings response.Standings `json:"standings"`
+			}{
+				Standings: res,
+			},
+		})
+	}
+}
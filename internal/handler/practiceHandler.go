@@ -0,0 +1,351 @@
+package handler
+
+import (
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/handler/request"
+	"CurlARC/internal/handler/response"
+	"CurlARC/internal/usecase"
+	"net/http"
+	"sort"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PracticeHandler handles requests related to the practice sessions of a team.
+type PracticeHandler struct {
+	practiceUsecase usecase.PracticeUsecase
+}
+
+// NewPracticeHandler creates a new PracticeHandler instance.
+func NewPracticeHandler(practiceUsecase usecase.PracticeUsecase) PracticeHandler {
+	return PracticeHandler{practiceUsecase: practiceUsecase}
+}
+
+func newPracticeSessionResponse(session *entity.PracticeSession) response.PracticeSession {
+	res := response.PracticeSession{
+		Id:        session.GetId().Value(),
+		TeamId:    session.GetTeamId(),
+		Date:      session.GetDate(),
+		Place:     session.GetPlace(),
+		Attendees: session.GetAttendees(),
+		Drills:    session.GetDrills(),
+	}
+	if res.Attendees == nil {
+		res.Attendees = []entity.LineupPlayer{}
+	}
+	if res.Drills == nil {
+		res.Drills = []entity.Drill{}
+	}
+	return res
+}
+
+func toPlayerPracticeStatsResponse(s usecase.PlayerPracticeStats) response.PlayerPracticeStats {
+	player := response.PlayerPracticeStats{
+		Shooter:  s.Shooter,
+		Attended: s.Attended,
+		Overall:  toSuccessRateResponse(s.Overall),
+		ByDrill:  make([]response.DrillStats, 0, len(s.ByDrill)),
+	}
+	if s.Player != nil {
+		player.UserId = s.Player.UserId
+		player.GuestName = s.Player.GuestName
+	}
+	for drillType, d := range s.ByDrill {
+		drill := response.DrillStats{
+			Type:        drillType,
+			SuccessRate: toSuccessRateResponse(d.SuccessRate),
+			Measured:    d.Measured,
+			OutOfPlay:   d.OutOfPlay,
+		}
+		if d.Measured > 0 {
+			distance := d.MeanDistance
+			drill.MeanDistance = &distance
+		}
+		player.ByDrill = append(player.ByDrill, drill)
+	}
+	sort.Slice(player.ByDrill, func(i, j int) bool { return player.ByDrill[i].Type < player.ByDrill[j].Type })
+	return player
+}
+
+// CreatePracticeSession godoc
+// @Summary Create a practice session
+// @Description Record a practice session with its attendees and drills. Practice is kept apart from the team's games
+// @Tags Practice
+// @Accept json
+// @Produce json
+// @Param teamId path string true "Team ID"
+// @Param request body request.CreatePracticeSessionRequest true "Practice session"
+// @Success 201 {object} response.SuccessResponse{data=response.PracticeSession}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/practices [post]
+func (h *PracticeHandler) CreatePracticeSession() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		userId := c.Get("uid").(string)
+
+		var req request.CreatePracticeSessionRequest
+		if err := c.Bind(&req); err != nil {
+			return badRequest("invalid request")
+		}
+
+		session, err := h.practiceUsecase.CreatePracticeSession(userId, teamId, req.Date, req.Place, req.Attendees, req.Drills)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusCreated, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Practice response.PracticeSession `json:"practice"`
+			}{
+				Practice: newPracticeSessionResponse(session),
+			},
+		})
+	}
+}
+
+// GetPracticeSessions godoc
+// @Summary Get the practice sessions of a team
+// @Description Get every practice session of the team, the most recent first
+// @Tags Practice
+// @Produce json
+// @Param teamId path string true "Team ID"
+// @Success 200 {object} response.SuccessResponse{data=[]response.PracticeSession}
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/practices [get]
+func (h *PracticeHandler) GetPracticeSessions() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		userId := c.Get("uid").(string)
+
+		sessions, err := h.practiceUsecase.GetPracticeSessionsByTeamId(userId, teamId)
+		if err != nil {
+			return err
+		}
+
+		res := make([]response.PracticeSession, 0, len(sessions))
+		for _, session := range sessions {
+			res = append(res, newPracticeSessionResponse(session))
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Practices []response.PracticeSession `json:"practices"`
+			}{
+				Practices: res,
+			},
+		})
+	}
+}
+
+// GetPracticeSession godoc
+// @Summary Get a practice session
+// @Tags Practice
+// @Produce json
+// @Param teamId path string true "Team ID"
+// @Param practiceId path string true "Practice session ID"
+// @Success 200 {object} response.SuccessResponse{data=response.PracticeSession}
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/practices/{practiceId} [get]
+func (h *PracticeHandler) GetPracticeSession() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		practiceId := c.Param("practiceId")
+		userId := c.Get("uid").(string)
+
+		session, err := h.practiceUsecase.GetPracticeSession(userId, teamId, practiceId)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Practice response.PracticeSession `json:"practice"`
+			}{
+				Practice: newPracticeSessionResponse(session),
+			},
+		})
+	}
+}
+
+// UpdatePracticeSession godoc
+// @Summary Update a practice session
+// @Description Update the given fields of a practice session and keep the others. Attendees and drills are replaced as a whole
+// @Tags Practice
+// @Accept json
+// @Produce json
+// @Param teamId path string true "Team ID"
+// @Param practiceId path string true "Practice session ID"
+// @Param request body request.UpdatePracticeSessionRequest true "Fields to update"
+// @Success 200 {object} response.SuccessResponse{data=response.PracticeSession}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/practices/{practiceId} [patch]
+func (h *PracticeHandler) UpdatePracticeSession() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		practiceId := c.Param("practiceId")
+		userId := c.Get("uid").(string)
+
+		var req request.UpdatePracticeSessionRequest
+		if err := c.Bind(&req); err != nil {
+			return badRequest("invalid request")
+		}
+
+		session, err := h.practiceUsecase.UpdatePracticeSession(userId, teamId, practiceId, req.Date, req.Place, req.Attendees, req.Drills)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Practice response.PracticeSession `json:"practice"`
+			}{
+				Practice: newPracticeSessionResponse(session),
+			},
+		})
+	}
+}
+
+// DeletePracticeSession godoc
+// @Summary Delete a practice session
+// @Tags Practice
+// @Param teamId path string true "Team ID"
+// @Param practiceId path string true "Practice session ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/practices/{practiceId} [delete]
+func (h *PracticeHandler) DeletePracticeSession() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		practiceId := c.Param("practiceId")
+		userId := c.Get("uid").(string)
+
+		if err := h.practiceUsecase.DeletePracticeSession(userId, teamId, practiceId); err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data:   nil,
+		})
+	}
+}
+
+// GetPracticeStats godoc
+// @Summary Get practice statistics per player
+// @Description Get each player's attendance and results per drill over the team's practice sessions. Games are not included
+// @Tags Practice
+// @Produce json
+// @Param teamId path string true "Team ID"
+// @Param from query string false "Start date (YYYY-MM-DD or RFC3339)"
+// @Param to query string false "End date (YYYY-MM-DD or RFC3339)"
+// @Success 200 {object} response.SuccessResponse{data=response.PracticeStats}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/stats/practice [get]
+func (h *PracticeHandler) GetPracticeStats() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		userId := c.Get("uid").(string)
+
+		var req request.StatsFilterRequest
+		if err := c.Bind(&req); err != nil {
+			return badRequest("invalid request")
+		}
+		filter, err := parseStatsFilter(req)
+		if err != nil {
+			return badRequest("invalid date: " + err.Error())
+		}
+
+		stats, err := h.practiceUsecase.GetPracticeStats(userId, teamId, filter)
+		if err != nil {
+			return err
+		}
+
+		res := response.PracticeStats{
+			Sessions: stats.Sessions,
+			Players:  make([]response.PlayerPracticeStats, 0, len(stats.Players)),
+		}
+		for _, player := range stats.Players {
+			res.Players = append(res.Players, toPlayerPracticeStatsResponse(player))
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Practice response.PracticeStats `json:"practice"`
+			}{
+				Practice: res,
+			},
+		})
+	}
+}
+
+// GetPlayerReports godoc
+// @Summary Get per-player reports combining games and practice
+// @Description Get each player's game and practice results side by side with their combined success rate. The opponent filter only applies to games
+// @Tags Practice
+// @Produce json
+// @Param teamId path string true "Team ID"
+// @Param from query string false "Start date (YYYY-MM-DD or RFC3339)"
+// @Param to query string false "End date (YYYY-MM-DD or RFC3339)"
+// @Param opponent query string false "Opponent team name"
+// @Success 200 {object} response.SuccessResponse{data=[]response.PlayerReport}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/stats/players [get]
+func (h *PracticeHandler) GetPlayerReports() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		userId := c.Get("uid").(string)
+
+		var req request.StatsFilterRequest
+		if err := c.Bind(&req); err != nil {
+			return badRequest("invalid request")
+		}
+		filter, err := parseStatsFilter(req)
+		if err != nil {
+			return badRequest("invalid date: " + err.Error())
+		}
+
+		reports, err := h.practiceUsecase.GetPlayerReports(userId, teamId, filter)
+		if err != nil {
+			return err
+		}
+
+		res := make([]response.PlayerReport, 0, len(reports))
+		for _, report := range reports {
+			r := response.PlayerReport{
+				Shooter:  report.Shooter,
+				Combined: toSuccessRateResponse(report.Combined),
+			}
+			if report.Player != nil {
+				r.UserId = report.Player.UserId
+				r.GuestName = report.Player.GuestName
+			}
+			if report.Match != nil {
+				match := toShooterStatsResponse(*report.Match)
+				r.Match = &match
+			}
+			if report.Practice != nil {
+				practice := toPlayerPracticeStatsResponse(*report.Practice)
+				r.Practice = &practice
+			}
+			res = append(res, r)
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Players []response.PlayerReport `json:"players"`
+			}{
+				Players: res,
+			},
+		})
+	}
+}
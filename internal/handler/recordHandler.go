@@ -2,10 +2,16 @@ package handler
 
 import (
 	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/geometry"
+	"CurlARC/internal/domain/repository"
+	"CurlARC/internal/handler/render"
 	"CurlARC/internal/handler/request"
 	"CurlARC/internal/handler/response"
 	"CurlARC/internal/usecase"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 )
@@ -18,6 +24,64 @@ func NewRecordHandler(recordHandler usecase.RecordUsecase) RecordHandler {
 	return RecordHandler{recordUsecase: recordHandler}
 }
 
+// newRecordResponse converts a record into its response representation.
+func newRecordResponse(record *entity.Record, coords coordinateSystem) (response.Record, error) {
+	endsData, err := coords.endsData(record)
+	if err != nil {
+		return response.Record{}, err
+	}
+
+	return response.Record{
+		Id:            record.GetId().Value(),
+		TeamId:        record.GetTeamId(),
+		Result:        record.GetResult(),
+		EnemyTeamName: record.GetEnemyTeamName(),
+		Place:         record.GetPlace(),
+		Date:          record.GetDate(),
+		EndsData:      endsData,
+		IsRed:         record.GetIsRed(),
+		IsFirst:       record.GetIsFirst(),
+		IsPublic:      record.IsPublic(),
+		Lineup:        record.GetLineup(),
+		GameFormat:    record.GetGameFormat(),
+		Status:        record.GetStatus(),
+		Coordinates:   coords.response(),
+	}, nil
+}
+
+// newMovementsResponse compares the consecutive stone snapshots of every end of a record.
+func newMovementsResponse(record *entity.Record, coords coordinateSystem) []response.EndMovements {
+	ends := make([]response.EndMovements, 0, len(record.GetEndsData()))
+	for i, end := range record.GetEndsData() {
+		diffs := geometry.DiffEnd(end)
+		shots := make([]response.ShotMovements, 0, len(diffs))
+		for _, diff := range diffs {
+			stones := make([]response.StoneMovement, 0, len(diff.Movements))
+			for _, m := range diff.Movements {
+				stones = append(stones, response.StoneMovement{
+					Index:    m.Index,
+					Side:     string(m.Side),
+					Movement: string(m.Movement),
+					From:     coords.position(m.From),
+					To:       coords.position(m.To),
+					DX:       m.DX,
+					DY:       m.DY,
+					Distance: m.Distance,
+				})
+			}
+			shots = append(shots, response.ShotMovements{
+				Shot:    diff.Shot,
+				Placed:  diff.Placed,
+				Moved:   diff.Moved,
+				Removed: diff.Removed,
+				Stones:  stones,
+			})
+		}
+		ends = append(ends, response.EndMovements{End: i + 1, Shots: shots})
+	}
+	return ends
+}
+
 // CreateRecord godoc
 // @Summary Create a new record
 // @Description Create a new record for a team by a user
@@ -27,7 +91,8 @@ func NewRecordHandler(recordHandler usecase.RecordUsecase) RecordHandler {
 // @Param teamId path string true "Team ID"
 // @Param userId path string true "User ID"
 // @Param record body request.CreateRecordRequest true "Record Data"
-// @Success 201 {object} entity.Record
+// @Param coords query string false "Stone coordinates: polar (default) or cartesian. Cartesian positions are in metres from where the centre line meets the back line, and the sheet can be resized with house_radius, stone_radius, tee_to_hog_line, tee_to_back_line and sheet_width"
+// @Success 201 {object} response.SuccessResponse{data=response.Record}
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /auth/record/{teamId}/{userId} [post]
@@ -36,16 +101,15 @@ func (h *RecordHandler) CreateRecord() echo.HandlerFunc {
 		teamId := c.Param("teamId")
 		userId := c.Get("uid").(string)
 
+		coords, err := parseCoordinateSystem(c)
+		if err != nil {
+			return badRequest(err.Error())
+		}
+
 		// validate request
 		var req request.CreateRecordRequest
 		if err := c.Bind(&req); err != nil {
-			return c.JSON(http.StatusBadRequest, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusBadRequest,
-					Message: "invalid request",
-				},
-			})
+			return badRequest("invalid request")
 		}
 
 		// call usecase
@@ -56,29 +120,16 @@ func (h *RecordHandler) CreateRecord() echo.HandlerFunc {
 			req.Place,
 			req.Result,
 			req.Date,
+			req.GameFormat,
 		)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
+			return err
 		}
 
 		// return response
-		res := response.Record{
-			Id:            createdRecord.GetId().Value(),
-			TeamId:        createdRecord.GetTeamId(),
-			Result:        createdRecord.GetResult(),
-			EnemyTeamName: createdRecord.GetEnemyTeamName(),
-			Place:         createdRecord.GetPlace(),
-			Date:          createdRecord.GetDate(),
-			EndsData:      createdRecord.GetEndsDataAsJSON(),
-			IsRed:         createdRecord.GetIsRed(),
-			IsFirst:       createdRecord.GetIsFirst(),
-			IsPublic:      createdRecord.IsPublic(),
+		res, err := newRecordResponse(createdRecord, coords)
+		if err != nil {
+			return err
 		}
 
 		return c.JSON(http.StatusCreated, response.SuccessResponse{
@@ -100,9 +151,11 @@ func (h *RecordHandler) CreateRecord() echo.HandlerFunc {
 // @Produce  json
 // @Param recordId path string true "Record ID"
 // @Param userId path string true "User ID"
-// @Param endsData body request.AppendEndDataRequest true "End Data"
-// @Success 201 {object} entity.Record
+// @Param endsData body request.AppendEndDataRequest true "End Data. free_guard_zone tells whether shots breaking the free guard zone rule are flagged, rejected or restored"
+// @Param coords query string false "Stone coordinates of ends_data and the response: polar (default) or cartesian. Cartesian positions are in metres from where the centre line meets the back line, and the sheet can be resized with house_radius, stone_radius, tee_to_hog_line, tee_to_back_line and sheet_width"
+// @Success 201 {object} response.SuccessResponse{data=response.Record}
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /auth/record/{recordId}/{userId}/end [post]
 func (h *RecordHandler) AppendEndData() echo.HandlerFunc {
@@ -110,16 +163,24 @@ func (h *RecordHandler) AppendEndData() echo.HandlerFunc {
 		recordId := c.Param("recordId")
 		userId := c.Get("uid").(string)
 
+		coords, err := parseCoordinateSystem(c)
+		if err != nil {
+			return badRequest(err.Error())
+		}
+
 		// validate request
 		var req request.AppendEndDataRequest
-		if err := c.Bind(&req); err != nil {
-			return c.JSON(http.StatusBadRequest, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusBadRequest,
-					Message: "invalid request",
-				},
-			})
+		cartesian, err := coords.bind(c, &req)
+		if err != nil {
+			return err
+		}
+		if err := coords.fromCartesian(req.EndsData, cartesian); err != nil {
+			return err
+		}
+
+		fgzPolicy, err := entity.ParseFreeGuardZonePolicy(req.FreeGuardZone)
+		if err != nil {
+			return badRequest(err.Error())
 		}
 
 		// call usecase
@@ -127,56 +188,59 @@ func (h *RecordHandler) AppendEndData() echo.HandlerFunc {
 			recordId,
 			userId,
 			req.EndsData,
+			fgzPolicy,
 		)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
+			return err
 		}
 
 		// return response
+		res, err := newRecordResponse(updatedRecord, coords)
+		if err != nil {
+			return err
+		}
+
 		return c.JSON(http.StatusCreated, response.SuccessResponse{
 			Status: "success",
 			Data: struct {
-				Record entity.Record `json:"record"`
+				Record response.Record `json:"record"`
 			}{
-				Record: *updatedRecord,
+				Record: res,
 			},
 		})
 	}
 }
 
+// GetRecordDetailsByRecordId godoc
+// @Summary Get the details of a record
+// @Description Get a record with its ends data and, for every shot, how each stone moved compared with the position before the shot
+// @Tags records
+// @Produce  json
+// @Param recordId path string true "Record ID"
+// @Param coords query string false "Stone coordinates: polar (default) or cartesian. Cartesian positions are in metres from where the centre line meets the back line, and the sheet can be resized with house_radius, stone_radius, tee_to_hog_line, tee_to_back_line and sheet_width"
+// @Success 200 {object} response.SuccessResponse{data=response.Record}
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/records/{recordId}/details [get]
 func (h *RecordHandler) GetRecordDetailsByRecordId() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		recordId := c.Param("recordId")
+		userId := c.Get("uid").(string)
 
-		record, err := h.recordUsecase.GetRecordDetailsByRecordId(recordId)
+		coords, err := parseCoordinateSystem(c)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
+			return badRequest(err.Error())
 		}
 
-		res := response.Record{
-			Id:            record.GetId().Value(),
-			TeamId:        record.GetTeamId(),
-			Result:        record.GetResult(),
-			EnemyTeamName: record.GetEnemyTeamName(),
-			Place:         record.GetPlace(),
-			Date:          record.GetDate(),
-			EndsData:      record.GetEndsDataAsJSON(),
-			IsRed:         record.GetIsRed(),
-			IsFirst:       record.GetIsFirst(),
-			IsPublic:      record.IsPublic(),
+		record, err := h.recordUsecase.GetRecordDetailsByRecordId(recordId, userId)
+		if err != nil {
+			return err
+		}
+
+		res, err := newRecordResponse(record, coords)
+		if err != nil {
+			return err
 		}
+		res.Movements = newMovementsResponse(record, coords)
 
 		return c.JSON(http.StatusOK, response.SuccessResponse{
 			Status: "success",
@@ -189,37 +253,240 @@ func (h *RecordHandler) GetRecordDetailsByRecordId() echo.HandlerFunc {
 	}
 }
 
+// GetShotImage godoc
+// @Summary Render the position after a shot
+// @Description Render the house and the stones in play after a shot as SVG. Stones are numbered by index and coloured by is_red. Shot 0 is the position before the first delivery
+// @Tags records
+// @Produce  image/svg+xml
+// @Param recordId path string true "Record ID"
+// @Param end path int true "End number, from 1"
+// @Param shot path int true "Shot number, from 1"
+// @Success 200 {string} string "SVG image"
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/records/{recordId}/ends/{end}/shots/{shot}/house.svg [get]
+func (h *RecordHandler) GetShotImage() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		recordId := c.Param("recordId")
+		userId := c.Get("uid").(string)
+		end, endErr := strconv.Atoi(c.Param("end"))
+		shot, shotErr := strconv.Atoi(c.Param("shot"))
+		if endErr != nil || shotErr != nil {
+			return badRequest("end and shot must be numbers")
+		}
+
+		record, err := h.recordUsecase.GetRecordDetailsByRecordId(recordId, userId)
+		if err != nil {
+			return err
+		}
+
+		stones, err := record.StonesAt(end, shot)
+		if err != nil {
+			return err
+		}
+
+		return c.Blob(http.StatusOK, "image/svg+xml", render.House(stones, record.GetIsRed()))
+	}
+}
+
+// GetEndImage godoc
+// @Summary Render a whole end
+// @Description Render every position of an end as a strip of small SVG diagrams, one per shot
+// @Tags records
+// @Produce  image/svg+xml
+// @Param recordId path string true "Record ID"
+// @Param end path int true "End number, from 1"
+// @Success 200 {string} string "SVG image"
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/records/{recordId}/ends/{end}/strip.svg [get]
+func (h *RecordHandler) GetEndImage() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		recordId := c.Param("recordId")
+		userId := c.Get("uid").(string)
+		end, err := strconv.Atoi(c.Param("end"))
+		if err != nil {
+			return badRequest("end must be a number")
+		}
+
+		record, err := h.recordUsecase.GetRecordDetailsByRecordId(recordId, userId)
+		if err != nil {
+			return err
+		}
+
+		data, err := record.End(end)
+		if err != nil {
+			return err
+		}
+
+		return c.Blob(http.StatusOK, "image/svg+xml", render.EndStrip(end, data, record.GetIsRed()))
+	}
+}
+
+// GetScoreboard godoc
+// @Summary Get the scoreboard of a record
+// @Description Get the end-by-end linescore of a record with running totals, blank ends, steals and hammer
+// @Tags records
+// @Produce  json
+// @Param recordId path string true "Record ID"
+// @Success 200 {object} response.SuccessResponse{data=response.Scoreboard}
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/records/{recordId}/scoreboard [get]
+func (h *RecordHandler) GetScoreboard() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		recordId := c.Param("recordId")
+		userId := c.Get("uid").(string)
+
+		scoreboard, err := h.recordUsecase.GetScoreboard(recordId, userId)
+		if err != nil {
+			return err
+		}
+
+		ends := make([]response.ScoreboardEnd, 0, len(scoreboard.Ends))
+		for _, end := range scoreboard.Ends {
+			ends = append(ends, response.ScoreboardEnd{
+				End:          end.End,
+				FriendPoints: end.FriendPoints,
+				EnemyPoints:  end.EnemyPoints,
+				FriendTotal:  end.FriendTotal,
+				EnemyTotal:   end.EnemyTotal,
+				FriendHammer: end.FriendHammer,
+				PowerPlay:    end.PowerPlay,
+				Extra:        end.Extra,
+				Blank:        end.Blank,
+				Steal:        end.Steal,
+			})
+		}
+
+		res := response.Scoreboard{
+			RecordId:       recordId,
+			Status:         scoreboard.Status,
+			RegulationEnds: scoreboard.RegulationEnds,
+			EndsPlayed:     scoreboard.EndsPlayed,
+			ExtraEnds:      scoreboard.ExtraEnds,
+			ConcededAfter:  scoreboard.ConcededAfter,
+			Ends:           ends,
+			FriendTotal:    scoreboard.FriendTotal,
+			EnemyTotal:     scoreboard.EnemyTotal,
+			BlankEnds:      scoreboard.BlankEnds,
+			FriendSteals:   scoreboard.FriendSteals,
+			EnemySteals:    scoreboard.EnemySteals,
+			Result:         scoreboard.Result,
+			ComputedResult: scoreboard.ComputedResult,
+			ResultMatches:  scoreboard.ResultMatches,
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Scoreboard response.Scoreboard `json:"scoreboard"`
+			}{
+				Scoreboard: res,
+			},
+		})
+	}
+}
+
+// parseRecordQuery reads the filters, the sort and the page of the record listing.
+func parseRecordQuery(req request.RecordListRequest) (repository.RecordQuery, error) {
+	filter, err := parseStatsFilter(req.StatsFilterRequest)
+	if err != nil {
+		return repository.RecordQuery{}, fmt.Errorf("invalid date: %w", err)
+	}
+	query := repository.RecordQuery{
+		From:     filter.From,
+		To:       filter.To,
+		Result:   entity.Result(strings.ToUpper(req.Result)),
+		Opponent: req.Opponent,
+		Place:    req.Place,
+		Sort:     repository.RecordSort(req.Sort),
+		Limit:    req.Limit,
+	}
+
+	for _, param := range []struct {
+		name  string
+		raw   string
+		value **bool
+	}{
+		{"is_public", req.IsPublic, &query.IsPublic},
+		{"has_ends_data", req.HasEndsData, &query.HasEndsData},
+	} {
+		if param.raw == "" {
+			continue
+		}
+		value, err := strconv.ParseBool(param.raw)
+		if err != nil {
+			return query, fmt.Errorf("%s must be true or false", param.name)
+		}
+		*param.value = &value
+	}
+
+	if req.Cursor != "" {
+		cursor, err := repository.DecodeRecordCursor(req.Cursor)
+		if err != nil {
+			return query, err
+		}
+		query.Cursor = cursor
+	}
+	return query, nil
+}
+
 // GetRecordByTeamId godoc
 // @Summary Get records by team ID
-// @Description Get all records for a specific team
+// @Description Get one page of a team's records, the most recent first by default. Pass next_cursor as cursor to get the next page; it is null on the last page
 // @Tags records
 // @Produce  json
 // @Param teamId path string true "Team ID"
-// @Success 200 {object} []entity.Record
+// @Param from query string false "Start date (YYYY-MM-DD or RFC3339)"
+// @Param to query string false "End date (YYYY-MM-DD or RFC3339)"
+// @Param result query string false "WIN, LOSE or DRAW"
+// @Param opponent query string false "Opponent team name, ignoring case and whitespace"
+// @Param place query string false "Part of the place"
+// @Param is_public query bool false "Only public or only private records"
+// @Param has_ends_data query bool false "Only records with or without ends data"
+// @Param sort query string false "date, -date (default), opponent, -opponent, place or -place"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Success 200 {object} response.GetRecordIndicesByTeamIdResponse
+// @Failure 400 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
-// @Router /auth/record/{teamId} [get]
+// @Router /auth/records/{teamId} [get]
 func (h *RecordHandler) GetRecordsByTeamId() echo.HandlerFunc {
 	return func(c echo.Context) error {
+		userId := c.Get("uid").(string)
 		teamId := c.Param("teamId")
 
+		var req request.RecordListRequest
+		if err := c.Bind(&req); err != nil {
+			return badRequest("invalid request")
+		}
+		query, err := parseRecordQuery(req)
+		if err != nil {
+			return badRequest(err.Error())
+		}
+
 		// ユースケースにリクエストを渡す
-		RecordIndices, err := h.recordUsecase.GetRecordIndicesByTeamId(teamId)
-		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
+		page, err := h.recordUsecase.GetRecordIndicesByTeamId(userId, teamId, query)
+		if err != nil {
+			return err
+		}
+
+		var nextCursor *string
+		if page.NextCursor != nil {
+			token := page.NextCursor.Encode()
+			nextCursor = &token
 		}
 
 		return c.JSON(http.StatusOK, response.GetRecordIndicesByTeamIdResponse{
 			Status: "success",
 			Data: struct {
 				RecordIndices []response.RecordIndex `json:"record_indices"`
+				NextCursor    *string                `json:"next_cursor"`
 			}{
-				RecordIndices: *RecordIndices,
+				RecordIndices: page.Indices,
+				NextCursor:    nextCursor,
 			},
 		})
 	}
@@ -234,8 +501,10 @@ func (h *RecordHandler) GetRecordsByTeamId() echo.HandlerFunc {
 // @Param recordId path string true "Record ID"
 // @Param userId path string true "User ID"
 // @Param record body request.UpdateRecordRequest true "Updated Record Data"
+// @Param coords query string false "Stone coordinates of ends_data and the response: polar (default) or cartesian. Cartesian positions are in metres from where the centre line meets the back line, and the sheet can be resized with house_radius, stone_radius, tee_to_hog_line, tee_to_back_line and sheet_width"
 // @Success 200 {object} response.SuccessResponse
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /auth/record/{recordId}/{userId} [patch]
 func (h *RecordHandler) UpdateRecord() echo.HandlerFunc {
@@ -243,23 +512,37 @@ func (h *RecordHandler) UpdateRecord() echo.HandlerFunc {
 		recordId := c.Param("recordId")
 		userId := c.Get("uid").(string)
 
+		coords, err := parseCoordinateSystem(c)
+		if err != nil {
+			return badRequest(err.Error())
+		}
+
 		// validate request
 		var req request.UpdateRecordRequest
-		if err := c.Bind(&req); err != nil {
-			return c.JSON(http.StatusBadRequest, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusBadRequest,
-					Message: "invalid request",
-				},
-			})
+		cartesian, err := coords.bind(c, &req)
+		if err != nil {
+			return err
+		}
+		if err := coords.fromCartesian(*req.EndsData, cartesian); err != nil {
+			return err
+		}
+
+		fgzPolicy, err := entity.ParseFreeGuardZonePolicy(req.FreeGuardZone)
+		if err != nil {
+			return badRequest(err.Error())
+		}
+
+		// the status is optional and kept as it is when omitted
+		var status entity.GameStatus
+		if req.Status != nil {
+			status = *req.Status
 		}
 
 		// call usecase
 		updatedRecord, err := h.recordUsecase.UpdateRecord(
 			recordId,
 			userId,
-      *req.Result,
+			*req.Result,
 			*req.EnemyTeamName,
 			*req.Place,
 			*req.EndsData,
@@ -267,24 +550,25 @@ func (h *RecordHandler) UpdateRecord() echo.HandlerFunc {
 			*req.IsRed,
 			*req.IsFirst,
 			*req.IsPublic,
+			status,
+			fgzPolicy,
 		)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
+			return err
 		}
 
 		// return response
+		res, err := newRecordResponse(updatedRecord, coords)
+		if err != nil {
+			return err
+		}
+
 		return c.JSON(http.StatusOK, response.SuccessResponse{
 			Status: "success",
 			Data: struct {
-				Record entity.Record `json:"record"`
+				Record response.Record `json:"record"`
 			}{
-				Record: *updatedRecord,
+				Record: res,
 			},
 		})
 	}
@@ -302,17 +586,12 @@ func (h *RecordHandler) UpdateRecord() echo.HandlerFunc {
 func (h *RecordHandler) DeleteRecord() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		recordId := c.Param("recordId")
+		userId := c.Get("uid").(string)
 
 		// ユースケースにリクエストを渡す
-		err := h.recordUsecase.DeleteRecord(recordId)
-		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
+		err := h.recordUsecase.DeleteRecord(recordId, userId)
+		if err != nil {
+			return err
 		}
 
 		// 成功時のレスポンス形式も統一
@@ -325,49 +604,165 @@ func (h *RecordHandler) DeleteRecord() echo.HandlerFunc {
 
 // SetVisibility godoc
 // @Summary Set record visibility
-// @Description Set the visibility of a record by its ID and user ID
+// @Description Set the visibility of a record by its ID
 // @Tags records
 // @Accept  json
 // @Produce  json
 // @Param recordId path string true "Record ID"
-// @Param userId path string true "User ID"
 // @Param visibility body request.SetVisibilityRequest true "Visibility Data"
-// @Success 200 {object} entity.Record
+// @Param coords query string false "Stone coordinates: polar (default) or cartesian. Cartesian positions are in metres from where the centre line meets the back line, and the sheet can be resized with house_radius, stone_radius, tee_to_hog_line, tee_to_back_line and sheet_width"
+// @Success 200 {object} response.SuccessResponse{data=response.Record}
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
-// @Router /auth/record/{recordId}/{userId}/visibility [patch]
+// @Router /auth/records/{recordId}/visibility [patch]
+// @Router /auth/records/{recordId}/userId/visibility [patch]
 func (h *RecordHandler) SetVisibility() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		recordId := c.Param("recordId")
-		userId := c.Param("userId")
+		userId := c.Get("uid").(string)
+
+		coords, err := parseCoordinateSystem(c)
+		if err != nil {
+			return badRequest(err.Error())
+		}
 
 		var req request.SetVisibilityRequest
 		if err := c.Bind(&req); err != nil {
-			return c.JSON(http.StatusBadRequest, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusBadRequest,
-					Message: "invalid request",
-				},
-			})
+			return badRequest("invalid request")
 		}
 
 		// ユースケースにリクエストを渡す
 		record, err := h.recordUsecase.SetVisibility(recordId, userId, req.IsPublic)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
+			return err
 		}
 
 		// 成功時のレスポンス形式も統一
+		res, err := newRecordResponse(record, coords)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Record response.Record `json:"record"`
+			}{
+				Record: res,
+			},
+		})
+	}
+}
+
+// SetLineup godoc
+// @Summary Set the lineup of a record
+// @Description Set the players of a record by position, with optional substitutions. Every recorded shooter must resolve against the lineup
+// @Tags records
+// @Accept  json
+// @Produce  json
+// @Param recordId path string true "Record ID"
+// @Param lineup body request.SetLineupRequest true "Lineup"
+// @Param coords query string false "Stone coordinates: polar (default) or cartesian. Cartesian positions are in metres from where the centre line meets the back line, and the sheet can be resized with house_radius, stone_radius, tee_to_hog_line, tee_to_back_line and sheet_width"
+// @Success 200 {object} response.SuccessResponse{data=response.Record}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/records/{recordId}/lineup [put]
+func (h *RecordHandler) SetLineup() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		recordId := c.Param("recordId")
+		userId := c.Get("uid").(string)
+
+		coords, err := parseCoordinateSystem(c)
+		if err != nil {
+			return badRequest(err.Error())
+		}
+
+		var req request.SetLineupRequest
+		if err := c.Bind(&req); err != nil {
+			return badRequest("invalid request")
+		}
+
+		lineup := entity.Lineup{
+			Entries:       req.Entries,
+			Substitutions: req.Substitutions,
+		}
+		record, err := h.recordUsecase.SetLineup(recordId, userId, lineup)
+		if err != nil {
+			return err
+		}
+
+		res, err := newRecordResponse(record, coords)
+		if err != nil {
+			return err
+		}
+
 		return c.JSON(http.StatusOK, response.SuccessResponse{
 			Status: "success",
-			Data:   record,
+			Data: struct {
+				Record response.Record `json:"record"`
+			}{
+				Record: res,
+			},
+		})
+	}
+}
+
+// AddSubstitution godoc
+// @Summary Record a substitution
+// @Description Record a player coming in at a position from the given end and shot onwards
+// @Tags records
+// @Accept  json
+// @Produce  json
+// @Param recordId path string true "Record ID"
+// @Param substitution body request.AddSubstitutionRequest true "Substitution"
+// @Param coords query string false "Stone coordinates: polar (default) or cartesian. Cartesian positions are in metres from where the centre line meets the back line, and the sheet can be resized with house_radius, stone_radius, tee_to_hog_line, tee_to_back_line and sheet_width"
+// @Success 201 {object} response.SuccessResponse{data=response.Record}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/records/{recordId}/lineup/substitutions [post]
+func (h *RecordHandler) AddSubstitution() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		recordId := c.Param("recordId")
+		userId := c.Get("uid").(string)
+
+		coords, err := parseCoordinateSystem(c)
+		if err != nil {
+			return badRequest(err.Error())
+		}
+
+		var req request.AddSubstitutionRequest
+		if err := c.Bind(&req); err != nil {
+			return badRequest("invalid request")
+		}
+
+		substitution := entity.Substitution{
+			End:      req.End,
+			Shot:     req.Shot,
+			Position: req.Position,
+			LineupPlayer: entity.LineupPlayer{
+				UserId:    req.UserId,
+				GuestName: req.GuestName,
+			},
+		}
+		record, err := h.recordUsecase.AddSubstitution(recordId, userId, substitution)
+		if err != nil {
+			return err
+		}
+
+		res, err := newRecordResponse(record, coords)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusCreated, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Record response.Record `json:"record"`
+			}{
+				Record: res,
+			},
 		})
 	}
 }
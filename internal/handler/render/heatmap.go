@@ -0,0 +1,92 @@
+package render
+
+import (
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/geometry"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// HeatmapCell is one cell of a heatmap to draw: how many stones rested in it and their average success rate.
+type HeatmapCell struct {
+	Cell    geometry.Cell
+	Count   int
+	Average float64
+}
+
+// Heatmap renders the house with the cells of a grid overlaid. The more stones a cell holds the more opaque it is,
+// and its colour goes from red to green as the average success rate rises.
+// Cells are drawn on the default sheet whatever the sheet of the grid, so the diagram matches the other drawings.
+func Heatmap(grid geometry.Grid, cells []HeatmapCell) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.1f %.1f">`,
+		panelWidth*pixelsPerMetre/unitsPerMetre, panelHeight*pixelsPerMetre/unitsPerMetre, panelWidth, panelHeight)
+	writeSheet(&b, entity.Stones{}, false)
+
+	maxCount := 0
+	for _, cell := range cells {
+		maxCount = max(maxCount, cell.Count)
+	}
+	for _, cell := range cells {
+		if cell.Count == 0 {
+			continue
+		}
+		opacity := 0.2 + 0.6*float64(cell.Count)/float64(maxCount)
+		if grid.Kind == geometry.GridPolar {
+			writePolarCell(&b, grid, cell, opacity)
+		} else {
+			writeCartesianCell(&b, grid, cell, opacity)
+		}
+	}
+
+	b.WriteString(`</svg>`)
+	return []byte(b.String())
+}
+
+// successColour goes from red for a success rate of 0 through yellow to green for 1.
+func successColour(average float64) string {
+	average = math.Max(0, math.Min(1, average))
+	red, green := 255.0, 255.0
+	if average < 0.5 {
+		green = 510 * average
+	} else {
+		red = 510 * (1 - average)
+	}
+	return fmt.Sprintf("#%02x%02x40", int(red), int(green))
+}
+
+func writeCartesianCell(b *strings.Builder, grid geometry.Grid, cell HeatmapCell, opacity float64) {
+	bounds := grid.Bounds(cell.Cell)
+	x := (bounds.Min.X + geometry.DefaultSheet.Width/2) * unitsPerMetre
+	y := bounds.Min.Y * unitsPerMetre
+	fmt.Fprintf(b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s" fill-opacity="%.2f"><title>%d shots, %.0f%%</title></rect>`,
+		x, y, grid.CellSize*unitsPerMetre, grid.CellSize*unitsPerMetre, successColour(cell.Average), opacity, cell.Count, cell.Average*100)
+}
+
+func writePolarCell(b *strings.Builder, grid geometry.Grid, cell HeatmapCell, opacity float64) {
+	bounds := grid.Bounds(cell.Cell)
+	cx, cy := toCanvas(entity.Coordinate{})
+	inner, outer := bounds.MinR*unitsPerMetre, bounds.MaxR*unitsPerMetre
+	colour := successColour(cell.Average)
+
+	// a single sector is a whole ring, which an arc cannot draw
+	if grid.Sectors == 1 {
+		fmt.Fprintf(b, `<circle cx="%.1f" cy="%.1f" r="%.1f" fill="none" stroke="%s" stroke-opacity="%.2f" stroke-width="%.1f"><title>%d shots, %.0f%%</title></circle>`,
+			cx, cy, (inner+outer)/2, colour, opacity, outer-inner, cell.Count, cell.Average*100)
+		return
+	}
+
+	// y grows towards the hog line on the canvas as it does on the sheet, so angles turn clockwise on screen
+	point := func(r, theta float64) (float64, float64) { return cx + r*math.Cos(theta), cy + r*math.Sin(theta) }
+	largeArc := 0
+	if bounds.MaxTheta-bounds.MinTheta > math.Pi {
+		largeArc = 1
+	}
+	x1, y1 := point(outer, bounds.MinTheta)
+	x2, y2 := point(outer, bounds.MaxTheta)
+	x3, y3 := point(inner, bounds.MaxTheta)
+	x4, y4 := point(inner, bounds.MinTheta)
+	fmt.Fprintf(b, `<path d="M%.1f %.1f A%.1f %.1f 0 %d 1 %.1f %.1f L%.1f %.1f A%.1f %.1f 0 %d 0 %.1f %.1f Z" fill="%s" fill-opacity="%.2f"><title>%d shots, %.0f%%</title></path>`,
+		x1, y1, outer, outer, largeArc, x2, y2, x3, y3, inner, inner, largeArc, x4, y4, colour, opacity, cell.Count, cell.Average*100)
+}
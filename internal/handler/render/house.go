@@ -0,0 +1,135 @@
+// Package render draws records as images which can be embedded in reports and chat messages.
+package render
+
+import (
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/geometry"
+	"fmt"
+	"strings"
+)
+
+// unitsPerMetre is the resolution of the drawings: one SVG unit is one centimetre on the sheet.
+const unitsPerMetre = 100
+
+// Rendered sizes in pixels of one diagram: a full-size one and a thumbnail used in end strips.
+const (
+	pixelsPerMetre      = 40
+	stripPixelsPerMetre = 24
+)
+
+const (
+	stripLabelHeight = 60 // room above each diagram of a strip for its label, in SVG units
+	stripGap         = 30 // space between the diagrams of a strip, in SVG units
+)
+
+const (
+	redStone    = "#d32f2f"
+	yellowStone = "#fbc02d"
+	iceColour   = "#f4f8fb"
+	ringOuter   = "#1e63b5"
+	ringInner   = "#d32f2f"
+)
+
+var (
+	panelWidth  = geometry.DefaultSheet.Width * unitsPerMetre
+	panelHeight = (geometry.DefaultSheet.TeeToBackLine + geometry.DefaultSheet.TeeToHogLine) * unitsPerMetre
+)
+
+// House renders the sheet from the back line to the hog line with the stones in play.
+// Our stones are red when the record is played with red stones and yellow otherwise.
+func House(stones entity.Stones, isRed bool) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.1f %.1f">`,
+		panelWidth*pixelsPerMetre/unitsPerMetre, panelHeight*pixelsPerMetre/unitsPerMetre, panelWidth, panelHeight)
+	writeSheet(&b, stones, isRed)
+	b.WriteString(`</svg>`)
+	return []byte(b.String())
+}
+
+// EndStrip renders every position of an end side by side, one small diagram per shot.
+// When stones were placed before the end, their position comes first.
+func EndStrip(endNo int, end entity.DataPerEnd, isRed bool) []byte {
+	type panel struct {
+		label  string
+		stones entity.Stones
+	}
+	var panels []panel
+	if end.PrePlaced != nil {
+		panels = append(panels, panel{label: "Pre-placed", stones: *end.PrePlaced})
+	}
+	for i, shot := range end.Shots {
+		panels = append(panels, panel{label: fmt.Sprintf("Shot %d", i+1), stones: shot.Stones})
+	}
+
+	width := float64(len(panels))*(panelWidth+stripGap) - stripGap
+	if len(panels) == 0 {
+		width = panelWidth
+	}
+	height := panelHeight + 2*stripLabelHeight
+	scale := float64(stripPixelsPerMetre) / unitsPerMetre
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.1f %.1f">`,
+		width*scale, height*scale, width, height)
+	fmt.Fprintf(&b, `<text x="0" y="%d" font-family="sans-serif" font-size="44" font-weight="bold">End %d</text>`,
+		stripLabelHeight-15, endNo)
+	for i, p := range panels {
+		x := float64(i) * (panelWidth + stripGap)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" font-family="sans-serif" font-size="36" text-anchor="middle">%s</text>`,
+			x+panelWidth/2, 2*stripLabelHeight-15, p.label)
+		fmt.Fprintf(&b, `<svg x="%.1f" y="%d" width="%.1f" height="%.1f" viewBox="0 0 %.1f %.1f">`,
+			x, 2*stripLabelHeight, panelWidth, panelHeight, panelWidth, panelHeight)
+		writeSheet(&b, p.stones, isRed)
+		b.WriteString(`</svg>`)
+	}
+	b.WriteString(`</svg>`)
+	return []byte(b.String())
+}
+
+// toCanvas maps a stone position to SVG units, with the back line at the top and the hog line at the bottom.
+func toCanvas(stone entity.Coordinate) (float64, float64) {
+	p := geometry.DefaultSheet.ToCartesian(stone)
+	return (p.X + geometry.DefaultSheet.Width/2) * unitsPerMetre, p.Y * unitsPerMetre
+}
+
+func writeSheet(b *strings.Builder, stones entity.Stones, isRed bool) {
+	fmt.Fprintf(b, `<rect width="%.1f" height="%.1f" fill="%s" stroke="#999"/>`, panelWidth, panelHeight, iceColour)
+
+	cx, cy := toCanvas(entity.Coordinate{})
+	rings := []struct {
+		radius float64
+		fill   string
+	}{
+		{geometry.DefaultSheet.HouseRadius, ringOuter}, // 12-foot
+		{1.219, "#fff"},    // 8-foot
+		{0.610, ringInner}, // 4-foot
+		{0.152, "#fff"},    // button
+	}
+	for _, ring := range rings {
+		fmt.Fprintf(b, `<circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s"/>`, cx, cy, ring.radius*unitsPerMetre, ring.fill)
+	}
+
+	// tee line, centre line and hog line
+	fmt.Fprintf(b, `<line x1="0" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#555" stroke-width="2"/>`, cy, panelWidth, cy)
+	fmt.Fprintf(b, `<line x1="%.1f" y1="0" x2="%.1f" y2="%.1f" stroke="#555" stroke-width="2"/>`, cx, cx, panelHeight)
+	fmt.Fprintf(b, `<line x1="0" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#c62828" stroke-width="8"/>`, panelHeight-4, panelWidth, panelHeight-4)
+
+	friendColour, enemyColour := yellowStone, redStone
+	if isRed {
+		friendColour, enemyColour = redStone, yellowStone
+	}
+	for _, stone := range stones.FriendStones {
+		writeStone(b, stone, friendColour)
+	}
+	for _, stone := range stones.EnemyStones {
+		writeStone(b, stone, enemyColour)
+	}
+}
+
+func writeStone(b *strings.Builder, stone entity.Coordinate, colour string) {
+	x, y := toCanvas(stone)
+	fmt.Fprintf(b, `<circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s" stroke="#333" stroke-width="3"/>`,
+		x, y, geometry.DefaultSheet.StoneRadius*unitsPerMetre, colour)
+	fmt.Fprintf(b, `<text x="%.1f" y="%.1f" font-family="sans-serif" font-size="16" font-weight="bold" text-anchor="middle" dominant-baseline="central">%d</text>`,
+		x, y, stone.Index)
+}
@@ -0,0 +1,103 @@
+package render_test
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/handler/render"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// drawing is the part of an SVG document the tests look at.
+type drawing struct {
+	Width   string `xml:"width,attr"`
+	Height  string `xml:"height,attr"`
+	Circles []struct {
+		Fill   string `xml:"fill,attr"`
+		Stroke string `xml:"stroke,attr"`
+	} `xml:"circle"`
+	Texts  []string  `xml:"text"`
+	Panels []drawing `xml:"svg"`
+}
+
+// stoneFills returns the colours of the stones, which are the only circles with an outline.
+func (d drawing) stoneFills() []string {
+	fills := []string{}
+	for _, circle := range d.Circles {
+		if circle.Stroke != "" {
+			fills = append(fills, circle.Fill)
+		}
+	}
+	return fills
+}
+
+func parse(t *testing.T, svg []byte) drawing {
+	t.Helper()
+	var d drawing
+	if err := xml.Unmarshal(svg, &d); err != nil {
+		t.Fatalf("invalid SVG: %v\n%s", err, svg)
+	}
+	return d
+}
+
+func TestHouse(t *testing.T) {
+	stones := entity.Stones{
+		FriendStones: []entity.Coordinate{{Index: 1, R: 0.5, Theta: 0}, {Index: 3, R: 1, Theta: 2}},
+		EnemyStones:  []entity.Coordinate{{Index: 2, R: 0.3, Theta: 1}},
+	}
+
+	t.Run("正常系: 赤の記録では自チームの石が赤", func(t *testing.T) {
+		d := parse(t, render.House(stones, true))
+		assert.Equal(t, []string{"#d32f2f", "#d32f2f", "#fbc02d"}, d.stoneFills())
+	})
+
+	t.Run("正常系: 黄の記録では自チームの石が黄", func(t *testing.T) {
+		d := parse(t, render.House(stones, false))
+		assert.Equal(t, []string{"#fbc02d", "#fbc02d", "#d32f2f"}, d.stoneFills())
+	})
+
+	t.Run("正常系: 石に番号を振る", func(t *testing.T) {
+		d := parse(t, render.House(stones, true))
+		assert.Equal(t, []string{"1", "3", "2"}, d.Texts)
+	})
+
+	t.Run("正常系: 石がなくてもシートを描く", func(t *testing.T) {
+		svg := render.House(entity.Stones{}, true)
+		d := parse(t, svg)
+		assert.Empty(t, d.stoneFills())
+		assert.Len(t, d.Circles, 4, "the rings of the house")
+		assert.NotEmpty(t, d.Width)
+		assert.NotEmpty(t, d.Height)
+		assert.True(t, strings.HasPrefix(string(svg), `<svg xmlns="http://www.w3.org/2000/svg"`))
+	})
+}
+
+func TestEndStrip(t *testing.T) {
+	prePlaced := entity.Stones{FriendStones: []entity.Coordinate{{Index: 6, R: 1, Theta: 1}}}
+	end := entity.DataPerEnd{
+		PrePlaced: &prePlaced,
+		Shots: []entity.Shot{
+			{Stones: entity.Stones{FriendStones: []entity.Coordinate{{Index: 6, R: 1, Theta: 1}, {Index: 1, R: 0.2, Theta: 0}}}},
+			{Stones: entity.Stones{EnemyStones: []entity.Coordinate{{Index: 1, R: 0.4, Theta: 0}}}},
+		},
+	}
+
+	t.Run("正常系: 事前に置かれた石とショットごとに図を並べる", func(t *testing.T) {
+		d := parse(t, render.EndStrip(3, end, false))
+		assert.Equal(t, []string{"End 3", "Pre-placed", "Shot 1", "Shot 2"}, d.Texts)
+		assert.Len(t, d.Panels, 3)
+		assert.Equal(t, []string{"#fbc02d"}, d.Panels[0].stoneFills())
+		assert.Equal(t, []string{"#fbc02d", "#fbc02d"}, d.Panels[1].stoneFills())
+		assert.Equal(t, []string{"#d32f2f"}, d.Panels[2].stoneFills())
+		assert.Equal(t, []string{"1"}, d.Panels[2].Texts)
+	})
+
+	t.Run("正常系: ショットのないエンドも描ける", func(t *testing.T) {
+		d := parse(t, render.EndStrip(1, entity.DataPerEnd{}, true))
+		assert.Equal(t, []string{"End 1"}, d.Texts)
+		assert.Empty(t, d.Panels)
+	})
+}
@@ -0,0 +1,79 @@
+package render
+
+import (
+	"CurlARC/internal/handler/response"
+	"bytes"
+	"fmt"
+	"html/template"
+)
+
+var seasonReport = template.Must(template.New("season").Funcs(template.FuncMap{
+	"percent": func(rate float64) string { return fmt.Sprintf("%.0f%%", rate*100) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.TeamName}} – {{.Season.Name}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+h1 { margin-bottom: 0; }
+.period { color: #666; margin-top: 0.2em; }
+table { border-collapse: collapse; margin: 0.5em 0 1.5em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.8em; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+th { background: #f0f3f7; }
+.record { font-size: 1.4em; }
+@media print { body { margin: 0; } h2 { page-break-after: avoid; } table { page-break-inside: avoid; } }
+</style>
+</head>
+<body>
+<h1>{{.TeamName}} – {{.Season.Name}}</h1>
+<p class="period">{{.Season.StartDate.Format "2006-01-02"}} to {{.Season.EndDate.Format "2006-01-02"}}</p>
+
+<h2>Record</h2>
+<p class="record">{{.Wins}} W – {{.Losses}} L – {{.Draws}} D{{if .Abandoned}} ({{.Abandoned}} abandoned){{end}}</p>
+<table>
+<tr><th>Games</th><th>Points for</th><th>Points against</th><th>Longest win streak</th><th>Longest losing streak</th><th>Current streak</th></tr>
+<tr><td>{{.Games}}</td><td>{{.PointsFor}}</td><td>{{.PointsAgainst}}</td><td>{{.LongestWinStreak}}</td><td>{{.LongestLossStreak}}</td><td>{{if .CurrentStreak.Games}}{{.CurrentStreak.Games}} {{.CurrentStreak.Result}}{{else}}–{{end}}</td></tr>
+</table>
+
+<h2>Top shooters</h2>
+<table>
+<tr><th>Shooter</th><th>Shots</th><th>Success</th><th>With hammer</th><th>Without hammer</th></tr>
+{{range .TopShooters}}<tr><td>{{.Shooter}}</td><td>{{.Overall.Shots}}</td><td>{{percent .Overall.Average}}</td><td>{{percent .WithHammer.Average}}</td><td>{{percent .WithoutHammer.Average}}</td></tr>
+{{else}}<tr><td colspan="5">No shots recorded</td></tr>
+{{end}}</table>
+
+<h2>Hammer efficiency</h2>
+{{with .Hammer.Overall}}<table>
+<tr><th>With hammer</th><th>Ends</th><th>Two or more</th><th>Forced to one</th><th>Blanks</th><th>Stolen against</th></tr>
+<tr><td></td><td>{{.WithHammer.Ends}}</td><td>{{percent .WithHammer.ConversionRate}}</td><td>{{percent .WithHammer.ForcedRate}}</td><td>{{percent .WithHammer.BlankRate}}</td><td>{{percent .WithHammer.StolenRate}}</td></tr>
+<tr><th>Without hammer</th><th>Ends</th><th>Steals</th><th>Forced to one</th><th>Blanks</th><th>Allowed two or more</th></tr>
+<tr><td></td><td>{{.WithoutHammer.Ends}}</td><td>{{percent .WithoutHammer.StealRate}}</td><td>{{percent .WithoutHammer.ForceRate}}</td><td>{{percent .WithoutHammer.BlankRate}}</td><td>{{percent .WithoutHammer.AllowedTwoOrMoreRate}}</td></tr>
+</table>{{end}}
+
+<h2>Opponents</h2>
+<table>
+<tr><th>Opponent</th><th>W</th><th>L</th><th>D</th><th>Points for / game</th><th>Points against / game</th></tr>
+{{range .Opponents}}<tr><td>{{.Opponent}}</td><td>{{.Wins}}</td><td>{{.Losses}}</td><td>{{.Draws}}</td><td>{{printf "%.1f" .AveragePointsFor}}</td><td>{{printf "%.1f" .AveragePointsAgainst}}</td></tr>
+{{else}}<tr><td colspan="6">No games</td></tr>
+{{end}}</table>
+
+<h2>Venues</h2>
+<table>
+<tr><th>Venue</th><th>Games</th><th>W</th><th>L</th><th>D</th></tr>
+{{range .Venues}}<tr><td>{{.Place}}</td><td>{{.Games}}</td><td>{{.Wins}}</td><td>{{.Losses}}</td><td>{{.Draws}}</td></tr>
+{{else}}<tr><td colspan="5">No games</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// SeasonReport renders a season summary as an HTML page laid out for printing.
+func SeasonReport(summary response.SeasonSummary) ([]byte, error) {
+	var b bytes.Buffer
+	if err := seasonReport.Execute(&b, summary); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,29 @@
+package request
+
+import (
+	"CurlARC/internal/domain/entity"
+	"time"
+)
+
+type CreateEventRequest struct {
+	Name      string             `json:"name"`
+	Venue     string             `json:"venue"`
+	Format    entity.EventFormat `json:"format"`
+	StartDate time.Time          `json:"start_date"`
+	EndDate   time.Time          `json:"end_date"`
+}
+
+type UpdateEventRequest struct {
+	Name      *string             `json:"name"`
+	Venue     *string             `json:"venue"`
+	Format    *entity.EventFormat `json:"format"`
+	StartDate *time.Time          `json:"start_date"`
+	EndDate   *time.Time          `json:"end_date"`
+}
+
+// AttachRecordRequest holds the round of a game and the last-stone draws thrown before it, in metres from the button.
+type AttachRecordRequest struct {
+	Round     string   `json:"round"`
+	FriendLSD *float64 `json:"friend_lsd"`
+	EnemyLSD  *float64 `json:"enemy_lsd"`
+}
@@ -0,0 +1,21 @@
+package request
+
+import (
+	"CurlARC/internal/domain/entity"
+	"time"
+)
+
+type CreatePracticeSessionRequest struct {
+	Date      time.Time             `json:"date"`
+	Place     string                `json:"place"`
+	Attendees []entity.LineupPlayer `json:"attendees"`
+	Drills    []entity.Drill        `json:"drills"`
+}
+
+// UpdatePracticeSessionRequest replaces the attendees and the drills as a whole when they are given.
+type UpdatePracticeSessionRequest struct {
+	Date      *time.Time             `json:"date"`
+	Place     *string                `json:"place"`
+	Attendees *[]entity.LineupPlayer `json:"attendees"`
+	Drills    *[]entity.Drill        `json:"drills"`
+}
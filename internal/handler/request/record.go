@@ -6,14 +6,16 @@ import (
 )
 
 type CreateRecordRequest struct {
-	Result        entity.Result `json:"result"`
-	EnemyTeamName string        `json:"enemy_team_name"`
-	Place         string        `json:"place"`
-	Date          time.Time     `json:"date"`
+	Result        entity.Result     `json:"result"`
+	EnemyTeamName string            `json:"enemy_team_name"`
+	Place         string            `json:"place"`
+	Date          time.Time         `json:"date"`
+	GameFormat    entity.GameFormat `json:"game_format"`
 }
 
 type AppendEndDataRequest struct {
-	EndsData []entity.DataPerEnd `json:"ends_data"`
+	EndsData      []entity.DataPerEnd `json:"ends_data"`
+	FreeGuardZone string              `json:"free_guard_zone"` // FLAG (default), REJECT or RESTORE
 }
 
 type UpdateRecordRequest = struct {
@@ -25,8 +27,57 @@ type UpdateRecordRequest = struct {
 	IsRed         *bool                `json:"is_red"`
 	IsFirst       *bool                `json:"is_first"`
 	IsPublic      *bool                `json:"is_public"`
+	Status        *entity.GameStatus   `json:"status"`
+	FreeGuardZone string               `json:"free_guard_zone"` // FLAG (default), REJECT or RESTORE
+}
+
+// CartesianStone is a stone position on the sheet in metres, as written in ends_data with ?coords=cartesian.
+type CartesianStone struct {
+	Index int     `json:"index"`
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+}
+
+type CartesianStones struct {
+	FriendStones []CartesianStone `json:"friend_stones"`
+	EnemyStones  []CartesianStone `json:"enemy_stones"`
+}
+
+// CartesianEnd holds the stones of an end written in cartesian coordinates. The rest of the end is read as usual.
+type CartesianEnd struct {
+	Shots []struct {
+		Stones CartesianStones `json:"stones"`
+	} `json:"shots"`
+	PrePlaced *CartesianStones `json:"pre_placed"`
 }
 
 type SetVisibilityRequest struct {
 	IsPublic bool `json:"is_public"`
 }
+
+type SetLineupRequest struct {
+	Entries       []entity.LineupEntry  `json:"entries"`
+	Substitutions []entity.Substitution `json:"substitutions"`
+}
+
+type AddSubstitutionRequest struct {
+	End       int             `json:"end"`
+	Shot      int             `json:"shot"`
+	Position  entity.Position `json:"position"`
+	UserId    string          `json:"user_id"`
+	GuestName string          `json:"guest_name"`
+}
+
+// RecordListRequest holds the query parameters of the record listing on top of the date and opponent filter.
+// IsPublic and HasEndsData are "true" or "false", Sort is one of date, -date (default), opponent, -opponent,
+// place and -place, and Cursor is the next_cursor of the previous page.
+type RecordListRequest struct {
+	StatsFilterRequest
+	Result      string `query:"result"`
+	Place       string `query:"place"`
+	IsPublic    string `query:"is_public"`
+	HasEndsData string `query:"has_ends_data"`
+	Sort        string `query:"sort"`
+	Cursor      string `query:"cursor"`
+	Limit       int    `query:"limit"`
+}
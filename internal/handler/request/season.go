@@ -0,0 +1,16 @@
+package request
+
+import "time"
+
+// Season dates are days; the time of day is ignored.
+type CreateSeasonRequest struct {
+	Name      string    `json:"name"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+type UpdateSeasonRequest struct {
+	Name      *string    `json:"name"`
+	StartDate *time.Time `json:"start_date"`
+	EndDate   *time.Time `json:"end_date"`
+}
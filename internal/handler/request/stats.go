@@ -0,0 +1,42 @@
+package request
+
+import "CurlARC/internal/domain/entity"
+
+// StatsFilterRequest holds the query parameters shared by the team statistics endpoints.
+// Dates are either YYYY-MM-DD or RFC3339.
+type StatsFilterRequest struct {
+	From     string `query:"from"`
+	To       string `query:"to"`
+	Opponent string `query:"opponent"`
+}
+
+// HeatmapRequest holds the query parameters of the heatmap endpoint on top of the statistics filter.
+// Hammer is "true" or "false", and Format is "json" or "svg".
+type HeatmapRequest struct {
+	StatsFilterRequest
+	Grid     string  `query:"grid"`
+	CellSize float64 `query:"cell_size"`
+	Sectors  int     `query:"sectors"`
+	Shooter  string  `query:"shooter"`
+	ShotType string  `query:"shot_type"`
+	End      int     `query:"end"`
+	Hammer   string  `query:"hammer"`
+	Format   string  `query:"format"`
+}
+
+// WinProbabilityRequest describes a game state before an end. ScoreDifference is our score minus theirs,
+// and GameFormat defaults to the default format.
+type WinProbabilityRequest struct {
+	End             int    `query:"end"`
+	ScoreDifference int    `query:"score_difference"`
+	Hammer          bool   `query:"hammer"`
+	GameFormat      string `query:"game_format"`
+}
+
+// SimilarPositionRequest is a position to look for in past ends, in polar coordinates around the button.
+type SimilarPositionRequest struct {
+	Stones        entity.Stones `json:"stones"`
+	Hammer        *bool         `json:"hammer"`
+	IncludePublic bool          `json:"include_public"`
+	Limit         int           `json:"limit"` // 10 by default, at most 50
+}
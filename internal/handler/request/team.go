@@ -10,4 +10,12 @@ type UpdateTeamRequest struct {
 
 type InviteUsersRequest struct {
 	TargetUserEmails []string `json:"target_user_emails"`
+}
+
+type SetRoleRequest struct {
+	Role string `json:"role"` // ADMIN, MEMBER or VIEWER
+}
+
+type TransferOwnershipRequest struct {
+	UserId string `json:"user_id"`
 }
\ No newline at end of file
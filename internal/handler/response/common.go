@@ -10,7 +10,11 @@ type ErrorResponse struct {
 	Error  ErrorDetail `json:"error"`
 }
 
+// ErrorDetail describes a failure. Code is the HTTP status and ErrorCode a stable machine-readable code
+// such as NOT_TEAM_MEMBER, which does not change with the wording of the message.
 type ErrorDetail struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+	Code      int         `json:"code"`
+	ErrorCode string      `json:"error_code,omitempty"`
+	Message   string      `json:"message"`
+	Details   interface{} `json:"details,omitempty"`
 }
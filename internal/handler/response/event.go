@@ -0,0 +1,45 @@
+package response
+
+import (
+	"CurlARC/internal/domain/entity"
+	"time"
+)
+
+type EventGame struct {
+	RecordId  string   `json:"record_id"`
+	Round     string   `json:"round,omitempty"`
+	FriendLSD *float64 `json:"friend_lsd"`
+	EnemyLSD  *float64 `json:"enemy_lsd"`
+}
+
+type Event struct {
+	Id        string             `json:"id"`
+	TeamId    string             `json:"team_id"`
+	Name      string             `json:"name"`
+	Venue     string             `json:"venue"`
+	Format    entity.EventFormat `json:"format"`
+	StartDate time.Time          `json:"start_date"`
+	EndDate   time.Time          `json:"end_date"`
+	Games     []EventGame        `json:"games"`
+}
+
+type StandingsRow struct {
+	Rank              int      `json:"rank"`
+	Team              string   `json:"team"`
+	Friend            bool     `json:"friend"`
+	Games             int      `json:"games"`
+	Wins              int      `json:"wins"`
+	Losses            int      `json:"losses"`
+	Draws             int      `json:"draws"`
+	PointsFor         int      `json:"points_for"`
+	PointsAgainst     int      `json:"points_against"`
+	DrawShotChallenge *float64 `json:"draw_shot_challenge"`
+	BestLSD           *float64 `json:"best_lsd"`
+	Eliminated        bool     `json:"eliminated"`
+}
+
+type Standings struct {
+	Format    entity.EventFormat `json:"format"`
+	Rows      []StandingsRow     `json:"rows"`
+	Abandoned int                `json:"abandoned"`
+}
@@ -0,0 +1,47 @@
+package response
+
+import (
+	"CurlARC/internal/domain/entity"
+	"time"
+)
+
+type PracticeSession struct {
+	Id        string                `json:"id"`
+	TeamId    string                `json:"team_id"`
+	Date      time.Time             `json:"date"`
+	Place     string                `json:"place"`
+	Attendees []entity.LineupPlayer `json:"attendees"`
+	Drills    []entity.Drill        `json:"drills"`
+}
+
+// DrillStats reports the distances of positional drills in metres; they are left out for takeout drills.
+type DrillStats struct {
+	Type entity.DrillType `json:"type"`
+	SuccessRate
+	Measured     int      `json:"measured,omitempty"`
+	MeanDistance *float64 `json:"mean_distance,omitempty"`
+	OutOfPlay    int      `json:"out_of_play,omitempty"`
+}
+
+type PlayerPracticeStats struct {
+	Shooter   string       `json:"shooter"`
+	UserId    string       `json:"user_id,omitempty"`
+	GuestName string       `json:"guest_name,omitempty"`
+	Attended  int          `json:"attended"`
+	Overall   SuccessRate  `json:"overall"`
+	ByDrill   []DrillStats `json:"by_drill"`
+}
+
+type PracticeStats struct {
+	Sessions int                   `json:"sessions"`
+	Players  []PlayerPracticeStats `json:"players"`
+}
+
+type PlayerReport struct {
+	Shooter   string               `json:"shooter"`
+	UserId    string               `json:"user_id,omitempty"`
+	GuestName string               `json:"guest_name,omitempty"`
+	Match     *ShooterStats        `json:"match"`
+	Practice  *PlayerPracticeStats `json:"practice"`
+	Combined  SuccessRate          `json:"combined"`
+}
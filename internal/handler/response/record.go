@@ -2,35 +2,136 @@ package response
 
 import (
 	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/geometry"
 	"time"
 
 	"gorm.io/datatypes"
 )
 
 type RecordIndex struct {
-	Id            string        `json:"id"`
-	Result        entity.Result `json:"result"`
-	EnemyTeamName string        `json:"enemy_team_name"`
-	Place         string        `json:"place"`
-	Date          time.Time     `json:"date"`
+	Id            string            `json:"id"`
+	Result        entity.Result     `json:"result"`
+	EnemyTeamName string            `json:"enemy_team_name"`
+	Place         string            `json:"place"`
+	Date          time.Time         `json:"date"`
+	GameFormat    entity.GameFormat `json:"game_format"`
+	Status        entity.GameStatus `json:"status"`
+	EndsPlayed    int               `json:"ends_played"`
+	ExtraEnds     int               `json:"extra_ends"`
 }
 
 type Record struct {
-	Id            string         `json:"id"`
-	TeamId        string         `json:"team_id"`
-	Result        entity.Result  `json:"result"`
-	EnemyTeamName string         `json:"enemy_team_name"`
-	Place         string         `json:"place"`
-	Date          time.Time      `json:"date"`
-	EndsData      datatypes.JSON `json:"ends_data"`
-	IsRed         bool           `json:"is_red"`
-	IsFirst       bool           `json:"is_first"`
-	IsPublic      bool           `json:"is_public"`
+	Id            string            `json:"id"`
+	TeamId        string            `json:"team_id"`
+	Result        entity.Result     `json:"result"`
+	EnemyTeamName string            `json:"enemy_team_name"`
+	Place         string            `json:"place"`
+	Date          time.Time         `json:"date"`
+	EndsData      datatypes.JSON    `json:"ends_data"`
+	IsRed         bool              `json:"is_red"`
+	IsFirst       bool              `json:"is_first"`
+	IsPublic      bool              `json:"is_public"`
+	Lineup        entity.Lineup     `json:"lineup"`
+	GameFormat    entity.GameFormat `json:"game_format"`
+	Status        entity.GameStatus `json:"status"`
+	Coordinates   Coordinates       `json:"coordinates"`
+	Movements     []EndMovements    `json:"movements,omitempty"`
+}
+
+// Coordinates tells how the stone positions of a response are expressed.
+// Polar positions are around the button, cartesian ones are on the sheet described by Sheet.
+type Coordinates struct {
+	System string          `json:"system"` // POLAR or CARTESIAN
+	Sheet  *geometry.Sheet `json:"sheet,omitempty"`
+}
+
+// CartesianStone is a stone position on the sheet in metres.
+type CartesianStone struct {
+	Index   int     `json:"index"`
+	X       float64 `json:"x"`
+	Y       float64 `json:"y"`
+	InHouse bool    `json:"in_house"`
+}
+
+type CartesianStones struct {
+	FriendStones []CartesianStone `json:"friend_stones"`
+	EnemyStones  []CartesianStone `json:"enemy_stones"`
+}
+
+// CartesianShot is a shot whose stones are given in cartesian coordinates.
+type CartesianShot struct {
+	entity.Shot
+	Stones CartesianStones `json:"stones"`
+}
+
+// CartesianEnd is an end whose stones are given in cartesian coordinates.
+type CartesianEnd struct {
+	entity.DataPerEnd
+	Shots     []CartesianShot  `json:"shots"`
+	PrePlaced *CartesianStones `json:"pre_placed,omitempty"`
+}
+
+// StoneMovement is what a shot did to one stone. The displacement is in metres.
+// From and To are an entity.Coordinate or a CartesianStone depending on the coordinate system.
+type StoneMovement struct {
+	Index    int         `json:"index"`
+	Side     string      `json:"side"`
+	Movement string      `json:"movement"`
+	From     interface{} `json:"from,omitempty"`
+	To       interface{} `json:"to,omitempty"`
+	DX       float64     `json:"dx"`
+	DY       float64     `json:"dy"`
+	Distance float64     `json:"distance"`
+}
+
+type ShotMovements struct {
+	Shot    int             `json:"shot"`
+	Placed  int             `json:"placed"`
+	Moved   int             `json:"moved"`
+	Removed int             `json:"removed"`
+	Stones  []StoneMovement `json:"stones"`
+}
+
+type EndMovements struct {
+	End   int             `json:"end"`
+	Shots []ShotMovements `json:"shots"`
 }
 
 type GetRecordIndicesByTeamIdResponse struct {
 	Status string `json:"status"`
 	Data   struct {
 		RecordIndices []RecordIndex `json:"record_indices"`
+		NextCursor    *string       `json:"next_cursor"`
 	} `json:"data"`
 }
+
+type ScoreboardEnd struct {
+	End          int  `json:"end"`
+	FriendPoints int  `json:"friend_points"`
+	EnemyPoints  int  `json:"enemy_points"`
+	FriendTotal  int  `json:"friend_total"`
+	EnemyTotal   int  `json:"enemy_total"`
+	FriendHammer bool `json:"friend_hammer"`
+	PowerPlay    bool `json:"power_play"`
+	Extra        bool `json:"extra"`
+	Blank        bool `json:"blank"`
+	Steal        bool `json:"steal"`
+}
+
+type Scoreboard struct {
+	RecordId       string            `json:"record_id"`
+	Status         entity.GameStatus `json:"status"`
+	RegulationEnds int               `json:"regulation_ends"`
+	EndsPlayed     int               `json:"ends_played"`
+	ExtraEnds      int               `json:"extra_ends"`
+	ConcededAfter  int               `json:"conceded_after,omitempty"`
+	Ends           []ScoreboardEnd   `json:"ends"`
+	FriendTotal    int               `json:"friend_total"`
+	EnemyTotal     int               `json:"enemy_total"`
+	BlankEnds      int               `json:"blank_ends"`
+	FriendSteals   int               `json:"friend_steals"`
+	EnemySteals    int               `json:"enemy_steals"`
+	Result         entity.Result     `json:"result"`
+	ComputedResult entity.Result     `json:"computed_result"`
+	ResultMatches  bool              `json:"result_matches"`
+}
@@ -0,0 +1,46 @@
+package response
+
+import (
+	"CurlARC/internal/domain/entity"
+	"time"
+)
+
+type Season struct {
+	Id        string    `json:"id"`
+	TeamId    string    `json:"team_id"`
+	Name      string    `json:"name"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+type Streak struct {
+	Result entity.Result `json:"result,omitempty"`
+	Games  int           `json:"games"`
+}
+
+type VenueSummary struct {
+	Place  string `json:"place"`
+	Games  int    `json:"games"`
+	Wins   int    `json:"wins"`
+	Losses int    `json:"losses"`
+	Draws  int    `json:"draws"`
+}
+
+type SeasonSummary struct {
+	Season            Season         `json:"season"`
+	TeamName          string         `json:"team_name"`
+	Games             int            `json:"games"`
+	Wins              int            `json:"wins"`
+	Losses            int            `json:"losses"`
+	Draws             int            `json:"draws"`
+	Abandoned         int            `json:"abandoned"`
+	PointsFor         int            `json:"points_for"`
+	PointsAgainst     int            `json:"points_against"`
+	LongestWinStreak  int            `json:"longest_win_streak"`
+	LongestLossStreak int            `json:"longest_loss_streak"`
+	CurrentStreak     Streak         `json:"current_streak"`
+	TopShooters       []ShooterStats `json:"top_shooters"`
+	Hammer            HammerStats    `json:"hammer"`
+	Opponents         []HeadToHead   `json:"opponents"`
+	Venues            []VenueSummary `json:"venues"`
+}
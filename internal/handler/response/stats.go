@@ -0,0 +1,167 @@
+package response
+
+import (
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/geometry"
+	"time"
+)
+
+type SuccessRate struct {
+	Shots   int     `json:"shots"`
+	Average float64 `json:"average"`
+}
+
+type ShotTypeSuccessRate struct {
+	Type string `json:"type"`
+	SuccessRate
+}
+
+type EndSuccessRate struct {
+	End int `json:"end"`
+	SuccessRate
+}
+
+type ShooterStats struct {
+	Shooter       string                `json:"shooter"`
+	UserId        string                `json:"user_id,omitempty"`
+	GuestName     string                `json:"guest_name,omitempty"`
+	Overall       SuccessRate           `json:"overall"`
+	ByShotType    []ShotTypeSuccessRate `json:"by_shot_type"`
+	ByEnd         []EndSuccessRate      `json:"by_end"`
+	WithHammer    SuccessRate           `json:"with_hammer"`
+	WithoutHammer SuccessRate           `json:"without_hammer"`
+}
+
+type HammerEfficiency struct {
+	Ends           int     `json:"ends"`
+	TwoOrMore      int     `json:"two_or_more"`
+	ForcedToOne    int     `json:"forced_to_one"`
+	Blanks         int     `json:"blanks"`
+	StolenAgainst  int     `json:"stolen_against"`
+	ConversionRate float64 `json:"conversion_rate"`
+	ForcedRate     float64 `json:"forced_rate"`
+	BlankRate      float64 `json:"blank_rate"`
+	StolenRate     float64 `json:"stolen_rate"`
+}
+
+type StealDefense struct {
+	Ends                 int     `json:"ends"`
+	Steals               int     `json:"steals"`
+	ForcedOpponentToOne  int     `json:"forced_opponent_to_one"`
+	AllowedTwoOrMore     int     `json:"allowed_two_or_more"`
+	Blanks               int     `json:"blanks"`
+	StealRate            float64 `json:"steal_rate"`
+	ForceRate            float64 `json:"force_rate"`
+	AllowedTwoOrMoreRate float64 `json:"allowed_two_or_more_rate"`
+	BlankRate            float64 `json:"blank_rate"`
+}
+
+type HammerSplit struct {
+	WithHammer    HammerEfficiency `json:"with_hammer"`
+	WithoutHammer StealDefense     `json:"without_hammer"`
+}
+
+type HammerStats struct {
+	Records     int                    `json:"records"`
+	Overall     HammerSplit            `json:"overall"`
+	BySituation map[string]HammerSplit `json:"by_situation"`
+	ByPhase     map[string]HammerSplit `json:"by_phase"`
+}
+
+type HeadToHeadMatch struct {
+	RecordId      string            `json:"record_id"`
+	Date          time.Time         `json:"date"`
+	Place         string            `json:"place"`
+	Result        entity.Result     `json:"result"`
+	Status        entity.GameStatus `json:"status"`
+	EndsPlayed    int               `json:"ends_played"`
+	PointsFor     int               `json:"points_for"`
+	PointsAgainst int               `json:"points_against"`
+}
+
+type HeadToHead struct {
+	Opponent                   string            `json:"opponent"`
+	Wins                       int               `json:"wins"`
+	Losses                     int               `json:"losses"`
+	Draws                      int               `json:"draws"`
+	Abandoned                  int               `json:"abandoned"`
+	AveragePointsFor           float64           `json:"average_points_for"`
+	AveragePointsAgainst       float64           `json:"average_points_against"`
+	AveragePointsForPerEnd     float64           `json:"average_points_for_per_end"`
+	AveragePointsAgainstPerEnd float64           `json:"average_points_against_per_end"`
+	Hammer                     HammerSplit       `json:"hammer"`
+	Matches                    []HeadToHeadMatch `json:"matches"`
+}
+
+type PolarCellBounds struct {
+	MinR     float64 `json:"min_r"`
+	MaxR     float64 `json:"max_r"`
+	MinTheta float64 `json:"min_theta"`
+	MaxTheta float64 `json:"max_theta"`
+}
+
+type CartesianCellBounds struct {
+	MinX float64 `json:"min_x"`
+	MaxX float64 `json:"max_x"`
+	MinY float64 `json:"min_y"`
+	MaxY float64 `json:"max_y"`
+}
+
+// HeatmapCell is a ring and a sector of a polar grid, or a row and a column of a cartesian one.
+type HeatmapCell struct {
+	Row       int                  `json:"row"`
+	Column    int                  `json:"column"`
+	Polar     *PolarCellBounds     `json:"polar,omitempty"`
+	Cartesian *CartesianCellBounds `json:"cartesian,omitempty"`
+	SuccessRate
+}
+
+type Heatmap struct {
+	Grid      geometry.Grid `json:"grid"`
+	Records   int           `json:"records"`
+	Shots     int           `json:"shots"`
+	OutOfPlay int           `json:"out_of_play"`
+	Cells     []HeatmapCell `json:"cells"`
+}
+
+type WinProbability struct {
+	Probability float64 `json:"probability"`
+	Games       int     `json:"games"`
+	Wins        float64 `json:"wins"`
+	Prior       float64 `json:"prior"`
+}
+
+type WinProbabilityPoint struct {
+	End          int  `json:"end"`
+	FriendTotal  int  `json:"friend_total"`
+	EnemyTotal   int  `json:"enemy_total"`
+	FriendHammer bool `json:"friend_hammer"`
+	WinProbability
+	Swing float64 `json:"swing"`
+}
+
+type WinProbabilityCurve struct {
+	RecordId string                `json:"record_id"`
+	Result   entity.Result         `json:"result"`
+	Points   []WinProbabilityPoint `json:"points"`
+	Final    float64               `json:"final"`
+}
+
+type SimilarPosition struct {
+	RecordId      string        `json:"record_id"`
+	TeamId        string        `json:"team_id"`
+	EnemyTeamName string        `json:"enemy_team_name"`
+	Date          time.Time     `json:"date"`
+	End           int           `json:"end"`
+	Shot          int           `json:"shot"`
+	FriendHammer  bool          `json:"friend_hammer"`
+	Distance      float64       `json:"distance"`
+	Stones        entity.Stones `json:"stones"`
+	NextShot      *entity.Shot  `json:"next_shot"`
+	EndScore      int           `json:"end_score"`
+}
+
+// SimilarPositions is the result of a similar position search, closest first.
+type SimilarPositions struct {
+	Positions []SimilarPosition `json:"positions"`
+}
@@ -1,10 +1,25 @@
 package response
 
+import "time"
+
 type Team struct {
 	Id   string `json:"id"`
 	Name string `json:"name"`
 }
 
+type Invitation struct {
+	Team      Team      `json:"team"`
+	User      User      `json:"user"`       // the invited user
+	InvitedBy *User     `json:"invited_by"` // null if the inviter is unknown
+	InvitedAt time.Time `json:"invited_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type MemberRole struct {
+	UserId string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
 type GetAllTeamsResponse []struct {
 	Id   string `json:"id"`
 	Name string `json:"name"`
@@ -15,6 +15,10 @@ func InitRouting(
 	userHandler UserHandler,
 	teamHandler TeamHandler,
 	recordHandler RecordHandler,
+	statsHandler StatsHandler,
+	eventHandler EventHandler,
+	seasonHandler SeasonHandler,
+	practiceHandler PracticeHandler,
 ) {
 	// health check
 	e.GET("/health", func(c echo.Context) error {
@@ -49,20 +53,59 @@ func InitRouting(
 	teamGroup.DELETE("/:teamId", teamHandler.DeleteTeam())
 	teamGroup.POST("/:teamId/invite", teamHandler.InviteUsers())
 	teamGroup.POST("/:teamId/accept", teamHandler.AcceptInvitation())
+	teamGroup.POST("/:teamId/decline", teamHandler.DeclineInvitation())
+	teamGroup.DELETE("/:teamId/invitations/:userId", teamHandler.RevokeInvitation())
 	teamGroup.DELETE("/:teamId/:userId", teamHandler.RemoveMember())
+	teamGroup.GET("/:teamId/roles", teamHandler.GetRoles())
+	teamGroup.PUT("/:teamId/members/:userId/role", teamHandler.SetRole())
+	teamGroup.POST("/:teamId/transfer", teamHandler.TransferOwnership())
+	teamGroup.GET("/:teamId/stats/shooters", statsHandler.GetShooterStats())
+	teamGroup.GET("/:teamId/stats/hammer", statsHandler.GetHammerStats())
+	teamGroup.GET("/:teamId/stats/head-to-head", statsHandler.GetHeadToHead())
+	teamGroup.GET("/:teamId/stats/heatmap", statsHandler.GetHeatmap())
+	teamGroup.GET("/:teamId/stats/win-probability", statsHandler.GetWinProbability())
+	teamGroup.POST("/:teamId/positions/search", statsHandler.FindSimilarPositions())
+	teamGroup.POST("/:teamId/events", eventHandler.CreateEvent())
+	teamGroup.GET("/:teamId/events", eventHandler.GetEvents())
+	teamGroup.GET("/:teamId/events/:eventId", eventHandler.GetEvent())
+	teamGroup.PATCH("/:teamId/events/:eventId", eventHandler.UpdateEvent())
+	teamGroup.DELETE("/:teamId/events/:eventId", eventHandler.DeleteEvent())
+	teamGroup.PUT("/:teamId/events/:eventId/records/:recordId", eventHandler.AttachRecord())
+	teamGroup.DELETE("/:teamId/events/:eventId/records/:recordId", eventHandler.DetachRecord())
+	teamGroup.GET("/:teamId/events/:eventId/standings", eventHandler.GetStandings())
+	teamGroup.POST("/:teamId/seasons", seasonHandler.CreateSeason())
+	teamGroup.GET("/:teamId/seasons", seasonHandler.GetSeasons())
+	teamGroup.PATCH("/:teamId/seasons/:seasonId", seasonHandler.UpdateSeason())
+	teamGroup.DELETE("/:teamId/seasons/:seasonId", seasonHandler.DeleteSeason())
+	teamGroup.GET("/:teamId/seasons/:seasonId/summary", seasonHandler.GetSeasonSummary())
+	teamGroup.POST("/:teamId/practices", practiceHandler.CreatePracticeSession())
+	teamGroup.GET("/:teamId/practices", practiceHandler.GetPracticeSessions())
+	teamGroup.GET("/:teamId/practices/:practiceId", practiceHandler.GetPracticeSession())
+	teamGroup.PATCH("/:teamId/practices/:practiceId", practiceHandler.UpdatePracticeSession())
+	teamGroup.DELETE("/:teamId/practices/:practiceId", practiceHandler.DeletePracticeSession())
+	teamGroup.GET("/:teamId/stats/practice", practiceHandler.GetPracticeStats())
+	teamGroup.GET("/:teamId/stats/players", practiceHandler.GetPlayerReports())
 
 	// レコード関連のエンドポイント
 	recordGroup := authGroup.Group("/records")
 	recordGroup.POST("/:teamId", recordHandler.CreateRecord())
 	recordGroup.PATCH("/:recordId/append", recordHandler.AppendEndData())
 	recordGroup.GET("/:recordId/details", recordHandler.GetRecordDetailsByRecordId())
+	recordGroup.GET("/:recordId/scoreboard", recordHandler.GetScoreboard())
+	recordGroup.GET("/:recordId/win-probability", statsHandler.GetWinProbabilityCurve())
+	recordGroup.GET("/:recordId/ends/:end/strip.svg", recordHandler.GetEndImage())
+	recordGroup.GET("/:recordId/ends/:end/shots/:shot/house.svg", recordHandler.GetShotImage())
 	recordGroup.GET("/:teamId", recordHandler.GetRecordsByTeamId())
 	recordGroup.PATCH("/:recordId", recordHandler.UpdateRecord())
 	recordGroup.DELETE("/:recordId", recordHandler.DeleteRecord())
-	recordGroup.PATCH("/:recordId/userId/visibility", recordHandler.SetVisibility())
+	recordGroup.PATCH("/:recordId/visibility", recordHandler.SetVisibility())
+	recordGroup.PATCH("/:recordId/userId/visibility", recordHandler.SetVisibility()) // former path, kept for existing clients
+	recordGroup.PUT("/:recordId/lineup", recordHandler.SetLineup())
+	recordGroup.POST("/:recordId/lineup/substitutions", recordHandler.AddSubstitution())
 
-	// デバッグ用
+	// デバッグ用: チームのハンドラは操作するユーザーを必要とするため、認証を通す
 	debug := e.Group("/debug")
+	debug.Use(middleware.JWTMiddleware)
 	debug.GET("/users", userHandler.GetAllUsers())
 	debug.POST("/teams", teamHandler.CreateTeam())
 	debug.GET("/teams", teamHandler.GetAllTeams())
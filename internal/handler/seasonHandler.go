@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/handler/render"
+	"CurlARC/internal/handler/request"
+	"CurlARC/internal/handler/response"
+	"CurlARC/internal/usecase"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SeasonHandler handles requests related to the seasons of a team.
+type SeasonHandler struct {
+	seasonUsecase usecase.SeasonUsecase
+}
+
+// NewSeasonHandler creates a new SeasonHandler instance.
+func NewSeasonHandler(seasonUsecase usecase.SeasonUsecase) SeasonHandler {
+	return SeasonHandler{seasonUsecase: seasonUsecase}
+}
+
+func newSeasonResponse(season *entity.Season) response.Season {
+	return response.Season{
+		Id:        season.GetId().Value(),
+		TeamId:    season.GetTeamId(),
+		Name:      season.GetName(),
+		StartDate: season.GetStartDate(),
+		EndDate:   season.GetEndDate(),
+	}
+}
+
+// CreateSeason godoc
+// @Summary Create a season
+// @Description Create a named period of the team's games. Both dates are days and are included in the season
+// @Tags Seasons
+// @Accept json
+// @Produce json
+// @Param teamId path string true "Team ID"
+// @Param request body request.CreateSeasonRequest true "Season"
+// @Success 201 {object} response.SuccessResponse{data=response.Season}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/seasons [post]
+func (h *SeasonHandler) CreateSeason() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		userId := c.Get("uid").(string)
+
+		var req request.CreateSeasonRequest
+		if err := c.Bind(&req); err != nil {
+			return badRequest("invalid request")
+		}
+
+		season, err := h.seasonUsecase.CreateSeason(userId, teamId, req.Name, req.StartDate, req.EndDate)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusCreated, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Season response.Season `json:"season"`
+			}{
+				Season: newSeasonResponse(season),
+			},
+		})
+	}
+}
+
+// GetSeasons godoc
+// @Summary Get the seasons of a team
+// @Description Get every season of the team, the most recent first
+// @Tags Seasons
+// @Produce json
+// @Param teamId path string true "Team ID"
+// @Success 200 {object} response.SuccessResponse{data=[]response.Season}
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/seasons [get]
+func (h *SeasonHandler) GetSeasons() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		userId := c.Get("uid").(string)
+
+		seasons, err := h.seasonUsecase.GetSeasonsByTeamId(userId, teamId)
+		if err != nil {
+			return err
+		}
+
+		res := make([]response.Season, 0, len(seasons))
+		for _, season := range seasons {
+			res = append(res, newSeasonResponse(season))
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Seasons []response.Season `json:"seasons"`
+			}{
+				Seasons: res,
+			},
+		})
+	}
+}
+
+// UpdateSeason godoc
+// @Summary Update a season
+// @Description Update the given fields of a season and keep the others
+// @Tags Seasons
+// @Accept json
+// @Produce json
+// @Param teamId path string true "Team ID"
+// @Param seasonId path string true "Season ID"
+// @Param request body request.UpdateSeasonRequest true "Fields to update"
+// @Success 200 {object} response.SuccessResponse{data=response.Season}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/seasons/{seasonId} [patch]
+func (h *SeasonHandler) UpdateSeason() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		seasonId := c.Param("seasonId")
+		userId := c.Get("uid").(string)
+
+		var req request.UpdateSeasonRequest
+		if err := c.Bind(&req); err != nil {
+			return badRequest("invalid request")
+		}
+
+		season, err := h.seasonUsecase.UpdateSeason(userId, teamId, seasonId, req.Name, req.StartDate, req.EndDate)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Season response.Season `json:"season"`
+			}{
+				Season: newSeasonResponse(season),
+			},
+		})
+	}
+}
+
+// DeleteSeason godoc
+// @Summary Delete a season
+// @Description Delete a season. The records played in it are kept
+// @Tags Seasons
+// @Param teamId path string true "Team ID"
+// @Param seasonId path string true "Season ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/seasons/{seasonId} [delete]
+func (h *SeasonHandler) DeleteSeason() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		seasonId := c.Param("seasonId")
+		userId := c.Get("uid").(string)
+
+		if err := h.seasonUsecase.DeleteSeason(userId, teamId, seasonId); err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data:   nil,
+		})
+	}
+}
+
+// GetSeasonSummary godoc
+// @Summary Get the summary of a season
+// @Description Get the results, streaks, top shooters, hammer efficiency, opponents and venues of a season. Returns a printable HTML report with format=html
+// @Tags Seasons
+// @Produce json
+// @Produce html
+// @Param teamId path string true "Team ID"
+// @Param seasonId path string true "Season ID"
+// @Param format query string false "json (default) or html"
+// @Success 200 {object} response.SuccessResponse{data=response.SeasonSummary}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/seasons/{seasonId}/summary [get]
+func (h *SeasonHandler) GetSeasonSummary() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		seasonId := c.Param("seasonId")
+		userId := c.Get("uid").(string)
+
+		format := c.QueryParam("format")
+		if format != "" && format != "json" && format != "html" {
+			return badRequest("format must be json or html")
+		}
+
+		summary, err := h.seasonUsecase.GetSeasonSummary(userId, teamId, seasonId)
+		if err != nil {
+			return err
+		}
+
+		res := response.SeasonSummary{
+			Season:            newSeasonResponse(summary.Season),
+			TeamName:          summary.TeamName,
+			Games:             summary.Games,
+			Wins:              summary.Wins,
+			Losses:            summary.Losses,
+			Draws:             summary.Draws,
+			Abandoned:         summary.Abandoned,
+			PointsFor:         summary.PointsFor,
+			PointsAgainst:     summary.PointsAgainst,
+			LongestWinStreak:  summary.LongestWinStreak,
+			LongestLossStreak: summary.LongestLossStreak,
+			CurrentStreak:     response.Streak{Result: summary.CurrentStreak.Result, Games: summary.CurrentStreak.Games},
+			TopShooters:       make([]response.ShooterStats, 0, len(summary.TopShooters)),
+			Hammer:            toHammerStatsResponse(summary.Hammer),
+			Opponents:         make([]response.HeadToHead, 0, len(summary.Opponents)),
+			Venues:            make([]response.VenueSummary, 0, len(summary.Venues)),
+		}
+		for _, shooter := range summary.TopShooters {
+			res.TopShooters = append(res.TopShooters, toShooterStatsResponse(shooter))
+		}
+		for _, history := range summary.Opponents {
+			res.Opponents = append(res.Opponents, toHeadToHeadResponse(history))
+		}
+		for _, venue := range summary.Venues {
+			res.Venues = append(res.Venues, response.VenueSummary{
+				Place:  venue.Place,
+				Games:  venue.Games,
+				Wins:   venue.Wins,
+				Losses: venue.Losses,
+				Draws:  venue.Draws,
+			})
+		}
+
+		if format == "html" {
+			report, err := render.SeasonReport(res)
+			if err != nil {
+				return err
+			}
+			return c.HTMLBlob(http.StatusOK, report)
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Summary response.SeasonSummary `json:"summary"`
+			}{
+				Summary: res,
+			},
+		})
+	}
+}
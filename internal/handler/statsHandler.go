@@ -0,0 +1,615 @@
+package handler
+
+import (
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/geometry"
+	"CurlARC/internal/handler/render"
+	"CurlARC/internal/handler/request"
+	"CurlARC/internal/handler/response"
+	"CurlARC/internal/usecase"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// StatsHandler handles requests related to team statistics.
+type StatsHandler struct {
+	statsUsecase usecase.StatsUsecase
+}
+
+// NewStatsHandler creates a new StatsHandler instance.
+func NewStatsHandler(statsUsecase usecase.StatsUsecase) StatsHandler {
+	return StatsHandler{statsUsecase: statsUsecase}
+}
+
+// parseStatsFilter converts the query parameters into a filter.
+// A date-only "to" covers the whole day.
+func parseStatsFilter(req request.StatsFilterRequest) (usecase.StatsFilter, error) {
+	filter := usecase.StatsFilter{Opponent: req.Opponent}
+
+	if req.From != "" {
+		from, _, err := parseStatsDate(req.From)
+		if err != nil {
+			return filter, err
+		}
+		filter.From = from
+	}
+	if req.To != "" {
+		to, dateOnly, err := parseStatsDate(req.To)
+		if err != nil {
+			return filter, err
+		}
+		if dateOnly {
+			to = to.Add(24*time.Hour - time.Nanosecond)
+		}
+		filter.To = to
+	}
+
+	return filter, nil
+}
+
+func parseStatsDate(value string) (time.Time, bool, error) {
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return date, true, nil
+	}
+	date, err := time.Parse(time.RFC3339, value)
+	return date, false, err
+}
+
+// GetShooterStats godoc
+// @Summary Get shot success statistics per shooter
+// @Description Get each shooter's average success rate overall, by shot type, by end and by hammer
+// @Tags Stats
+// @Produce json
+// @Param teamId path string true "Team ID"
+// @Param from query string false "Start date (YYYY-MM-DD or RFC3339)"
+// @Param to query string false "End date (YYYY-MM-DD or RFC3339)"
+// @Param opponent query string false "Opponent team name"
+// @Success 200 {object} response.SuccessResponse{data=[]response.ShooterStats}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/stats/shooters [get]
+func (h *StatsHandler) GetShooterStats() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		userId := c.Get("uid").(string)
+
+		var req request.StatsFilterRequest
+		if err := c.Bind(&req); err != nil {
+			return badRequest("invalid request")
+		}
+		filter, err := parseStatsFilter(req)
+		if err != nil {
+			return badRequest("invalid date: " + err.Error())
+		}
+
+		stats, err := h.statsUsecase.GetShooterStats(userId, teamId, filter)
+		if err != nil {
+			return err
+		}
+
+		res := make([]response.ShooterStats, 0, len(stats))
+		for _, s := range stats {
+			res = append(res, toShooterStatsResponse(s))
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Shooters []response.ShooterStats `json:"shooters"`
+			}{
+				Shooters: res,
+			},
+		})
+	}
+}
+
+func toShooterStatsResponse(s usecase.ShooterStats) response.ShooterStats {
+	shooter := response.ShooterStats{
+		Shooter:       s.Shooter,
+		Overall:       toSuccessRateResponse(s.Overall),
+		ByShotType:    make([]response.ShotTypeSuccessRate, 0, len(s.ByShotType)),
+		ByEnd:         make([]response.EndSuccessRate, 0, len(s.ByEnd)),
+		WithHammer:    toSuccessRateResponse(s.WithHammer),
+		WithoutHammer: toSuccessRateResponse(s.WithoutHammer),
+	}
+	if s.Player != nil {
+		shooter.UserId = s.Player.UserId
+		shooter.GuestName = s.Player.GuestName
+	}
+	for shotType, rate := range s.ByShotType {
+		shooter.ByShotType = append(shooter.ByShotType, response.ShotTypeSuccessRate{
+			Type:        shotType,
+			SuccessRate: toSuccessRateResponse(rate),
+		})
+	}
+	sort.Slice(shooter.ByShotType, func(i, j int) bool { return shooter.ByShotType[i].Type < shooter.ByShotType[j].Type })
+	for end, rate := range s.ByEnd {
+		shooter.ByEnd = append(shooter.ByEnd, response.EndSuccessRate{
+			End:         end,
+			SuccessRate: toSuccessRateResponse(rate),
+		})
+	}
+	sort.Slice(shooter.ByEnd, func(i, j int) bool { return shooter.ByEnd[i].End < shooter.ByEnd[j].End })
+	return shooter
+}
+
+func toSuccessRateResponse(rate usecase.SuccessRate) response.SuccessRate {
+	return response.SuccessRate{
+		Shots:   rate.Shots,
+		Average: rate.Average,
+	}
+}
+
+// GetHammerStats godoc
+// @Summary Get hammer efficiency and steal defense statistics
+// @Description Get hammer conversion, force and steal rates, split by score situation and by game phase
+// @Tags Stats
+// @Produce json
+// @Param teamId path string true "Team ID"
+// @Param from query string false "Start date (YYYY-MM-DD or RFC3339)"
+// @Param to query string false "End date (YYYY-MM-DD or RFC3339)"
+// @Param opponent query string false "Opponent team name"
+// @Success 200 {object} response.SuccessResponse{data=response.HammerStats}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/stats/hammer [get]
+func (h *StatsHandler) GetHammerStats() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		userId := c.Get("uid").(string)
+
+		var req request.StatsFilterRequest
+		if err := c.Bind(&req); err != nil {
+			return badRequest("invalid request")
+		}
+		filter, err := parseStatsFilter(req)
+		if err != nil {
+			return badRequest("invalid date: " + err.Error())
+		}
+
+		stats, err := h.statsUsecase.GetHammerStats(userId, teamId, filter)
+		if err != nil {
+			return err
+		}
+
+		res := toHammerStatsResponse(*stats)
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Hammer response.HammerStats `json:"hammer"`
+			}{
+				Hammer: res,
+			},
+		})
+	}
+}
+
+// GetHeadToHead godoc
+// @Summary Get head-to-head history against opponents
+// @Description Get W/L/D counts, average points, hammer stats and matches per opponent. Opponent names are compared ignoring case and whitespace. Abandoned games are not counted in the results
+// @Tags Stats
+// @Produce json
+// @Param teamId path string true "Team ID"
+// @Param from query string false "Start date (YYYY-MM-DD or RFC3339)"
+// @Param to query string false "End date (YYYY-MM-DD or RFC3339)"
+// @Param opponent query string false "Opponent team name"
+// @Success 200 {object} response.SuccessResponse{data=[]response.HeadToHead}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/stats/head-to-head [get]
+func (h *StatsHandler) GetHeadToHead() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		userId := c.Get("uid").(string)
+
+		var req request.StatsFilterRequest
+		if err := c.Bind(&req); err != nil {
+			return badRequest("invalid request")
+		}
+		filter, err := parseStatsFilter(req)
+		if err != nil {
+			return badRequest("invalid date: " + err.Error())
+		}
+
+		histories, err := h.statsUsecase.GetHeadToHead(userId, teamId, filter)
+		if err != nil {
+			return err
+		}
+
+		res := make([]response.HeadToHead, 0, len(histories))
+		for _, history := range histories {
+			res = append(res, toHeadToHeadResponse(history))
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Opponents []response.HeadToHead `json:"opponents"`
+			}{
+				Opponents: res,
+			},
+		})
+	}
+}
+
+// parseHeatmapQuery converts the query parameters into the shots and the grid of a heatmap.
+// The grid defaults to rings a foot wide split into eight sectors, or to cells half a metre wide.
+func parseHeatmapQuery(c echo.Context, req request.HeatmapRequest) (usecase.HeatmapQuery, error) {
+	var query usecase.HeatmapQuery
+	switch req.Grid {
+	case "", "polar":
+		query.Grid = geometry.DefaultPolarGrid
+	case "cartesian":
+		query.Grid = geometry.DefaultCartesianGrid
+	default:
+		return query, fmt.Errorf("grid must be polar or cartesian, got %q", req.Grid)
+	}
+	if req.CellSize != 0 {
+		query.Grid.CellSize = req.CellSize
+	}
+	if req.Sectors != 0 && query.Grid.Kind == geometry.GridPolar {
+		query.Grid.Sectors = req.Sectors
+	}
+	sheet, err := parseSheet(c)
+	if err != nil {
+		return query, err
+	}
+	query.Grid.Sheet = sheet
+	if err := query.Grid.Validate(); err != nil {
+		return query, err
+	}
+
+	query.Shooter = req.Shooter
+	query.End = req.End
+	if req.ShotType != "" {
+		query.ShotType = entity.ShotType(req.ShotType)
+		if !query.ShotType.IsValid() {
+			return query, fmt.Errorf("unknown shot type %q", req.ShotType)
+		}
+	}
+	if req.Hammer != "" {
+		hammer, err := strconv.ParseBool(req.Hammer)
+		if err != nil {
+			return query, errors.New("hammer must be true or false")
+		}
+		query.Hammer = &hammer
+	}
+	return query, nil
+}
+
+// GetHeatmap godoc
+// @Summary Get a heatmap of where our stones came to rest
+// @Description Bin the position each of our stones came to rest at right after it was delivered, with the number of shots and their average success rate per cell. Returns an SVG overlay on the house with format=svg
+// @Tags Stats
+// @Produce json
+// @Produce image/svg+xml
+// @Param teamId path string true "Team ID"
+// @Param from query string false "Start date (YYYY-MM-DD or RFC3339)"
+// @Param to query string false "End date (YYYY-MM-DD or RFC3339)"
+// @Param opponent query string false "Opponent team name"
+// @Param shooter query string false "Shooter as recorded, or user ID or guest name of a lineup player"
+// @Param shot_type query string false "Shot type"
+// @Param end query int false "End number"
+// @Param hammer query bool false "Only ends played with (true) or without (false) hammer"
+// @Param grid query string false "polar (default) or cartesian"
+// @Param cell_size query number false "Ring width of a polar grid or cell side of a cartesian one, in metres"
+// @Param sectors query int false "Number of sectors of a polar grid"
+// @Param format query string false "json (default) or svg"
+// @Success 200 {object} response.SuccessResponse{data=response.Heatmap}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/stats/heatmap [get]
+func (h *StatsHandler) GetHeatmap() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		userId := c.Get("uid").(string)
+
+		var req request.HeatmapRequest
+		if err := c.Bind(&req); err != nil {
+			return badRequest("invalid request")
+		}
+		filter, err := parseStatsFilter(req.StatsFilterRequest)
+		if err != nil {
+			return badRequest("invalid date: " + err.Error())
+		}
+		query, err := parseHeatmapQuery(c, req)
+		if err == nil && req.Format != "" && req.Format != "json" && req.Format != "svg" {
+			err = fmt.Errorf("format must be json or svg, got %q", req.Format)
+		}
+		if err != nil {
+			return badRequest(err.Error())
+		}
+
+		heatmap, err := h.statsUsecase.GetHeatmap(userId, teamId, filter, query)
+		if err != nil {
+			return err
+		}
+
+		if req.Format == "svg" {
+			cells := make([]render.HeatmapCell, 0, len(heatmap.Cells))
+			for _, cell := range heatmap.Cells {
+				cells = append(cells, render.HeatmapCell{Cell: cell.Cell, Count: cell.Shots, Average: cell.Average})
+			}
+			return c.Blob(http.StatusOK, "image/svg+xml", render.Heatmap(heatmap.Grid, cells))
+		}
+
+		res := response.Heatmap{
+			Grid:      heatmap.Grid,
+			Records:   heatmap.Records,
+			Shots:     heatmap.Shots,
+			OutOfPlay: heatmap.OutOfPlay,
+			Cells:     make([]response.HeatmapCell, 0, len(heatmap.Cells)),
+		}
+		for _, cell := range heatmap.Cells {
+			resCell := response.HeatmapCell{Row: cell.Row, Column: cell.Column, SuccessRate: toSuccessRateResponse(cell.SuccessRate)}
+			if heatmap.Grid.Kind == geometry.GridPolar {
+				resCell.Polar = &response.PolarCellBounds{
+					MinR:     cell.Bounds.MinR,
+					MaxR:     cell.Bounds.MaxR,
+					MinTheta: cell.Bounds.MinTheta,
+					MaxTheta: cell.Bounds.MaxTheta,
+				}
+			} else {
+				resCell.Cartesian = &response.CartesianCellBounds{
+					MinX: cell.Bounds.Min.X,
+					MaxX: cell.Bounds.Max.X,
+					MinY: cell.Bounds.Min.Y,
+					MaxY: cell.Bounds.Max.Y,
+				}
+			}
+			res.Cells = append(res.Cells, resCell)
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Heatmap response.Heatmap `json:"heatmap"`
+			}{
+				Heatmap: res,
+			},
+		})
+	}
+}
+
+// GetWinProbability godoc
+// @Summary Estimate the win probability of a game state
+// @Description Estimate the chance of winning before an end from the team's records and every public record. States seen in few games are smoothed towards a prior
+// @Tags Stats
+// @Produce json
+// @Param teamId path string true "Team ID"
+// @Param end query int true "End about to be played"
+// @Param score_difference query int false "Our score minus theirs before the end"
+// @Param hammer query bool false "Whether we have hammer"
+// @Param game_format query string false "Game format"
+// @Success 200 {object} response.SuccessResponse{data=response.WinProbability}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/stats/win-probability [get]
+func (h *StatsHandler) GetWinProbability() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		userId := c.Get("uid").(string)
+
+		var req request.WinProbabilityRequest
+		if err := c.Bind(&req); err != nil {
+			return badRequest("invalid request")
+		}
+		format := entity.GameFormat(req.GameFormat)
+		if format == "" {
+			format = entity.DefaultGameFormat
+		}
+		if !format.IsValid() || req.End < 1 {
+			return badRequest("end must be at least 1 and game_format a known format")
+		}
+
+		estimate, err := h.statsUsecase.GetWinProbability(userId, teamId, format, req.End, req.ScoreDifference, req.Hammer)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				WinProbability response.WinProbability `json:"win_probability"`
+			}{
+				WinProbability: toWinProbabilityResponse(*estimate),
+			},
+		})
+	}
+}
+
+// GetWinProbabilityCurve godoc
+// @Summary Get the win probability before every end of a record
+// @Description Follow the estimated chance of winning through a finished game, with how much each end changed it. The record itself is not used for the estimates
+// @Tags Stats
+// @Produce json
+// @Param recordId path string true "Record ID"
+// @Success 200 {object} response.SuccessResponse{data=response.WinProbabilityCurve}
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/records/{recordId}/win-probability [get]
+func (h *StatsHandler) GetWinProbabilityCurve() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		recordId := c.Param("recordId")
+		userId := c.Get("uid").(string)
+
+		curve, err := h.statsUsecase.GetWinProbabilityCurve(userId, recordId)
+		if err != nil {
+			return err
+		}
+
+		res := response.WinProbabilityCurve{
+			RecordId: curve.RecordId,
+			Result:   curve.Result,
+			Points:   make([]response.WinProbabilityPoint, 0, len(curve.Points)),
+			Final:    curve.Final,
+		}
+		for _, point := range curve.Points {
+			res.Points = append(res.Points, response.WinProbabilityPoint{
+				End:            point.End,
+				FriendTotal:    point.FriendTotal,
+				EnemyTotal:     point.EnemyTotal,
+				FriendHammer:   point.FriendHammer,
+				WinProbability: toWinProbabilityResponse(point.WinProbability),
+				Swing:          point.Swing,
+			})
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Curve response.WinProbabilityCurve `json:"curve"`
+			}{
+				Curve: res,
+			},
+		})
+	}
+}
+
+func toWinProbabilityResponse(estimate usecase.WinProbability) response.WinProbability {
+	return response.WinProbability{
+		Probability: estimate.Probability,
+		Games:       estimate.Games,
+		Wins:        estimate.Wins,
+		Prior:       estimate.Prior,
+	}
+}
+
+// FindSimilarPositions godoc
+// @Summary Find past positions similar to a given one
+// @Description Rank the positions stored in the team's records, and optionally in public records, by their distance to the given stones. Each hit comes with the shot played next and the score of the end
+// @Tags Stats
+// @Accept json
+// @Produce json
+// @Param teamId path string true "Team ID"
+// @Param request body request.SimilarPositionRequest true "Position to look for"
+// @Success 200 {object} response.SuccessResponse{data=response.SimilarPositions}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/positions/search [post]
+func (h *StatsHandler) FindSimilarPositions() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		userId := c.Get("uid").(string)
+
+		var req request.SimilarPositionRequest
+		if err := c.Bind(&req); err != nil {
+			return badRequest("invalid request")
+		}
+		if req.Limit < 0 || req.Limit > usecase.MaxSimilarPositions {
+			return badRequest(fmt.Sprintf("limit must be between 1 and %d", usecase.MaxSimilarPositions))
+		}
+
+		positions, err := h.statsUsecase.FindSimilarPositions(userId, teamId, usecase.PositionQuery{
+			Stones:        req.Stones,
+			Hammer:        req.Hammer,
+			IncludePublic: req.IncludePublic,
+			Limit:         req.Limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		res := make([]response.SimilarPosition, 0, len(positions))
+		for _, position := range positions {
+			res = append(res, response.SimilarPosition{
+				RecordId:      position.RecordId,
+				TeamId:        position.TeamId,
+				EnemyTeamName: position.EnemyTeamName,
+				Date:          position.Date,
+				End:           position.End,
+				Shot:          position.Shot,
+				FriendHammer:  position.FriendHammer,
+				Distance:      position.Distance,
+				Stones:        position.Stones,
+				NextShot:      position.NextShot,
+				EndScore:      position.EndScore,
+			})
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data:   response.SimilarPositions{Positions: res},
+		})
+	}
+}
+
+func toHammerStatsResponse(stats usecase.HammerStats) response.HammerStats {
+	res := response.HammerStats{
+		Records:     stats.Records,
+		Overall:     toHammerSplitResponse(stats.Overall),
+		BySituation: make(map[string]response.HammerSplit, len(stats.BySituation)),
+		ByPhase:     make(map[string]response.HammerSplit, len(stats.ByPhase)),
+	}
+	for situation, split := range stats.BySituation {
+		res.BySituation[string(situation)] = toHammerSplitResponse(split)
+	}
+	for phase, split := range stats.ByPhase {
+		res.ByPhase[string(phase)] = toHammerSplitResponse(split)
+	}
+	return res
+}
+
+func toHeadToHeadResponse(history usecase.HeadToHead) response.HeadToHead {
+	matches := make([]response.HeadToHeadMatch, 0, len(history.Matches))
+	for _, match := range history.Matches {
+		matches = append(matches, response.HeadToHeadMatch{
+			RecordId:      match.RecordId,
+			Date:          match.Date,
+			Place:         match.Place,
+			Result:        match.Result,
+			Status:        match.Status,
+			EndsPlayed:    match.EndsPlayed,
+			PointsFor:     match.PointsFor,
+			PointsAgainst: match.PointsAgainst,
+		})
+	}
+	return response.HeadToHead{
+		Opponent:                   history.Opponent,
+		Wins:                       history.Wins,
+		Losses:                     history.Losses,
+		Draws:                      history.Draws,
+		Abandoned:                  history.Abandoned,
+		AveragePointsFor:           history.AveragePointsFor,
+		AveragePointsAgainst:       history.AveragePointsAgainst,
+		AveragePointsForPerEnd:     history.AveragePointsForPerEnd,
+		AveragePointsAgainstPerEnd: history.AveragePointsAgainstPerEnd,
+		Hammer:                     toHammerSplitResponse(history.Hammer),
+		Matches:                    matches,
+	}
+}
+
+func toHammerSplitResponse(split usecase.HammerSplit) response.HammerSplit {
+	h := split.WithHammer
+	d := split.WithoutHammer
+	return response.HammerSplit{
+		WithHammer: response.HammerEfficiency{
+			Ends:           h.Ends,
+			TwoOrMore:      h.TwoOrMore,
+			ForcedToOne:    h.ForcedToOne,
+			Blanks:         h.Blanks,
+			StolenAgainst:  h.StolenAgainst,
+			ConversionRate: h.ConversionRate,
+			ForcedRate:     h.ForcedRate,
+			BlankRate:      h.BlankRate,
+			StolenRate:     h.StolenRate,
+		},
+		WithoutHammer: response.StealDefense{
+			Ends:                 d.Ends,
+			Steals:               d.Steals,
+			ForcedOpponentToOne:  d.ForcedOpponentToOne,
+			AllowedTwoOrMore:     d.AllowedTwoOrMore,
+			Blanks:               d.Blanks,
+			StealRate:            d.StealRate,
+			ForceRate:            d.ForceRate,
+			AllowedTwoOrMoreRate: d.AllowedTwoOrMoreRate,
+			BlankRate:            d.BlankRate,
+		},
+	}
+}
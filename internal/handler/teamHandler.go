@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"CurlARC/internal/domain/entity"
 	"CurlARC/internal/handler/request"
 	"CurlARC/internal/handler/response"
 	"CurlARC/internal/usecase"
@@ -19,6 +20,35 @@ func NewTeamHandler(teamUsecase usecase.TeamUsecase) TeamHandler {
 	return TeamHandler{teamUsecase: teamUsecase}
 }
 
+func newUserResponse(user *entity.User) response.User {
+	return response.User{
+		Id:    user.GetId().Value(),
+		Name:  user.GetName(),
+		Email: user.GetEmail(),
+	}
+}
+
+func newInvitationsResponse(invitations []usecase.Invitation) []response.Invitation {
+	responseInvitations := make([]response.Invitation, 0, len(invitations))
+	for _, invitation := range invitations {
+		responseInvitation := response.Invitation{
+			Team: response.Team{
+				Id:   invitation.Team.GetId().Value(),
+				Name: invitation.Team.GetName(),
+			},
+			User:      newUserResponse(invitation.User),
+			InvitedAt: invitation.InvitedAt,
+			ExpiresAt: invitation.ExpiresAt,
+		}
+		if invitation.InvitedBy != nil {
+			inviter := newUserResponse(invitation.InvitedBy)
+			responseInvitation.InvitedBy = &inviter
+		}
+		responseInvitations = append(responseInvitations, responseInvitation)
+	}
+	return responseInvitations
+}
+
 // CreateTeam creates a new team.
 // @Summary Create a new team
 // @Description Creates a new team with the specified name
@@ -36,24 +66,12 @@ func (h *TeamHandler) CreateTeam() echo.HandlerFunc {
 		userId := c.Get("uid").(string)
 		var req request.CreateTeamRequest
 		if err := c.Bind(&req); err != nil {
-			return c.JSON(http.StatusBadRequest, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusBadRequest,
-					Message: "invalid request",
-				},
-			})
+			return badRequest("invalid request")
 		}
 
 		createdTeam, err := h.teamUsecase.CreateTeam(req.Name, userId)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
+			return err
 		}
 
 		responseTeam := response.Team{
@@ -86,13 +104,7 @@ func (h *TeamHandler) GetTeamsByUserId() echo.HandlerFunc {
 
 		teams, err := h.teamUsecase.GetTeamsByUserId(userId)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
+			return err
 		}
 
 		responseTeams := make([]response.Team, 0, len(teams))
@@ -116,41 +128,27 @@ func (h *TeamHandler) GetTeamsByUserId() echo.HandlerFunc {
 
 // GetInvitedTeams retrieves all teams that a user has been invited to.
 // @Summary Get all invited teams
-// @Description Retrieves a list of all teams that a user has been invited to
+// @Description Retrieves the invitations of the user which have not expired, with who sent them and until when they may be accepted
 // @Tags Teams
 // @Produce json
-// @Success 200 {object} response.SuccessResponse{data=[]response.Team}
+// @Success 200 {object} response.SuccessResponse{data=[]response.Invitation}
 // @Failure 500 {object} response.ErrorResponse
 // @Router /auth/users/me/teams/invited [get]
 func (h *TeamHandler) GetInvitedTeams() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		userId := c.Get("uid").(string)
 
-		teams, err := h.teamUsecase.GetInvitedTeams(userId)
+		invitations, err := h.teamUsecase.GetInvitedTeams(userId)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
-		}
-
-		responseTeams := make([]response.Team, 0, len(teams))
-		for _, team := range teams {
-			responseTeams = append(responseTeams, response.Team{
-				Id:   team.GetId().Value(),
-				Name: team.GetName(),
-			})
+			return err
 		}
 
 		return c.JSON(http.StatusOK, response.SuccessResponse{
 			Status: "success",
 			Data: struct {
-				Teams []response.Team `json:"teams"`
+				Invitations []response.Invitation `json:"invitations"`
 			}{
-				Teams: responseTeams,
+				Invitations: newInvitationsResponse(invitations),
 			},
 		})
 	}
@@ -168,13 +166,7 @@ func (h *TeamHandler) GetAllTeams() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		teams, err := h.teamUsecase.GetAllTeams()
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
+			return err
 		}
 
 		responseTeams := make([]response.Team, 0, len(teams))
@@ -211,26 +203,15 @@ func (h *TeamHandler) GetAllTeams() echo.HandlerFunc {
 func (h *TeamHandler) UpdateTeam() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		teamId := c.Param("teamId")
+		userId := c.Get("uid").(string)
 		var req request.UpdateTeamRequest
 		if err := c.Bind(&req); err != nil {
-			return c.JSON(http.StatusBadRequest, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusBadRequest,
-					Message: "invalid request",
-				},
-			})
+			return badRequest("invalid request")
 		}
 
-		updatedTeam, err := h.teamUsecase.UpdateTeam(teamId, req.Name)
+		updatedTeam, err := h.teamUsecase.UpdateTeam(teamId, userId, req.Name)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
+			return err
 		}
 
 		responseTeam := response.Team{
@@ -260,15 +241,10 @@ func (h *TeamHandler) UpdateTeam() echo.HandlerFunc {
 func (h *TeamHandler) DeleteTeam() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		teamId := c.Param("teamId")
-		err := h.teamUsecase.DeleteTeam(teamId)
+		userId := c.Get("uid").(string)
+		err := h.teamUsecase.DeleteTeam(teamId, userId)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
+			return err
 		}
 
 		return c.JSON(http.StatusOK, response.SuccessResponse{
@@ -294,24 +270,12 @@ func (h *TeamHandler) InviteUsers() echo.HandlerFunc {
 
 		var req request.InviteUsersRequest
 		if err := c.Bind(&req); err != nil {
-			return c.JSON(http.StatusBadRequest, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusBadRequest,
-					Message: "invalid request",
-				},
-			})
+			return badRequest("invalid request")
 		}
 
 		err := h.teamUsecase.InviteUsers(teamId, userId, req.TargetUserEmails)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
+			return err
 		}
 
 		return c.JSON(http.StatusCreated, response.SuccessResponse{
@@ -337,13 +301,60 @@ func (h *TeamHandler) AcceptInvitation() echo.HandlerFunc {
 
 		err := h.teamUsecase.AcceptInvitation(teamId, userId)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
+			return err
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data:   nil,
+		})
+	}
+}
+
+// DeclineInvitation declines an invitation to join a team.
+// @Summary Decline a team invitation
+// @Description Declines an invitation to join a specific team. The team may invite the user again later
+// @Tags Teams
+// @Param teamId path string true "Team ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/decline [post]
+func (h *TeamHandler) DeclineInvitation() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		userId := c.Get("uid").(string)
+
+		if err := h.teamUsecase.DeclineInvitation(teamId, userId); err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data:   nil,
+		})
+	}
+}
+
+// RevokeInvitation withdraws the invitation of a user to a team.
+// @Summary Revoke a team invitation
+// @Description Withdraws the invitation of a user to a specific team. Only admins may do it
+// @Tags Teams
+// @Param teamId path string true "Team ID"
+// @Param userId path string true "ID of the invited user"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/invitations/{userId} [delete]
+func (h *TeamHandler) RevokeInvitation() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		userId := c.Get("uid").(string)
+		inviteeId := c.Param("userId")
+
+		if err := h.teamUsecase.RevokeInvitation(teamId, userId, inviteeId); err != nil {
+			return err
 		}
 
 		return c.JSON(http.StatusOK, response.SuccessResponse{
@@ -365,20 +376,121 @@ func (h *TeamHandler) AcceptInvitation() echo.HandlerFunc {
 func (h *TeamHandler) RemoveMember() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		teamId := c.Param("teamId")
-		userId := c.Param("userId")
+		userId := c.Get("uid").(string)
+		memberId := c.Param("userId")
 
-		err := h.teamUsecase.RemoveMember(teamId, userId)
+		err := h.teamUsecase.RemoveMember(teamId, userId, memberId)
 
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
+			return err
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data:   nil,
+		})
+	}
+}
+
+// GetRoles retrieves the role of every member of a team.
+// @Summary Get the roles of the members of a team
+// @Description Retrieves the role of every member of a specific team: OWNER, ADMIN, MEMBER or VIEWER
+// @Tags Teams
+// @Param teamId path string true "Team ID"
+// @Produce json
+// @Success 200 {object} response.SuccessResponse{data=[]response.MemberRole}
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/roles [get]
+func (h *TeamHandler) GetRoles() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		userId := c.Get("uid").(string)
+
+		memberships, err := h.teamUsecase.GetRolesByTeamId(teamId, userId)
+		if err != nil {
+			return err
+		}
+
+		roles := make([]response.MemberRole, 0, len(memberships))
+		for _, membership := range memberships {
+			roles = append(roles, response.MemberRole{
+				UserId: membership.GetUserId().Value(),
+				Role:   string(membership.GetRole()),
 			})
 		}
 
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data: struct {
+				Roles []response.MemberRole `json:"roles"`
+			}{
+				Roles: roles,
+			},
+		})
+	}
+}
+
+// SetRole changes the role of a member of a team.
+// @Summary Set the role of a team member
+// @Description Gives a member of the team the ADMIN, MEMBER or VIEWER role. Only admins may do it, and the owner's role only changes by transferring the team
+// @Tags Teams
+// @Accept json
+// @Param teamId path string true "Team ID"
+// @Param userId path string true "User ID"
+// @Param role body request.SetRoleRequest true "New role"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/members/{userId}/role [put]
+func (h *TeamHandler) SetRole() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		userId := c.Get("uid").(string)
+		memberId := c.Param("userId")
+		var req request.SetRoleRequest
+		if err := c.Bind(&req); err != nil {
+			return badRequest("invalid request")
+		}
+
+		if err := h.teamUsecase.SetRole(teamId, userId, memberId, entity.TeamRole(req.Role)); err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, response.SuccessResponse{
+			Status: "success",
+			Data:   nil,
+		})
+	}
+}
+
+// TransferOwnership hands a team over to another member.
+// @Summary Transfer the ownership of a team
+// @Description Makes another member the owner of the team. Only the owner may do it, and stays on as an admin
+// @Tags Teams
+// @Accept json
+// @Param teamId path string true "Team ID"
+// @Param owner body request.TransferOwnershipRequest true "New owner"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Router /auth/teams/{teamId}/transfer [post]
+func (h *TeamHandler) TransferOwnership() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		teamId := c.Param("teamId")
+		userId := c.Get("uid").(string)
+		var req request.TransferOwnershipRequest
+		if err := c.Bind(&req); err != nil {
+			return badRequest("invalid request")
+		}
+
+		if err := h.teamUsecase.TransferOwnership(teamId, userId, req.UserId); err != nil {
+			return err
+		}
+
 		return c.JSON(http.StatusOK, response.SuccessResponse{
 			Status: "success",
 			Data:   nil,
@@ -398,16 +510,11 @@ func (h *TeamHandler) RemoveMember() echo.HandlerFunc {
 func (h *TeamHandler) GetMembers() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		teamID := c.Param("teamId")
+		userId := c.Get("uid").(string)
 
-		users, err := h.teamUsecase.GetMembersByTeamId(teamID)
+		users, err := h.teamUsecase.GetMembersByTeamId(teamID, userId)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
+			return err
 		}
 
 		responseUsers := make([]response.User, 0, len(users))
@@ -432,43 +539,29 @@ func (h *TeamHandler) GetMembers() echo.HandlerFunc {
 
 // GetInvitedUsers retrieves all users who have been invited to a team.
 // @Summary Get all invited users
-// @Description Retrieves a list of all users who have been invited to a specific team
+// @Description Retrieves the invitations of the team which have not expired, with who sent them and until when they may be accepted
 // @Tags Teams
 // @Param teamId path string true "Team ID"
 // @Produce json
-// @Success 200 {object} response.SuccessResponse{data=[]entity.User}
+// @Success 200 {object} response.SuccessResponse{data=[]response.Invitation}
 // @Failure 500 {object} response.ErrorResponse
 // @Router /auth/teams/{teamId}/invited [get]
 func (h *TeamHandler) GetInvitedUsers() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		teamID := c.Param("teamId")
+		userId := c.Get("uid").(string)
 
-		users, err := h.teamUsecase.GetInvitedUsersByTeamId(teamID)
+		invitations, err := h.teamUsecase.GetInvitedUsersByTeamId(teamID, userId)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
-		}
-
-		responseUsers := make([]response.User, 0, len(users))
-		for _, user := range users {
-			responseUsers = append(responseUsers, response.User{
-				Id:    user.GetId().Value(),
-				Name:  user.GetName(),
-				Email: user.GetEmail(),
-			})
+			return err
 		}
 
 		return c.JSON(http.StatusOK, response.SuccessResponse{
 			Status: "success",
 			Data: struct {
-				Users []response.User `json:"users"`
+				Invitations []response.Invitation `json:"invitations"`
 			}{
-				Users: responseUsers,
+				Invitations: newInvitationsResponse(invitations),
 			},
 		})
 	}
@@ -486,16 +579,11 @@ func (h *TeamHandler) GetInvitedUsers() echo.HandlerFunc {
 func (h *TeamHandler) GetTeamDetails() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		teamId := c.Param("teamId")
+		userId := c.Get("uid").(string)
 
-		team, err := h.teamUsecase.GetDetailsByTeamId(teamId)
+		team, err := h.teamUsecase.GetDetailsByTeamId(teamId, userId)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
+			return err
 		}
 
 		responseTeam := response.Team{
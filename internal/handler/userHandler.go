@@ -35,24 +35,12 @@ func (h *UserHandler) Authorize() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		var req request.AuthorizeRequest
 		if err := c.Bind(&req); err != nil {
-			return c.JSON(http.StatusBadRequest, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusBadRequest,
-					Message: "invalid request",
-				},
-			})
+			return badRequest("invalid request")
 		}
 
 		user, accessToken, err := h.userUsecase.Authorize(c, req.IdToken)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
+			return err
 		}
 
 		res := response.AuthorizeResponse{
@@ -83,13 +71,7 @@ func (h *UserHandler) GetAllUsers() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		users, err := h.userUsecase.GetAllUsers(c)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
+			return err
 		}
 
 		return c.JSON(http.StatusOK, response.SuccessResponse{
@@ -113,13 +95,7 @@ func (h *UserHandler) GetUser() echo.HandlerFunc {
 
 		user, err := h.userUsecase.GetUser(c, id)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
+			return err
 		}
 
 		res := response.User{
@@ -150,24 +126,12 @@ func (h *UserHandler) UpdateUser() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		var req request.UpdateUserRequest
 		if err := c.Bind(&req); err != nil {
-			return c.JSON(http.StatusBadRequest, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusBadRequest,
-					Message: "invalid request",
-				},
-			})
+			return badRequest("invalid request")
 		}
 		id := c.Get("uid").(string)
 
 		if _, err := h.userUsecase.UpdateUser(c, id, req.Name, req.Email); err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
+			return err
 		}
 		return c.JSON(http.StatusOK, response.SuccessResponse{
 			Status: "success",
@@ -191,23 +155,11 @@ func (h *UserHandler) DeleteUser() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		var req request.DeleteUserRequest
 		if err := c.Bind(&req); err != nil {
-			return c.JSON(http.StatusBadRequest, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusBadRequest,
-					Message: "invalid request",
-				},
-			})
+			return badRequest("invalid request")
 		}
 
 		if err := h.userUsecase.DeleteUser(c, req.Id); err != nil {
-			return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusInternalServerError,
-					Message: err.Error(),
-				},
-			})
+			return err
 		}
 		return c.JSON(http.StatusOK, response.SuccessResponse{
 			Status: "success",
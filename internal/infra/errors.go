@@ -0,0 +1,23 @@
+package infra
+
+import (
+	"CurlARC/internal/domain/domainerr"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// errDuplicated is reported when a row breaks a unique constraint.
+var errDuplicated = domainerr.Conflict("ALREADY_EXISTS", "the resource already exists")
+
+// translateError turns the errors of GORM callers can act on into domain errors: a missing row into notFound,
+// when given, and a duplicated key into a conflict. Other errors are returned as they are.
+func translateError(err error, notFound *domainerr.Error) error {
+	switch {
+	case notFound != nil && errors.Is(err, gorm.ErrRecordNotFound):
+		return notFound.Wrap(err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return errDuplicated.Wrap(err)
+	}
+	return err
+}
@@ -0,0 +1,130 @@
+package infra
+
+import (
+	"CurlARC/internal/domain/domainerr"
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/repository"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type EventRepository struct {
+	SqlHandler
+}
+
+func NewEventRepository(sqlHandler SqlHandler) repository.EventRepository {
+	eventRepository := EventRepository{SqlHandler: sqlHandler}
+	return &eventRepository
+}
+
+func (e *Event) FromDomain(event *entity.Event) {
+	e.Id = event.GetId().Value()
+	e.TeamId = event.GetTeamId()
+	e.Name = event.GetName()
+	e.Venue = event.GetVenue()
+	e.StartDate = event.GetStartDate()
+	e.EndDate = event.GetEndDate()
+	e.Format = string(event.GetFormat())
+	e.Games = make([]EventGame, 0, len(event.GetGames()))
+	for _, game := range event.GetGames() {
+		e.Games = append(e.Games, EventGame{
+			EventId:   e.Id,
+			RecordId:  game.RecordId,
+			Round:     game.Round,
+			FriendLSD: game.FriendLSD,
+			EnemyLSD:  game.EnemyLSD,
+		})
+	}
+}
+
+func (e *Event) ToDomain() *entity.Event {
+	games := make([]entity.EventGame, 0, len(e.Games))
+	for _, game := range e.Games {
+		games = append(games, entity.EventGame{
+			RecordId:  game.RecordId,
+			Round:     game.Round,
+			FriendLSD: game.FriendLSD,
+			EnemyLSD:  game.EnemyLSD,
+		})
+	}
+	return entity.NewEventFromDB(e.Id, e.TeamId, e.Name, e.Venue, e.StartDate, e.EndDate, entity.EventFormat(e.Format), games)
+}
+
+////////////////////////////////////////
+// Event Repository Implementation
+////////////////////////////////////////
+
+func (r *EventRepository) Save(event *entity.Event) (*entity.Event, error) {
+	var dbEvent Event
+	dbEvent.FromDomain(event)
+
+	if err := r.Conn.Omit("Team", "Games.Record").Create(&dbEvent).Error; err != nil {
+		return nil, translateError(err, nil)
+	}
+
+	return dbEvent.ToDomain(), nil
+}
+
+func (r *EventRepository) FindById(id string) (*entity.Event, error) {
+	var dbEvent Event
+	if err := r.Conn.Preload("Games").First(&dbEvent, "id = ?", id).Error; err != nil {
+		return nil, translateError(err, domainerr.ErrEventNotFound)
+	}
+	return dbEvent.ToDomain(), nil
+}
+
+func (r *EventRepository) FindByTeamId(teamId string) ([]*entity.Event, error) {
+	var dbEvents []Event
+	if err := r.Conn.Preload("Games").Where("team_id = ?", teamId).Order("start_date DESC").Find(&dbEvents).Error; err != nil {
+		return nil, err
+	}
+
+	var events []*entity.Event
+	for _, dbEvent := range dbEvents {
+		events = append(events, dbEvent.ToDomain())
+	}
+	return events, nil
+}
+
+func (r *EventRepository) FindByRecordId(recordId string) (*entity.Event, error) {
+	var game EventGame
+	if err := r.Conn.First(&game, "record_id = ?", recordId).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.FindById(game.EventId)
+}
+
+// Update saves the event and replaces its games, so that detached records are removed.
+func (r *EventRepository) Update(event *entity.Event) (*entity.Event, error) {
+	var dbEvent Event
+	dbEvent.FromDomain(event)
+
+	err := r.Conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Team", "Games").Save(&dbEvent).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&EventGame{}, "event_id = ?", dbEvent.Id).Error; err != nil {
+			return err
+		}
+		if len(dbEvent.Games) == 0 {
+			return nil
+		}
+		return tx.Omit("Record").Create(&dbEvent.Games).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbEvent.ToDomain(), nil
+}
+
+func (r *EventRepository) Delete(id string) error {
+	if err := r.Conn.Delete(&Event{}, "id = ?", id).Error; err != nil {
+		return err
+	}
+	return nil
+}
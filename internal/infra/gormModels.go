@@ -14,11 +14,16 @@ type Team struct {
 }
 
 type UserTeam struct {
-	UserId string `gorm:"primaryKey"`
-	TeamId string `gorm:"primaryKey"`
-	State  string `gorm:"type:varchar(100)"`
-	Team   Team   `gorm:"foreignKey:TeamId;constraint:OnDelete:CASCADE;"`
-	User   User   `gorm:"foreignKey:UserId"`
+	UserId    string     `gorm:"primaryKey"`
+	TeamId    string     `gorm:"primaryKey"`
+	State     string     `gorm:"type:varchar(100)"`
+	Role      string     `gorm:"type:varchar(16);not null;default:MEMBER"`
+	InvitedBy *string    `gorm:"type:text"`
+	InvitedAt *time.Time `gorm:"type:timestamp"`
+	ExpiresAt *time.Time `gorm:"type:timestamp"`
+	ClosedAt  *time.Time `gorm:"type:timestamp"`
+	Team      Team       `gorm:"foreignKey:TeamId;constraint:OnDelete:CASCADE;"`
+	User      User       `gorm:"foreignKey:UserId"`
 }
 
 type User struct {
@@ -39,5 +44,48 @@ type Record struct {
 	IsRed         bool           `gorm:"type:boolean"`
 	IsFirst       bool           `gorm:"type:boolean"`
 	IsPublic      bool           `gorm:"type:boolean"`
+	LineupJSON    datatypes.JSON `gorm:"type:json"`
+	GameFormat    string         `gorm:"type:varchar(32)"`
+	Status        string         `gorm:"type:varchar(16)"`
+	Team          Team           `gorm:"foreignKey:TeamId;constraint:OnDelete:CASCADE;"`
+}
+
+type Event struct {
+	Id        string      `gorm:"type:uuid;primaryKey"`
+	TeamId    string      `gorm:"foreignKey:TeamId"`
+	Name      string      `gorm:"type:varchar(255)"`
+	Venue     string      `gorm:"type:varchar(255)"`
+	StartDate time.Time   `gorm:"type:timestamp"`
+	EndDate   time.Time   `gorm:"type:timestamp"`
+	Format    string      `gorm:"type:varchar(16)"`
+	Games     []EventGame `gorm:"foreignKey:EventId;constraint:OnDelete:CASCADE;"`
+	Team      Team        `gorm:"foreignKey:TeamId;constraint:OnDelete:CASCADE;"`
+}
+
+type EventGame struct {
+	EventId   string   `gorm:"type:uuid;primaryKey"`
+	RecordId  string   `gorm:"type:uuid;primaryKey;uniqueIndex"`
+	Round     string   `gorm:"type:varchar(64)"`
+	FriendLSD *float64 `gorm:"column:friend_lsd"`
+	EnemyLSD  *float64 `gorm:"column:enemy_lsd"`
+	Record    Record   `gorm:"foreignKey:RecordId;constraint:OnDelete:CASCADE;"`
+}
+
+type Season struct {
+	Id        string    `gorm:"type:uuid;primaryKey"`
+	TeamId    string    `gorm:"foreignKey:TeamId"`
+	Name      string    `gorm:"type:varchar(255)"`
+	StartDate time.Time `gorm:"type:date"`
+	EndDate   time.Time `gorm:"type:date"`
+	Team      Team      `gorm:"foreignKey:TeamId;constraint:OnDelete:CASCADE;"`
+}
+
+type PracticeSession struct {
+	Id            string         `gorm:"type:uuid;primaryKey"`
+	TeamId        string         `gorm:"foreignKey:TeamId"`
+	Date          time.Time      `gorm:"type:timestamp"`
+	Place         string         `gorm:"type:varchar(255)"`
+	AttendeesJSON datatypes.JSON `gorm:"type:json"`
+	DrillsJSON    datatypes.JSON `gorm:"type:json"`
 	Team          Team           `gorm:"foreignKey:TeamId;constraint:OnDelete:CASCADE;"`
 }
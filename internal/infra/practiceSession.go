@@ -0,0 +1,90 @@
+package infra
+
+import (
+	"CurlARC/internal/domain/domainerr"
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/repository"
+	"encoding/json"
+)
+
+type PracticeSessionRepository struct {
+	SqlHandler
+}
+
+func NewPracticeSessionRepository(sqlHandler SqlHandler) repository.PracticeSessionRepository {
+	practiceSessionRepository := PracticeSessionRepository{SqlHandler: sqlHandler}
+	return &practiceSessionRepository
+}
+
+func (p *PracticeSession) FromDomain(session *entity.PracticeSession) {
+	p.Id = session.GetId().Value()
+	p.TeamId = session.GetTeamId()
+	p.Date = session.GetDate()
+	p.Place = session.GetPlace()
+	p.AttendeesJSON, _ = json.Marshal(session.GetAttendees())
+	p.DrillsJSON, _ = json.Marshal(session.GetDrills())
+}
+
+func (p *PracticeSession) ToDomain() *entity.PracticeSession {
+	var attendees []entity.LineupPlayer
+	if len(p.AttendeesJSON) > 0 {
+		_ = json.Unmarshal(p.AttendeesJSON, &attendees)
+	}
+	var drills []entity.Drill
+	if len(p.DrillsJSON) > 0 {
+		_ = json.Unmarshal(p.DrillsJSON, &drills)
+	}
+	return entity.NewPracticeSessionFromDB(p.Id, p.TeamId, p.Date, p.Place, attendees, drills)
+}
+
+////////////////////////////////////////
+// PracticeSession Repository Implementation
+////////////////////////////////////////
+
+func (r *PracticeSessionRepository) Save(session *entity.PracticeSession) (*entity.PracticeSession, error) {
+	var dbSession PracticeSession
+	dbSession.FromDomain(session)
+
+	if err := r.Conn.Omit("Team").Create(&dbSession).Error; err != nil {
+		return nil, translateError(err, nil)
+	}
+
+	return dbSession.ToDomain(), nil
+}
+
+func (r *PracticeSessionRepository) FindById(id string) (*entity.PracticeSession, error) {
+	var dbSession PracticeSession
+	if err := r.Conn.First(&dbSession, "id = ?", id).Error; err != nil {
+		return nil, translateError(err, domainerr.ErrPracticeSessionNotFound)
+	}
+	return dbSession.ToDomain(), nil
+}
+
+func (r *PracticeSessionRepository) FindByTeamId(teamId string) ([]*entity.PracticeSession, error) {
+	var dbSessions []PracticeSession
+	if err := r.Conn.Where("team_id = ?", teamId).Order("date DESC").Find(&dbSessions).Error; err != nil {
+		return nil, err
+	}
+
+	var sessions []*entity.PracticeSession
+	for _, dbSession := range dbSessions {
+		sessions = append(sessions, dbSession.ToDomain())
+	}
+	return sessions, nil
+}
+
+func (r *PracticeSessionRepository) Update(session *entity.PracticeSession) (*entity.PracticeSession, error) {
+	var dbSession PracticeSession
+	dbSession.FromDomain(session)
+	if err := r.Conn.Omit("Team").Save(&dbSession).Error; err != nil {
+		return nil, err
+	}
+	return dbSession.ToDomain(), nil
+}
+
+func (r *PracticeSessionRepository) Delete(id string) error {
+	if err := r.Conn.Delete(&PracticeSession{}, "id = ?", id).Error; err != nil {
+		return err
+	}
+	return nil
+}
@@ -1,12 +1,16 @@
 package infra
 
 import (
+	"CurlARC/internal/domain/domainerr"
 	"CurlARC/internal/domain/entity"
 	"CurlARC/internal/domain/repository"
 	"CurlARC/internal/handler/response"
 	"encoding/json"
+	"strings"
+	"time"
 
 	"gorm.io/datatypes"
+	"gorm.io/gorm"
 )
 
 type RecordRepository struct {
@@ -29,12 +33,30 @@ func (r *Record) FromDomain(record *entity.Record) {
 	r.IsRed = record.GetIsRed()
 	r.IsFirst = record.GetIsFirst()
 	r.IsPublic = record.IsPublic()
+	r.GameFormat = string(record.GetGameFormat())
+	r.Status = string(record.GetStatus())
+	r.LineupJSON = nil
+	if lineup := record.GetLineup(); !lineup.IsEmpty() {
+		r.LineupJSON, _ = json.Marshal(lineup)
+	}
 }
 
 func (r *Record) ToDomain() *entity.Record {
 	result := entity.Result(r.Result)           // convert string to Result
 	endsData := convertFromJSON(r.EndsDataJSON) // convert JSON to []DataPerEnd
 
+	var options []entity.RecordOption
+	var lineup entity.Lineup
+	if len(r.LineupJSON) > 0 && json.Unmarshal(r.LineupJSON, &lineup) == nil {
+		options = append(options, entity.WithLineup(lineup))
+	}
+	if r.GameFormat != "" {
+		options = append(options, entity.WithGameFormat(entity.GameFormat(r.GameFormat)))
+	}
+	if r.Status != "" {
+		options = append(options, entity.WithStatus(entity.GameStatus(r.Status)))
+	}
+
 	record := entity.NewRecordFromDB(
 		r.Id,
 		r.TeamId,
@@ -46,6 +68,7 @@ func (r *Record) ToDomain() *entity.Record {
 		r.IsRed,
 		r.IsFirst,
 		r.IsPublic,
+		options...,
 	) // create a new Record
 
 	return record
@@ -70,7 +93,7 @@ func (r *RecordRepository) Save(record entity.Record) (*entity.Record, error) {
 	dbRecord.FromDomain(&record)
 
 	if err := r.Conn.Create(&dbRecord).Error; err != nil {
-		return nil, err
+		return nil, translateError(err, nil)
 	}
 
 	return dbRecord.ToDomain(), nil
@@ -79,33 +102,152 @@ func (r *RecordRepository) Save(record entity.Record) (*entity.Record, error) {
 func (r *RecordRepository) FindByRecordId(recordId string) (*entity.Record, error) {
 	var dbRecord Record
 	if err := r.Conn.First(&dbRecord, "id = ?", recordId).Error; err != nil {
-		return nil, err
+		return nil, translateError(err, domainerr.ErrRecordNotFound)
 	}
 	return dbRecord.ToDomain(), nil
 }
 
-func (r *RecordRepository) FindIndicesByTeamId(teamId string) (*[]response.RecordIndex, error) {
-	var dbRecords []Record
-	if err := r.Conn.Select(
-		"id", "result", "enemy_team_name", "place", "date").Where("team_id = ?", teamId).Find(&dbRecords).Error; err != nil {
+// recordIndexRow is a row of the record list. The number of ends is counted in the database
+// so that the ends data does not have to be loaded.
+type recordIndexRow struct {
+	Id            string
+	Result        string
+	EnemyTeamName string
+	Place         string
+	Date          time.Time
+	GameFormat    string
+	Status        string
+	EndsPlayed    int
+}
+
+// endsPlayedExpression counts the ends of a record. A NULL, scalar or object ends_data_json counts as no ends;
+// the CASE keeps jsonb_array_length from ever running on something other than an array.
+const endsPlayedExpression = "(CASE WHEN jsonb_typeof(ends_data_json) = 'array' THEN jsonb_array_length(ends_data_json) ELSE 0 END)"
+
+const endsPlayedColumn = endsPlayedExpression + " AS ends_played"
+
+// recordSortKeys maps each sort to the expression it orders by, the value of that expression for a row,
+// and whether the order is descending. Missing values sort as the zero value so that the cursor can compare them.
+var recordSortKeys = map[repository.RecordSort]struct {
+	expression string
+	value      func(recordIndexRow) string
+	descending bool
+}{
+	repository.SortDateDesc:     {dateSortExpression, dateSortValue, true},
+	repository.SortDateAsc:      {dateSortExpression, dateSortValue, false},
+	repository.SortOpponentAsc:  {"COALESCE(enemy_team_name, '')", func(row recordIndexRow) string { return row.EnemyTeamName }, false},
+	repository.SortOpponentDesc: {"COALESCE(enemy_team_name, '')", func(row recordIndexRow) string { return row.EnemyTeamName }, true},
+	repository.SortPlaceAsc:     {"COALESCE(place, '')", func(row recordIndexRow) string { return row.Place }, false},
+	repository.SortPlaceDesc:    {"COALESCE(place, '')", func(row recordIndexRow) string { return row.Place }, true},
+}
+
+const dateSortExpression = "COALESCE(date, '0001-01-01')"
+
+func dateSortValue(row recordIndexRow) string {
+	return row.Date.Format(time.RFC3339Nano)
+}
+
+// hasEndsDataCondition and hasNoEndsDataCondition never evaluate to NULL, so records whose ends_data_json is NULL
+// are among those with no ends data.
+const (
+	hasEndsDataCondition   = endsPlayedExpression + " > 0"
+	hasNoEndsDataCondition = endsPlayedExpression + " = 0"
+)
+
+// normalizedEnemyTeamName matches entity.NormalizeTeamName: lower case without whitespace, full-width spaces included.
+const normalizedEnemyTeamName = "LOWER(REGEXP_REPLACE(enemy_team_name, '[[:space:]\u3000]', '', 'g'))"
+
+func (r *RecordRepository) FindIndicesByTeamId(teamId string, query repository.RecordQuery) (*repository.RecordIndexPage, error) {
+	sort, ok := recordSortKeys[query.Sort]
+	if !ok {
+		sort = recordSortKeys[repository.SortDateDesc]
+		query.Sort = repository.SortDateDesc
+	}
+
+	db := r.Conn.Model(&Record{}).Select(
+		"id", "result", "enemy_team_name", "place", "date", "game_format", "status", endsPlayedColumn).Where("team_id = ?", teamId)
+	if !query.From.IsZero() {
+		db = db.Where("date >= ?", query.From)
+	}
+	if !query.To.IsZero() {
+		db = db.Where("date <= ?", query.To)
+	}
+	if query.Result != "" {
+		db = db.Where("result = ?", string(query.Result))
+	}
+	if query.Opponent != "" {
+		db = db.Where(normalizedEnemyTeamName+" = ?", entity.NormalizeTeamName(query.Opponent))
+	}
+	if place := strings.TrimSpace(query.Place); place != "" {
+		db = db.Where("place ILIKE ?", "%"+likeEscaper.Replace(place)+"%")
+	}
+	if query.IsPublic != nil {
+		db = db.Where("is_public = ?", *query.IsPublic)
+	}
+	if query.HasEndsData != nil {
+		if *query.HasEndsData {
+			db = db.Where(hasEndsDataCondition)
+		} else {
+			db = db.Where(hasNoEndsDataCondition)
+		}
+	}
+
+	direction, comparison := "ASC", ">"
+	if sort.descending {
+		direction, comparison = "DESC", "<"
+	}
+	if query.Cursor != nil {
+		var value interface{} = query.Cursor.Value
+		if sort.expression == dateSortExpression {
+			date, err := time.Parse(time.RFC3339Nano, query.Cursor.Value)
+			if err != nil {
+				return nil, domainerr.Invalid("cursor", "invalid cursor")
+			}
+			value = date
+		}
+		db = db.Where("("+sort.expression+", id) "+comparison+" (?, ?)", value, query.Cursor.Id)
+	}
+
+	// one more row than asked tells whether there is a next page
+	var rows []recordIndexRow
+	if err := db.Order(sort.expression + " " + direction).Order("id " + direction).Limit(query.Limit + 1).Scan(&rows).Error; err != nil {
 		return nil, err
 	}
 
-	var recordIndices []response.RecordIndex
-	for _, dbRecord := range dbRecords {
+	page := &repository.RecordIndexPage{Indices: []response.RecordIndex{}}
+	if len(rows) > query.Limit {
+		rows = rows[:query.Limit]
+		last := rows[len(rows)-1]
+		page.NextCursor = &repository.RecordCursor{Sort: query.Sort, Value: sort.value(last), Id: last.Id}
+	}
+
+	for _, row := range rows {
+		record := entity.NewRecordFromDB(row.Id, teamId, row.EnemyTeamName, row.Place, entity.Result(row.Result), row.Date, nil, false, false, false,
+			entity.WithGameFormat(entity.GameFormat(row.GameFormat)),
+			entity.WithStatus(entity.GameStatus(row.Status)),
+		)
 		recordIndex := response.RecordIndex{
-			Id:            dbRecord.Id,
-			Result:        entity.Result(dbRecord.Result),
-			EnemyTeamName: dbRecord.EnemyTeamName,
-			Place:         dbRecord.Place,
-			Date:          dbRecord.Date,
+			Id:            row.Id,
+			Result:        record.GetResult(),
+			EnemyTeamName: row.EnemyTeamName,
+			Place:         row.Place,
+			Date:          row.Date,
+			GameFormat:    record.GetGameFormat(),
+			Status:        record.GetStatus(),
+			EndsPlayed:    row.EndsPlayed,
+		}
+		if extra := row.EndsPlayed - record.RegulationEnds(); extra > 0 {
+			recordIndex.ExtraEnds = extra
 		}
-		recordIndices = append(recordIndices, recordIndex)
+		page.Indices = append(page.Indices, recordIndex)
 	}
 
-	return &recordIndices, nil
+	return page, nil
 }
 
+// likeEscaper escapes the wildcards of a LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 func (r *RecordRepository) FindByTeamId(teamId string) (*[]entity.Record, error) {
 	var dbRecords []Record
 	if err := r.Conn.Where("team_id = ?", teamId).Find(&dbRecords).Error; err != nil {
@@ -120,10 +262,49 @@ func (r *RecordRepository) FindByTeamId(teamId string) (*[]entity.Record, error)
 	return &records, nil
 }
 
+func (r *RecordRepository) FindByIds(recordIds []string) (*[]entity.Record, error) {
+	var records []entity.Record
+	if len(recordIds) == 0 {
+		return &records, nil
+	}
+
+	var dbRecords []Record
+	if err := r.Conn.Where("id IN ?", recordIds).Find(&dbRecords).Error; err != nil {
+		return nil, err
+	}
+	for _, dbRecord := range dbRecords {
+		records = append(records, *dbRecord.ToDomain())
+	}
+
+	return &records, nil
+}
+
+// recordBatchSize is how many records are held in memory at once when iterating over many of them.
+const recordBatchSize = 100
+
+func (r *RecordRepository) EachWithEndsData(teamId string, includePublic bool, fn func(entity.Record) error) error {
+	query := r.Conn.Model(&Record{}).Where(hasEndsDataCondition)
+	if includePublic {
+		query = query.Where("team_id = ? OR is_public = ?", teamId, true)
+	} else {
+		query = query.Where("team_id = ?", teamId)
+	}
+
+	var dbRecords []Record
+	return query.FindInBatches(&dbRecords, recordBatchSize, func(tx *gorm.DB, batch int) error {
+		for _, dbRecord := range dbRecords {
+			if err := fn(*dbRecord.ToDomain()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
 func (r *RecordRepository) Update(record entity.Record) (*entity.Record, error) {
 	var dbRecord Record
 	if err := r.Conn.First(&dbRecord, "id = ?", record.GetId().Value()).Error; err != nil {
-		return nil, err
+		return nil, translateError(err, domainerr.ErrRecordNotFound)
 	}
 
 	dbRecord.FromDomain(&record)
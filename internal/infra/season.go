@@ -0,0 +1,80 @@
+package infra
+
+import (
+	"CurlARC/internal/domain/domainerr"
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/repository"
+)
+
+type SeasonRepository struct {
+	SqlHandler
+}
+
+func NewSeasonRepository(sqlHandler SqlHandler) repository.SeasonRepository {
+	seasonRepository := SeasonRepository{SqlHandler: sqlHandler}
+	return &seasonRepository
+}
+
+func (s *Season) FromDomain(season *entity.Season) {
+	s.Id = season.GetId().Value()
+	s.TeamId = season.GetTeamId()
+	s.Name = season.GetName()
+	s.StartDate = season.GetStartDate()
+	s.EndDate = season.GetEndDate()
+}
+
+func (s *Season) ToDomain() *entity.Season {
+	return entity.NewSeasonFromDB(s.Id, s.TeamId, s.Name, s.StartDate, s.EndDate)
+}
+
+////////////////////////////////////////
+// Season Repository Implementation
+////////////////////////////////////////
+
+func (r *SeasonRepository) Save(season *entity.Season) (*entity.Season, error) {
+	var dbSeason Season
+	dbSeason.FromDomain(season)
+
+	if err := r.Conn.Omit("Team").Create(&dbSeason).Error; err != nil {
+		return nil, translateError(err, nil)
+	}
+
+	return dbSeason.ToDomain(), nil
+}
+
+func (r *SeasonRepository) FindById(id string) (*entity.Season, error) {
+	var dbSeason Season
+	if err := r.Conn.First(&dbSeason, "id = ?", id).Error; err != nil {
+		return nil, translateError(err, domainerr.ErrSeasonNotFound)
+	}
+	return dbSeason.ToDomain(), nil
+}
+
+func (r *SeasonRepository) FindByTeamId(teamId string) ([]*entity.Season, error) {
+	var dbSeasons []Season
+	if err := r.Conn.Where("team_id = ?", teamId).Order("start_date DESC").Find(&dbSeasons).Error; err != nil {
+		return nil, err
+	}
+
+	var seasons []*entity.Season
+	for _, dbSeason := range dbSeasons {
+		seasons = append(seasons, dbSeason.ToDomain())
+	}
+	return seasons, nil
+}
+
+func (r *SeasonRepository) Update(season *entity.Season) (*entity.Season, error) {
+	var dbSeason Season
+	dbSeason.FromDomain(season)
+	if err := r.Conn.Omit("Team").Save(&dbSeason).Error; err != nil {
+		return nil, err
+	}
+	return dbSeason.ToDomain(), nil
+}
+
+func (r *SeasonRepository) Delete(id string) error {
+	if err := r.Conn.Delete(&Season{}, "id = ?", id).Error; err != nil {
+		return err
+	}
+	return nil
+}
@@ -26,7 +26,7 @@ func NewSqlHandler() *SqlHandler {
 		host, user, password, dbname)
 
 	// データベースへの接続
-	conn, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	conn, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		panic(err.Error)
 	}
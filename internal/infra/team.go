@@ -1,6 +1,7 @@
 package infra
 
 import (
+	"CurlARC/internal/domain/domainerr"
 	"CurlARC/internal/domain/entity"
 	"CurlARC/internal/domain/repository"
 )
@@ -32,7 +33,7 @@ func (r *TeamRepository) Save(team *entity.Team) (*entity.Team, error) {
 	dbTeam.FromDomain(team)
 
 	if err := r.SqlHandler.Conn.Create(&dbTeam).Error; err != nil {
-		return nil, err
+		return nil, translateError(err, nil)
 	}
 
 	return dbTeam.ToDomain(), nil
@@ -55,7 +56,7 @@ func (r *TeamRepository) FindAll() ([]*entity.Team, error) {
 func (r *TeamRepository) FindById(id string) (*entity.Team, error) {
 	var team Team
 	if err := r.SqlHandler.Conn.First(&team, "id = ?", id).Error; err != nil {
-		return nil, err
+		return nil, translateError(err, domainerr.ErrTeamNotFound)
 	}
 	return team.ToDomain(), nil
 }
@@ -1,6 +1,7 @@
 package infra
 
 import (
+	"CurlARC/internal/domain/domainerr"
 	"CurlARC/internal/domain/entity"
 	"CurlARC/internal/domain/repository"
 )
@@ -34,7 +35,7 @@ func (r *UserRepository) Save(user *entity.User) (*entity.User, error) {
 	User.FromDomain(user)
 
 	if err := r.Conn.Create(&User).Error; err != nil {
-		return nil, err
+		return nil, translateError(err, nil)
 	}
 
 	return User.ToDomain(), nil
@@ -57,7 +58,7 @@ func (r *UserRepository) FindAll() ([]*entity.User, error) {
 func (r *UserRepository) FindById(id string) (*entity.User, error) {
 	var user User
 	if err := r.Conn.First(&user, "id = ?", id).Error; err != nil {
-		return nil, err
+		return nil, translateError(err, domainerr.ErrUserNotFound)
 	}
 
 	return user.ToDomain(), nil
@@ -66,7 +67,7 @@ func (r *UserRepository) FindById(id string) (*entity.User, error) {
 func (r *UserRepository) FindByEmail(email string) (*entity.User, error) {
 	var user User
 	if err := r.Conn.First(&user, "email = ?", email).Error; err != nil {
-		return nil, err
+		return nil, translateError(err, domainerr.ErrUserNotFound)
 	}
 
 	return user.ToDomain(), nil
@@ -1,11 +1,14 @@
 package infra
 
 import (
+	"CurlARC/internal/domain/domainerr"
 	"CurlARC/internal/domain/entity"
 	"CurlARC/internal/domain/repository"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserTeamRepository struct {
@@ -21,30 +24,105 @@ func (userTeam *UserTeam) FromDomain(userTeamEntity *entity.UserTeam) {
 	userTeam.UserId = userTeamEntity.GetUserId().Value()
 	userTeam.TeamId = userTeamEntity.GetTeamId().Value()
 	userTeam.State = string(userTeamEntity.GetState())
+	userTeam.Role = string(userTeamEntity.GetRole())
+	userTeam.InvitedBy = nil
+	if invitedBy := userTeamEntity.GetInvitedBy(); invitedBy != "" {
+		userTeam.InvitedBy = &invitedBy
+	}
+	userTeam.InvitedAt = nullableTime(userTeamEntity.GetInvitedAt())
+	userTeam.ExpiresAt = nullableTime(userTeamEntity.GetExpiresAt())
+	userTeam.ClosedAt = nullableTime(userTeamEntity.GetClosedAt())
 }
 
 func (userTeam *UserTeam) ToDomain() *entity.UserTeam {
-	return entity.NewUserTeam(
-		*entity.NewUserId(userTeam.UserId),
-		*entity.NewTeamId(userTeam.TeamId),
+	var invitedBy string
+	if userTeam.InvitedBy != nil {
+		invitedBy = *userTeam.InvitedBy
+	}
+	var invitedAt, expiresAt, closedAt time.Time
+	if userTeam.InvitedAt != nil {
+		invitedAt = *userTeam.InvitedAt
+	}
+	if userTeam.ExpiresAt != nil {
+		expiresAt = *userTeam.ExpiresAt
+	}
+	if userTeam.ClosedAt != nil {
+		closedAt = *userTeam.ClosedAt
+	}
+
+	return entity.NewUserTeamFromDB(
+		userTeam.UserId,
+		userTeam.TeamId,
 		entity.UserTeamState(userTeam.State),
+		entity.TeamRole(userTeam.Role),
+		invitedBy,
+		invitedAt,
+		expiresAt,
+		closedAt,
 	)
 }
 
+// nullableTime stores the zero time as NULL.
+// nullableTime stores a time in UTC, as the timestamp columns keep no time zone.
+func nullableTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
+
 func (userTeamRepo *UserTeamRepository) Save(userTeam *entity.UserTeam) (*entity.UserTeam, error) {
 	var dbUserTeam UserTeam
 	dbUserTeam.FromDomain(userTeam)
 
 	if err := userTeamRepo.SqlHandler.Conn.Create(&dbUserTeam).Error; err != nil {
-		return nil, err
+		return nil, translateError(err, nil)
+	}
+
+	return dbUserTeam.ToDomain(), nil
+}
+
+// SaveInvitation inserts the invitation, or sends it again if the user was already invited to the team,
+// reopening a declined or revoked invitation: the inviter, the time it was sent and the expiry are replaced.
+// A member of the team is not invited again.
+func (userTeamRepo *UserTeamRepository) SaveInvitation(userTeam *entity.UserTeam) (*entity.UserTeam, error) {
+	var dbUserTeam UserTeam
+	dbUserTeam.FromDomain(userTeam)
+
+	reopenable := clause.IN{
+		Column: clause.Column{Table: "user_teams", Name: "state"},
+		Values: []interface{}{entity.Invited, entity.Declined, entity.Revoked},
+	}
+	result := userTeamRepo.SqlHandler.Conn.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "team_id"}},
+		Where:     clause.Where{Exprs: []clause.Expression{reopenable}},
+		DoUpdates: clause.AssignmentColumns([]string{"state", "invited_by", "invited_at", "expires_at", "closed_at"}),
+	}).Create(&dbUserTeam)
+
+	if result.Error != nil {
+		return nil, translateError(result.Error, nil)
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, domainerr.Conflict("ALREADY_TEAM_MEMBER", "user is already a member of the team")
 	}
 
 	return dbUserTeam.ToDomain(), nil
 }
 
+func (userTeamRepo *UserTeamRepository) Find(userId, teamId string) (*entity.UserTeam, error) {
+	var userTeam UserTeam
+	if err := userTeamRepo.SqlHandler.Conn.First(&userTeam, "user_id = ? AND team_id = ?", userId, teamId).Error; err != nil {
+		return nil, translateError(err, domainerr.ErrUserTeamNotFound)
+	}
+
+	return userTeam.ToDomain(), nil
+}
+
 func (userTeamRepo *UserTeamRepository) FindUsersByTeamId(teamId string) ([]string, error) {
 	var userTeams []*UserTeam
-	result := userTeamRepo.SqlHandler.Conn.Where("team_id = ?", teamId).Find(&userTeams)
+	result := userTeamRepo.SqlHandler.Conn.Where("team_id = ? AND state IN ?", teamId, []entity.UserTeamState{entity.Member, entity.Invited}).Find(&userTeams)
 
 	if result.Error != nil {
 		return nil, result.Error
@@ -74,20 +152,20 @@ func (userTeamRepo *UserTeamRepository) FindMembersByTeamId(teamId string) ([]st
 	return userIds, nil
 }
 
-func (userTeamRepo *UserTeamRepository) FindInvitedUsersByTeamId(teamId string) ([]string, error) {
+func (userTeamRepo *UserTeamRepository) FindInvitationsByTeamId(teamId string) ([]*entity.UserTeam, error) {
 	var userTeams []*UserTeam
-	result := userTeamRepo.SqlHandler.Conn.Where("team_id = ? AND state = ?", teamId, entity.Invited).Find(&userTeams)
+	result := userTeamRepo.SqlHandler.Conn.Where("team_id = ? AND state = ?", teamId, entity.Invited).Order("invited_at DESC").Find(&userTeams)
 
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	var userIds []string
+	invitations := make([]*entity.UserTeam, 0, len(userTeams))
 	for _, userTeam := range userTeams {
-		userIds = append(userIds, userTeam.UserId)
+		invitations = append(invitations, userTeam.ToDomain())
 	}
 
-	return userIds, nil
+	return invitations, nil
 }
 
 func (userTeamRepo *UserTeamRepository) FindTeamsByUserId(userId string) ([]string, error) {
@@ -106,41 +184,124 @@ func (userTeamRepo *UserTeamRepository) FindTeamsByUserId(userId string) ([]stri
 	return teamIds, nil
 }
 
-func (userTeamRepo *UserTeamRepository) FindInvitedTeamsByUserId(userId string) ([]string, error) {
+func (userTeamRepo *UserTeamRepository) FindInvitationsByUserId(userId string) ([]*entity.UserTeam, error) {
 	var userTeams []*UserTeam
-	result := userTeamRepo.SqlHandler.Conn.Where("user_id = ? AND state = ?", userId, entity.Invited).Find(&userTeams)
+	result := userTeamRepo.SqlHandler.Conn.Where("user_id = ? AND state = ?", userId, entity.Invited).Order("invited_at DESC").Find(&userTeams)
 
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	var teamIds []string
+	invitations := make([]*entity.UserTeam, 0, len(userTeams))
 	for _, userTeam := range userTeams {
-		teamIds = append(teamIds, userTeam.TeamId)
+		invitations = append(invitations, userTeam.ToDomain())
 	}
 
-	return teamIds, nil
+	return invitations, nil
 }
 
+// UpdateState answers a pending invitation with the state of userTeam. The invitation is checked in the same
+// statement, so that it cannot be accepted once it was revoked, sent again or expired in the meantime.
+// Expiry is compared with the current time in UTC from Go rather than the database clock, whose session
+// time zone may differ from the one the times were written in.
 func (userTeamRepo *UserTeamRepository) UpdateState(userTeam *entity.UserTeam) (*entity.UserTeam, error) {
 	var dbUserTeam UserTeam
 	dbUserTeam.FromDomain(userTeam)
 
+	query := userTeamRepo.SqlHandler.Conn.Model(&dbUserTeam).
+		Where("user_id = ? AND team_id = ? AND state = ?", dbUserTeam.UserId, dbUserTeam.TeamId, entity.Invited)
+	if userTeam.GetState() == entity.Member {
+		query = query.Where("expires_at IS NULL OR expires_at > ?", time.Now().UTC())
+	}
+	result := query.Updates(map[string]interface{}{"state": dbUserTeam.State, "closed_at": dbUserTeam.ClosedAt})
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, userTeamRepo.unanswerable(dbUserTeam.UserId, dbUserTeam.TeamId)
+	}
+
+	return dbUserTeam.ToDomain(), nil
+}
+
+// unanswerable tells why an invitation could not be answered: there is none pending, or it has expired.
+func (userTeamRepo *UserTeamRepository) unanswerable(userId, teamId string) error {
+	current, err := userTeamRepo.Find(userId, teamId)
+	if errors.Is(err, domainerr.ErrUserTeamNotFound) {
+		return domainerr.ErrInvitationNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if current.GetState() != entity.Invited {
+		return domainerr.ErrInvitationNotFound
+	}
+	return domainerr.ErrInvitationExpired
+}
+
+func (userTeamRepo *UserTeamRepository) FindMembershipsByTeamId(teamId string) ([]*entity.UserTeam, error) {
+	var userTeams []*UserTeam
+	result := userTeamRepo.SqlHandler.Conn.Where("team_id = ? AND state = ?", teamId, entity.Member).Find(&userTeams)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	memberships := make([]*entity.UserTeam, 0, len(userTeams))
+	for _, userTeam := range userTeams {
+		memberships = append(memberships, userTeam.ToDomain())
+	}
+
+	return memberships, nil
+}
+
+func (userTeamRepo *UserTeamRepository) UpdateRole(userTeam *entity.UserTeam) (*entity.UserTeam, error) {
+	var dbUserTeam UserTeam
+	dbUserTeam.FromDomain(userTeam)
+
 	result := userTeamRepo.SqlHandler.Conn.Model(&dbUserTeam).
-		Where("user_id = ? AND team_id = ?", dbUserTeam.UserId, dbUserTeam.TeamId).
-		Update("state", dbUserTeam.State)
+		Where("user_id = ? AND team_id = ? AND state = ?", dbUserTeam.UserId, dbUserTeam.TeamId, entity.Member).
+		Update("role", dbUserTeam.Role)
 
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
 	if result.RowsAffected == 0 {
-		return nil, errors.New("user team not found")
+		return nil, domainerr.ErrUserTeamNotFound
 	}
 
 	return dbUserTeam.ToDomain(), nil
 }
 
+// TransferOwnership swaps the roles in one transaction, so that the team never has no owner or two.
+func (userTeamRepo *UserTeamRepository) TransferOwnership(teamId, fromUserId, toUserId string) error {
+	return userTeamRepo.SqlHandler.Conn.Transaction(func(tx *gorm.DB) error {
+		demoted := tx.Model(&UserTeam{}).
+			Where("user_id = ? AND team_id = ? AND role = ?", fromUserId, teamId, entity.RoleOwner).
+			Update("role", entity.RoleAdmin)
+		if demoted.Error != nil {
+			return demoted.Error
+		}
+		if demoted.RowsAffected == 0 {
+			return domainerr.ErrUserTeamNotFound
+		}
+
+		promoted := tx.Model(&UserTeam{}).
+			Where("user_id = ? AND team_id = ? AND state = ?", toUserId, teamId, entity.Member).
+			Update("role", entity.RoleOwner)
+		if promoted.Error != nil {
+			return promoted.Error
+		}
+		if promoted.RowsAffected == 0 {
+			return domainerr.ErrUserTeamNotFound
+		}
+		return nil
+	})
+}
+
 func (userTeamRepo *UserTeamRepository) Delete(userId, teamId string) error {
 	result := userTeamRepo.SqlHandler.Conn.Delete(&UserTeam{}, "user_id = ? AND team_id = ?", userId, teamId)
 	if result.Error != nil {
@@ -163,3 +324,21 @@ func (userTeamRepo *UserTeamRepository) IsMember(userId, teamId string) (bool, e
 
 	return userTeam.State == string(entity.Member), nil
 }
+
+func (userTeamRepo *UserTeamRepository) FindRole(userId, teamId string) (entity.TeamRole, error) {
+	var userTeam UserTeam
+	result := userTeamRepo.SqlHandler.Conn.First(&userTeam, "user_id = ? AND team_id = ?", userId, teamId)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return entity.RoleNone, nil
+	}
+
+	if result.Error != nil {
+		return entity.RoleNone, result.Error
+	}
+
+	if userTeam.State != string(entity.Member) {
+		return entity.RoleNone, nil
+	}
+	return entity.TeamRole(userTeam.Role), nil
+}
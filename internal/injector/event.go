@@ -0,0 +1,26 @@
+package injector
+
+import (
+	"CurlARC/internal/domain/repository"
+	"CurlARC/internal/handler"
+	"CurlARC/internal/infra"
+	"CurlARC/internal/usecase"
+)
+
+func InjectEventRepository() repository.EventRepository {
+	sqlHandler := InjectDB()
+	return infra.NewEventRepository(sqlHandler)
+}
+
+func InjectEventUsecase() usecase.EventUsecase {
+	eventRepo := InjectEventRepository()
+	recordRepo := InjectRecordRepository()
+	teamRepo := InjectTeamRepository()
+	userTeamRepo := InjectUserTeamRepository()
+	return usecase.NewEventUsecase(eventRepo, recordRepo, teamRepo, userTeamRepo)
+}
+
+func InjectEventHandler() handler.EventHandler {
+	eventUsecase := InjectEventUsecase()
+	return handler.NewEventHandler(eventUsecase)
+}
@@ -0,0 +1,26 @@
+package injector
+
+import (
+	"CurlARC/internal/domain/repository"
+	"CurlARC/internal/handler"
+	"CurlARC/internal/infra"
+	"CurlARC/internal/usecase"
+)
+
+func InjectPracticeSessionRepository() repository.PracticeSessionRepository {
+	sqlHandler := InjectDB()
+	return infra.NewPracticeSessionRepository(sqlHandler)
+}
+
+func InjectPracticeUsecase() usecase.PracticeUsecase {
+	practiceRepo := InjectPracticeSessionRepository()
+	recordRepo := InjectRecordRepository()
+	teamRepo := InjectTeamRepository()
+	userTeamRepo := InjectUserTeamRepository()
+	return usecase.NewPracticeUsecase(practiceRepo, recordRepo, teamRepo, userTeamRepo)
+}
+
+func InjectPracticeHandler() handler.PracticeHandler {
+	practiceUsecase := InjectPracticeUsecase()
+	return handler.NewPracticeHandler(practiceUsecase)
+}
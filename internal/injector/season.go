@@ -0,0 +1,26 @@
+package injector
+
+import (
+	"CurlARC/internal/domain/repository"
+	"CurlARC/internal/handler"
+	"CurlARC/internal/infra"
+	"CurlARC/internal/usecase"
+)
+
+func InjectSeasonRepository() repository.SeasonRepository {
+	sqlHandler := InjectDB()
+	return infra.NewSeasonRepository(sqlHandler)
+}
+
+func InjectSeasonUsecase() usecase.SeasonUsecase {
+	seasonRepo := InjectSeasonRepository()
+	recordRepo := InjectRecordRepository()
+	teamRepo := InjectTeamRepository()
+	userTeamRepo := InjectUserTeamRepository()
+	return usecase.NewSeasonUsecase(seasonRepo, recordRepo, teamRepo, userTeamRepo)
+}
+
+func InjectSeasonHandler() handler.SeasonHandler {
+	seasonUsecase := InjectSeasonUsecase()
+	return handler.NewSeasonHandler(seasonUsecase)
+}
@@ -0,0 +1,17 @@
+package injector
+
+import (
+	"CurlARC/internal/handler"
+	"CurlARC/internal/usecase"
+)
+
+func InjectStatsUsecase() usecase.StatsUsecase {
+	recordRepo := InjectRecordRepository()
+	userTeamRepo := InjectUserTeamRepository()
+	return usecase.NewStatsUsecase(recordRepo, userTeamRepo)
+}
+
+func InjectStatsHandler() handler.StatsHandler {
+	statsUsecase := InjectStatsUsecase()
+	return handler.NewStatsHandler(statsUsecase)
+}
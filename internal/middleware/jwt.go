@@ -4,7 +4,6 @@ import (
 	"net/http"
 	"strings"
 
-	"CurlARC/internal/handler/response"
 	"CurlARC/internal/utils"
 
 	"github.com/labstack/echo/v4"
@@ -16,25 +15,13 @@ func JWTMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 		// Authorizationヘッダーからトークンを取得
 		authHeader := c.Request().Header.Get("Authorization")
 		if authHeader == "" {
-			return c.JSON(http.StatusUnauthorized, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusUnauthorized,
-					Message: "missing token",
-				},
-			})
+			return echo.NewHTTPError(http.StatusUnauthorized, "missing token")
 		}
 
 		// Bearerスキームを確認し、トークン部分を抽出
 		bearerToken := strings.Split(authHeader, " ")
 		if len(bearerToken) != 2 || bearerToken[0] != "Bearer" {
-			return c.JSON(http.StatusUnauthorized, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusUnauthorized,
-					Message: "invalid token format",
-				},
-			})
+			return echo.NewHTTPError(http.StatusUnauthorized, "invalid token format")
 		}
 
 		tokenStr := bearerToken[1]
@@ -42,13 +29,7 @@ func JWTMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 		// JWTトークンの解析と検証
 		claims, err := utils.ParseBackendAccessToken(tokenStr) // jwtKeyを使って署名検証
 		if err != nil {
-			return c.JSON(http.StatusUnauthorized, response.ErrorResponse{
-				Status: "error",
-				Error: response.ErrorDetail{
-					Code:    http.StatusUnauthorized,
-					Message: err.Error(),
-				},
-			})
+			return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
 		}
 
 		// ユーザーIDをコンテキストに設定
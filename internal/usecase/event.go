@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"CurlARC/internal/domain/domainerr"
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/repository"
+	"time"
+)
+
+type EventUsecase interface {
+	CreateEvent(userId, teamId, name, venue string, format entity.EventFormat, startDate, endDate time.Time) (*entity.Event, error)
+	GetEventsByTeamId(userId, teamId string) ([]*entity.Event, error)
+	GetEvent(userId, teamId, eventId string) (*entity.Event, error)
+	UpdateEvent(userId, teamId, eventId string, name, venue *string, format *entity.EventFormat, startDate, endDate *time.Time) (*entity.Event, error)
+	DeleteEvent(userId, teamId, eventId string) error
+
+	// 試合の紐付け
+	AttachRecord(userId, teamId, eventId string, game entity.EventGame) (*entity.Event, error)
+	DetachRecord(userId, teamId, eventId, recordId string) (*entity.Event, error)
+	GetStandings(userId, teamId, eventId string) (*entity.Standings, error)
+}
+
+type eventUsecase struct {
+	eventRepo    repository.EventRepository
+	recordRepo   repository.RecordRepository
+	teamRepo     repository.TeamRepository
+	userTeamRepo repository.UserTeamRepository
+	policy       Policy
+}
+
+func NewEventUsecase(eventRepo repository.EventRepository, recordRepo repository.RecordRepository, teamRepo repository.TeamRepository, userTeamRepo repository.UserTeamRepository) EventUsecase {
+	return &eventUsecase{eventRepo: eventRepo, recordRepo: recordRepo, teamRepo: teamRepo, userTeamRepo: userTeamRepo, policy: NewPolicy(userTeamRepo)}
+}
+
+// findEvent returns an event of the team, after asking the policy whether the user may access the team.
+func (u *eventUsecase) findEvent(access func(userId, teamId string) error, userId, teamId, eventId string) (*entity.Event, error) {
+	if err := access(userId, teamId); err != nil {
+		return nil, err
+	}
+	event, err := u.eventRepo.FindById(eventId)
+	if err != nil {
+		return nil, err
+	}
+	if event.GetTeamId() != teamId {
+		return nil, domainerr.ErrEventNotFound.Errorf("event does not belong to the team")
+	}
+	return event, nil
+}
+
+func (u *eventUsecase) CreateEvent(userId, teamId, name, venue string, format entity.EventFormat, startDate, endDate time.Time) (*entity.Event, error) {
+	if err := u.policy.CanWriteTeam(userId, teamId); err != nil {
+		return nil, err
+	}
+	if _, err := u.teamRepo.FindById(teamId); err != nil {
+		return nil, err
+	}
+
+	event, err := entity.NewEvent(teamId, name, format,
+		entity.WithEventVenue(venue),
+		entity.WithEventDates(startDate, endDate),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.eventRepo.Save(event)
+}
+
+func (u *eventUsecase) GetEventsByTeamId(userId, teamId string) ([]*entity.Event, error) {
+	if err := u.policy.CanReadTeam(userId, teamId); err != nil {
+		return nil, err
+	}
+	return u.eventRepo.FindByTeamId(teamId)
+}
+
+func (u *eventUsecase) GetEvent(userId, teamId, eventId string) (*entity.Event, error) {
+	return u.findEvent(u.policy.CanReadTeam, userId, teamId, eventId)
+}
+
+// UpdateEvent changes the given fields and keeps the others.
+func (u *eventUsecase) UpdateEvent(userId, teamId, eventId string, name, venue *string, format *entity.EventFormat, startDate, endDate *time.Time) (*entity.Event, error) {
+	event, err := u.findEvent(u.policy.CanWriteTeam, userId, teamId, eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	if name != nil {
+		if err := event.SetName(*name); err != nil {
+			return nil, err
+		}
+	}
+	if venue != nil {
+		event.SetVenue(*venue)
+	}
+	if format != nil {
+		if err := event.SetFormat(*format); err != nil {
+			return nil, err
+		}
+	}
+	start, end := event.GetStartDate(), event.GetEndDate()
+	if startDate != nil {
+		start = *startDate
+	}
+	if endDate != nil {
+		end = *endDate
+	}
+	if err := event.SetDates(start, end); err != nil {
+		return nil, err
+	}
+
+	return u.eventRepo.Update(event)
+}
+
+func (u *eventUsecase) DeleteEvent(userId, teamId, eventId string) error {
+	if _, err := u.findEvent(u.policy.CanWriteTeam, userId, teamId, eventId); err != nil {
+		return err
+	}
+	return u.eventRepo.Delete(eventId)
+}
+
+// AttachRecord adds a record of the team to the event, or updates its round and last-stone draws.
+// A record can only be part of one event.
+func (u *eventUsecase) AttachRecord(userId, teamId, eventId string, game entity.EventGame) (*entity.Event, error) {
+	event, err := u.findEvent(u.policy.CanWriteTeam, userId, teamId, eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := u.recordRepo.FindByRecordId(game.RecordId)
+	if err != nil {
+		return nil, err
+	}
+	if record.GetTeamId() != teamId {
+		return nil, domainerr.ErrRecordNotFound.Errorf("record does not belong to the team")
+	}
+	attached, err := u.eventRepo.FindByRecordId(game.RecordId)
+	if err != nil {
+		return nil, err
+	}
+	if attached != nil && !attached.GetId().Equals(event.GetId()) {
+		return nil, domainerr.Conflict("RECORD_ALREADY_ATTACHED", "record is already attached to another event")
+	}
+
+	if err := event.AttachGame(game); err != nil {
+		return nil, err
+	}
+	return u.eventRepo.Update(event)
+}
+
+func (u *eventUsecase) DetachRecord(userId, teamId, eventId, recordId string) (*entity.Event, error) {
+	event, err := u.findEvent(u.policy.CanWriteTeam, userId, teamId, eventId)
+	if err != nil {
+		return nil, err
+	}
+	if err := event.DetachGame(recordId); err != nil {
+		return nil, err
+	}
+	return u.eventRepo.Update(event)
+}
+
+func (u *eventUsecase) GetStandings(userId, teamId, eventId string) (*entity.Standings, error) {
+	event, err := u.findEvent(u.policy.CanReadTeam, userId, teamId, eventId)
+	if err != nil {
+		return nil, err
+	}
+	team, err := u.teamRepo.FindById(teamId)
+	if err != nil {
+		return nil, err
+	}
+
+	recordIds := make([]string, 0, len(event.GetGames()))
+	for _, game := range event.GetGames() {
+		recordIds = append(recordIds, game.RecordId)
+	}
+	records, err := u.recordRepo.FindByIds(recordIds)
+	if err != nil {
+		return nil, err
+	}
+
+	standings := event.Standings(team.GetName(), *records)
+	return &standings, nil
+}
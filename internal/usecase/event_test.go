@@ -0,0 +1,242 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/usecase"
+	"CurlARC/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEventRepo := mock.NewMockEventRepository(ctrl)
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
+	mockUserTeamRepo := mock.NewMockUserTeamRepository(ctrl)
+
+	eventUsecase := usecase.NewEventUsecase(mockEventRepo, mockRecordRepo, mockTeamRepo, mockUserTeamRepo)
+
+	userId := "user-123"
+	teamId := "team-123"
+	start := time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 11, 3, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常系: イベントが作成される", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockTeamRepo.EXPECT().FindById(teamId).Return(entity.NewTeamFromDB(teamId, "Team A"), nil)
+		mockEventRepo.EXPECT().Save(gomock.Any()).DoAndReturn(func(e *entity.Event) (*entity.Event, error) {
+			return e, nil
+		})
+
+		event, err := eventUsecase.CreateEvent(userId, teamId, " Autumn Bonspiel ", "Karuizawa", entity.EventKnockout, start, end)
+		assert.NoError(t, err)
+		assert.Equal(t, "Autumn Bonspiel", event.GetName())
+		assert.Equal(t, entity.EventKnockout, event.GetFormat())
+		assert.Equal(t, teamId, event.GetTeamId())
+	})
+
+	t.Run("異常系: 終了日が開始日より前", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockTeamRepo.EXPECT().FindById(teamId).Return(entity.NewTeamFromDB(teamId, "Team A"), nil)
+
+		event, err := eventUsecase.CreateEvent(userId, teamId, "Autumn Bonspiel", "", entity.EventRoundRobin, end, start)
+		assert.Error(t, err)
+		assert.Nil(t, event)
+	})
+
+	t.Run("異常系: 形式が不正", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockTeamRepo.EXPECT().FindById(teamId).Return(entity.NewTeamFromDB(teamId, "Team A"), nil)
+
+		event, err := eventUsecase.CreateEvent(userId, teamId, "Autumn Bonspiel", "", entity.EventFormat("SWISS"), start, end)
+		assert.Error(t, err)
+		assert.Nil(t, event)
+	})
+
+	t.Run("異常系: ユーザーがチームに所属していない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleNone, nil)
+
+		event, err := eventUsecase.CreateEvent(userId, teamId, "Autumn Bonspiel", "", entity.EventRoundRobin, start, end)
+		assert.Error(t, err)
+		assert.Nil(t, event)
+	})
+}
+
+func TestAttachRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEventRepo := mock.NewMockEventRepository(ctrl)
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
+	mockUserTeamRepo := mock.NewMockUserTeamRepository(ctrl)
+
+	eventUsecase := usecase.NewEventUsecase(mockEventRepo, mockRecordRepo, mockTeamRepo, mockUserTeamRepo)
+
+	userId := "user-123"
+	teamId := "team-123"
+	lsd := 0.42
+	record := entity.NewRecordFromDB("record-1", teamId, "Team B", "Tokyo", entity.Win, time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC), nil, false, false, false)
+	newEvent := func(id string) *entity.Event {
+		return entity.NewEventFromDB(id, teamId, "League", "", time.Time{}, time.Time{}, entity.EventRoundRobin, nil)
+	}
+
+	t.Run("正常系: 試合がイベントに紐付けられる", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockEventRepo.EXPECT().FindById("event-1").Return(newEvent("event-1"), nil)
+		mockRecordRepo.EXPECT().FindByRecordId("record-1").Return(record, nil)
+		mockEventRepo.EXPECT().FindByRecordId("record-1").Return(nil, nil)
+		mockEventRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(e *entity.Event) (*entity.Event, error) {
+			return e, nil
+		})
+
+		event, err := eventUsecase.AttachRecord(userId, teamId, "event-1", entity.EventGame{RecordId: "record-1", Round: "Draw 1", FriendLSD: &lsd})
+		assert.NoError(t, err)
+		assert.Len(t, event.GetGames(), 1)
+		assert.Equal(t, "Draw 1", event.GetGames()[0].Round)
+	})
+
+	t.Run("異常系: 別のイベントに紐付いている", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockEventRepo.EXPECT().FindById("event-1").Return(newEvent("event-1"), nil)
+		mockRecordRepo.EXPECT().FindByRecordId("record-1").Return(record, nil)
+		mockEventRepo.EXPECT().FindByRecordId("record-1").Return(newEvent("event-2"), nil)
+
+		event, err := eventUsecase.AttachRecord(userId, teamId, "event-1", entity.EventGame{RecordId: "record-1"})
+		assert.Error(t, err)
+		assert.Nil(t, event)
+	})
+
+	t.Run("異常系: 他チームのレコード", func(t *testing.T) {
+		other := entity.NewRecordFromDB("record-2", "team-456", "Team B", "Tokyo", entity.Win, time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC), nil, false, false, false)
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockEventRepo.EXPECT().FindById("event-1").Return(newEvent("event-1"), nil)
+		mockRecordRepo.EXPECT().FindByRecordId("record-2").Return(other, nil)
+
+		event, err := eventUsecase.AttachRecord(userId, teamId, "event-1", entity.EventGame{RecordId: "record-2"})
+		assert.Error(t, err)
+		assert.Nil(t, event)
+	})
+}
+
+func TestGetStandings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEventRepo := mock.NewMockEventRepository(ctrl)
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
+	mockUserTeamRepo := mock.NewMockUserTeamRepository(ctrl)
+
+	eventUsecase := usecase.NewEventUsecase(mockEventRepo, mockRecordRepo, mockTeamRepo, mockUserTeamRepo)
+
+	userId := "user-123"
+	teamId := "team-123"
+	distance := func(d float64) *float64 { return &d }
+	date := time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	// we beat Team B 3-1, lose to Team C 0-2 and beat team b again 2-1; the game against Team D was abandoned
+	records := []entity.Record{
+		*entity.NewRecordFromDB("record-1", teamId, "Team B", "", entity.Win, date, []entity.DataPerEnd{{Score: 3}, {Score: -1}}, false, false, false),
+		*entity.NewRecordFromDB("record-2", teamId, "Team C", "", entity.Loss, date, []entity.DataPerEnd{{Score: -2}}, false, false, false),
+		*entity.NewRecordFromDB("record-3", teamId, " team b", "", entity.Win, date, []entity.DataPerEnd{{Score: 2}, {Score: -1}}, false, false, false),
+		*entity.NewRecordFromDB("record-4", teamId, "Team D", "", entity.Draw, date, []entity.DataPerEnd{{Score: 1}}, false, false, false,
+			entity.WithStatus(entity.GameAbandoned)),
+	}
+	games := []entity.EventGame{
+		{RecordId: "record-1", FriendLSD: distance(0.30), EnemyLSD: distance(0.50)},
+		{RecordId: "record-2", FriendLSD: distance(1.20), EnemyLSD: distance(0.10)},
+		{RecordId: "record-3", FriendLSD: distance(0.40)},
+		{RecordId: "record-4"},
+	}
+
+	t.Run("正常系: 総当たり戦の順位が計算される", func(t *testing.T) {
+		event := entity.NewEventFromDB("event-1", teamId, "League", "", date, date, entity.EventRoundRobin, games)
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockEventRepo.EXPECT().FindById("event-1").Return(event, nil)
+		mockTeamRepo.EXPECT().FindById(teamId).Return(entity.NewTeamFromDB(teamId, "Team A"), nil)
+		mockRecordRepo.EXPECT().FindByIds([]string{"record-1", "record-2", "record-3", "record-4"}).Return(&records, nil)
+
+		standings, err := eventUsecase.GetStandings(userId, teamId, "event-1")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, standings.Abandoned)
+		assert.Len(t, standings.Rows, 3)
+
+		us := standings.Rows[0]
+		assert.Equal(t, "Team A", us.Team)
+		assert.True(t, us.Friend)
+		assert.Equal(t, 2, us.Wins)
+		assert.Equal(t, 1, us.Losses)
+		assert.Equal(t, 5, us.PointsFor)
+		assert.Equal(t, 4, us.PointsAgainst)
+		// the worst draw (1.20) is dropped from the draw shot challenge
+		assert.InDelta(t, 0.35, *us.DrawShotChallenge, 1e-9)
+		assert.InDelta(t, 0.30, *us.BestLSD, 1e-9)
+
+		// Team C and Team B have one win each fewer than us; Team C won its only game
+		assert.Equal(t, "Team C", standings.Rows[1].Team)
+		assert.Equal(t, 2, standings.Rows[1].Rank)
+		assert.Equal(t, "Team B", standings.Rows[2].Team)
+		assert.Equal(t, 2, standings.Rows[2].Games)
+		assert.Equal(t, 0, standings.Rows[2].Wins)
+		assert.InDelta(t, 0.50, *standings.Rows[2].DrawShotChallenge, 1e-9)
+	})
+
+	t.Run("正常系: 勝敗が並んだらドローショットチャレンジで順位が決まる", func(t *testing.T) {
+		// Team B and Team D both beat us, Team B with the closer draw
+		tied := []entity.EventGame{
+			{RecordId: "record-1", FriendLSD: distance(0.80), EnemyLSD: distance(0.20)},
+			{RecordId: "record-2", FriendLSD: distance(0.60), EnemyLSD: distance(0.50)},
+			{RecordId: "record-3", FriendLSD: distance(0.70)},
+		}
+		tiedRecords := []entity.Record{
+			*entity.NewRecordFromDB("record-1", teamId, "Team B", "", entity.Loss, date, []entity.DataPerEnd{{Score: -1}}, false, false, false),
+			*entity.NewRecordFromDB("record-2", teamId, "Team D", "", entity.Loss, date, []entity.DataPerEnd{{Score: -2}}, false, false, false),
+			*entity.NewRecordFromDB("record-3", teamId, "Team C", "", entity.Win, date, []entity.DataPerEnd{{Score: 1}}, false, false, false),
+		}
+		event := entity.NewEventFromDB("event-1", teamId, "League", "", date, date, entity.EventRoundRobin, tied)
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockEventRepo.EXPECT().FindById("event-1").Return(event, nil)
+		mockTeamRepo.EXPECT().FindById(teamId).Return(entity.NewTeamFromDB(teamId, "Team A"), nil)
+		mockRecordRepo.EXPECT().FindByIds([]string{"record-1", "record-2", "record-3"}).Return(&tiedRecords, nil)
+
+		standings, err := eventUsecase.GetStandings(userId, teamId, "event-1")
+		assert.NoError(t, err)
+		assert.Equal(t, "Team B", standings.Rows[0].Team)
+		assert.Equal(t, "Team D", standings.Rows[1].Team)
+		assert.Equal(t, 2, standings.Rows[1].Rank)
+		assert.Equal(t, "Team A", standings.Rows[2].Team)
+		assert.Equal(t, "Team C", standings.Rows[3].Team)
+	})
+
+	t.Run("正常系: ノックアウトでは敗退したチームが後ろに並ぶ", func(t *testing.T) {
+		event := entity.NewEventFromDB("event-1", teamId, "Cup", "", date, date, entity.EventKnockout, games)
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockEventRepo.EXPECT().FindById("event-1").Return(event, nil)
+		mockTeamRepo.EXPECT().FindById(teamId).Return(entity.NewTeamFromDB(teamId, "Team A"), nil)
+		mockRecordRepo.EXPECT().FindByIds(gomock.Any()).Return(&records, nil)
+
+		standings, err := eventUsecase.GetStandings(userId, teamId, "event-1")
+		assert.NoError(t, err)
+		assert.Equal(t, "Team C", standings.Rows[0].Team)
+		assert.False(t, standings.Rows[0].Eliminated)
+		assert.True(t, standings.Rows[1].Eliminated)
+	})
+
+	t.Run("異常系: 他チームのイベント", func(t *testing.T) {
+		event := entity.NewEventFromDB("event-1", "team-456", "League", "", date, date, entity.EventRoundRobin, games)
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockEventRepo.EXPECT().FindById("event-1").Return(event, nil)
+
+		standings, err := eventUsecase.GetStandings(userId, teamId, "event-1")
+		assert.Error(t, err)
+		assert.Nil(t, standings)
+	})
+}
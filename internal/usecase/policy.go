@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"CurlARC/internal/domain/domainerr"
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/repository"
+	"errors"
+)
+
+// Policy decides what a user may do with the data of a team, following their role in it. Viewers read all of it,
+// members also write it, admins also manage the members, and the owner also deletes or hands over the team.
+// Other users only read the records the team made public.
+//
+// Every usecase acting on a team or a record asks the policy first, with the ID of the user making the request.
+type Policy interface {
+	CanReadTeam(userId, teamId string) error
+	CanWriteTeam(userId, teamId string) error
+	CanReadRecord(userId string, record *entity.Record) error
+	CanWriteRecord(userId string, record *entity.Record) error
+	CanManageTeam(userId, teamId string) error
+	CanOwnTeam(userId, teamId string) error
+}
+
+type policy struct {
+	userTeamRepo repository.UserTeamRepository
+}
+
+func NewPolicy(userTeamRepo repository.UserTeamRepository) Policy {
+	return &policy{userTeamRepo: userTeamRepo}
+}
+
+// checkRole lets through members whose role is at least the given one.
+func (p *policy) checkRole(userId, teamId string, least entity.TeamRole) error {
+	role, err := p.userTeamRepo.FindRole(userId, teamId)
+	if err != nil {
+		return err
+	}
+	if role == entity.RoleNone {
+		return domainerr.ErrNotTeamMember
+	}
+	if !role.AtLeast(least) {
+		return domainerr.ErrInsufficientRole.Errorf("the %s role may not do this, it takes %s", role, least)
+	}
+	return nil
+}
+
+func (p *policy) CanReadTeam(userId, teamId string) error {
+	return p.checkRole(userId, teamId, entity.RoleViewer)
+}
+
+func (p *policy) CanWriteTeam(userId, teamId string) error {
+	return p.checkRole(userId, teamId, entity.RoleMember)
+}
+
+func (p *policy) CanManageTeam(userId, teamId string) error {
+	return p.checkRole(userId, teamId, entity.RoleAdmin)
+}
+
+func (p *policy) CanOwnTeam(userId, teamId string) error {
+	return p.checkRole(userId, teamId, entity.RoleOwner)
+}
+
+// CanReadRecord lets anyone read a public record. A private record is reported as missing to other users,
+// so that they cannot tell it exists.
+func (p *policy) CanReadRecord(userId string, record *entity.Record) error {
+	if record.IsPublic() {
+		return nil
+	}
+	if err := p.CanReadTeam(userId, record.GetTeamId()); err != nil {
+		if errors.Is(err, domainerr.ErrNotTeamMember) {
+			return domainerr.ErrRecordNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (p *policy) CanWriteRecord(userId string, record *entity.Record) error {
+	return p.CanWriteTeam(userId, record.GetTeamId())
+}
+
+// deniedAs names the user turned away after what they were doing, as in "appender is not a member of the team".
+func deniedAs(err error, actor string) error {
+	if errors.Is(err, domainerr.ErrNotTeamMember) {
+		return domainerr.ErrNotTeamMember.Errorf("%s is not a member of the team", actor)
+	}
+	return err
+}
@@ -0,0 +1,378 @@
+package usecase_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"CurlARC/internal/domain/domainerr"
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/geometry"
+	"CurlARC/internal/domain/repository"
+	"CurlARC/internal/usecase"
+	"CurlARC/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserTeamRepo := mock.NewMockUserTeamRepository(ctrl)
+	policy := usecase.NewPolicy(mockUserTeamRepo)
+
+	private := entity.NewRecordFromDB("record-123", "team-123", "Team B", "Tokyo", entity.Win, time.Now(), nil, false, false, false)
+	public := entity.NewRecordFromDB("record-456", "team-123", "Team B", "Tokyo", entity.Win, time.Now(), nil, false, false, true)
+
+	t.Run("正常系: メンバーはチームとレコードを読み書きできる", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-123", "team-123").Return(entity.RoleMember, nil).Times(4)
+
+		assert.NoError(t, policy.CanReadTeam("user-123", "team-123"))
+		assert.NoError(t, policy.CanWriteTeam("user-123", "team-123"))
+		assert.NoError(t, policy.CanReadRecord("user-123", private))
+		assert.NoError(t, policy.CanWriteRecord("user-123", private))
+	})
+
+	t.Run("異常系: 閲覧者はチームとレコードを読めるが書き込めない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-123", "team-123").Return(entity.RoleViewer, nil).Times(4)
+
+		assert.NoError(t, policy.CanReadTeam("user-123", "team-123"))
+		assert.NoError(t, policy.CanReadRecord("user-123", private))
+		assert.ErrorIs(t, policy.CanWriteTeam("user-123", "team-123"), domainerr.ErrInsufficientRole)
+		assert.ErrorIs(t, policy.CanWriteRecord("user-123", private), domainerr.ErrInsufficientRole)
+	})
+
+	t.Run("正常系: 役割ごとにチームの管理と所有が許される", func(t *testing.T) {
+		roles := []struct {
+			role           entity.TeamRole
+			manage, owning bool
+		}{
+			{entity.RoleViewer, false, false},
+			{entity.RoleMember, false, false},
+			{entity.RoleAdmin, true, false},
+			{entity.RoleOwner, true, true},
+		}
+		for _, tt := range roles {
+			mockUserTeamRepo.EXPECT().FindRole("user-123", "team-123").Return(tt.role, nil).Times(2)
+
+			assert.Equal(t, tt.manage, policy.CanManageTeam("user-123", "team-123") == nil, "%s manages the team", tt.role)
+			assert.Equal(t, tt.owning, policy.CanOwnTeam("user-123", "team-123") == nil, "%s owns the team", tt.role)
+		}
+	})
+
+	t.Run("正常系: 公開されたレコードは誰でも読める", func(t *testing.T) {
+		assert.NoError(t, policy.CanReadRecord("user-456", public))
+	})
+
+	t.Run("異常系: メンバー以外はチームを読み書きできない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-456", "team-123").Return(entity.RoleNone, nil).Times(3)
+
+		assert.ErrorIs(t, policy.CanReadTeam("user-456", "team-123"), domainerr.ErrNotTeamMember)
+		assert.ErrorIs(t, policy.CanWriteTeam("user-456", "team-123"), domainerr.ErrNotTeamMember)
+		assert.ErrorIs(t, policy.CanWriteRecord("user-456", public), domainerr.ErrNotTeamMember)
+	})
+
+	t.Run("異常系: 非公開のレコードはメンバー以外には存在しないものとして扱う", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-456", "team-123").Return(entity.RoleNone, nil)
+
+		assert.ErrorIs(t, policy.CanReadRecord("user-456", private), domainerr.ErrRecordNotFound)
+	})
+
+	t.Run("異常系: メンバーの確認に失敗する", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-123", "team-123").Return(entity.RoleNone, errors.New("connection refused"))
+
+		err := policy.CanReadRecord("user-123", private)
+		assert.EqualError(t, err, "connection refused")
+	})
+}
+
+// TestEveryUsecaseAsksThePolicy calls every usecase method behind the team and record routes as a user outside the team.
+// Each one must turn the user away without changing anything, or only return public records. A method added to one of
+// the interfaces without a case here makes the test fail.
+func TestEveryUsecaseAsksThePolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockUserTeamRepo := mock.NewMockUserTeamRepository(ctrl)
+	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockEventRepo := mock.NewMockEventRepository(ctrl)
+	mockSeasonRepo := mock.NewMockSeasonRepository(ctrl)
+	mockPracticeRepo := mock.NewMockPracticeSessionRepository(ctrl)
+
+	recordUsecase := usecase.NewRecordUsecase(mockRecordRepo, mockUserTeamRepo, mockTeamRepo)
+	teamUsecase := usecase.NewTeamUsecase(mockTeamRepo, mockUserRepo, mockUserTeamRepo)
+	statsUsecase := usecase.NewStatsUsecase(mockRecordRepo, mockUserTeamRepo)
+	eventUsecase := usecase.NewEventUsecase(mockEventRepo, mockRecordRepo, mockTeamRepo, mockUserTeamRepo)
+	seasonUsecase := usecase.NewSeasonUsecase(mockSeasonRepo, mockRecordRepo, mockTeamRepo, mockUserTeamRepo)
+	practiceUsecase := usecase.NewPracticeUsecase(mockPracticeRepo, mockRecordRepo, mockTeamRepo, mockUserTeamRepo)
+
+	outsider := "user-outsider"
+	teamId := "team-123"
+	recordId := "record-123"
+	date := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	team := entity.NewTeam("Team A")
+	user := entity.NewUser("Outsider", "outsider@gmail.com")
+	private := entity.NewRecordFromDB(recordId, teamId, "Team B", "Tokyo", entity.Win, date, nil, false, false, false)
+	public := entity.NewRecordFromDB("record-456", teamId, "Team C", "Tokyo", entity.Loss, date, nil, false, false, true)
+	event, _ := entity.NewEvent(teamId, "Japan Championship", entity.EventRoundRobin)
+	season, _ := entity.NewSeason(teamId, "2024", date, date.AddDate(1, 0, 0))
+	session, _ := entity.NewPracticeSession(teamId, date)
+
+	// lookups succeed, so that only the policy can turn the user away; writes are not expected at all
+	mockUserTeamRepo.EXPECT().FindRole(outsider, gomock.Any()).Return(entity.RoleNone, nil).AnyTimes()
+	mockRecordRepo.EXPECT().FindByRecordId(gomock.Any()).Return(private, nil).AnyTimes()
+	mockTeamRepo.EXPECT().FindById(gomock.Any()).Return(team, nil).AnyTimes()
+	mockUserRepo.EXPECT().FindById(gomock.Any()).Return(user, nil).AnyTimes()
+	mockEventRepo.EXPECT().FindById(gomock.Any()).Return(event, nil).AnyTimes()
+	mockSeasonRepo.EXPECT().FindById(gomock.Any()).Return(season, nil).AnyTimes()
+	mockPracticeRepo.EXPECT().FindById(gomock.Any()).Return(session, nil).AnyTimes()
+
+	name := "Renamed"
+	refused := map[string]func() error{
+		"RecordUsecase.CreateRecord": func() error {
+			_, err := recordUsecase.CreateRecord(outsider, teamId, "Team B", "Tokyo", entity.Win, date, entity.GameFormatEightEnds)
+			return err
+		},
+		"RecordUsecase.AppendEndData": func() error {
+			_, err := recordUsecase.AppendEndData(recordId, outsider, nil, entity.FreeGuardZoneFlag)
+			return err
+		},
+		"RecordUsecase.GetRecordDetailsByRecordId": func() error {
+			_, err := recordUsecase.GetRecordDetailsByRecordId(recordId, outsider)
+			return err
+		},
+		"RecordUsecase.GetScoreboard": func() error {
+			_, err := recordUsecase.GetScoreboard(recordId, outsider)
+			return err
+		},
+		"RecordUsecase.UpdateRecord": func() error {
+			_, err := recordUsecase.UpdateRecord(recordId, outsider, entity.Win, "Team B", "Tokyo", nil, date, false, false, true, "", entity.FreeGuardZoneFlag)
+			return err
+		},
+		"RecordUsecase.DeleteRecord": func() error {
+			return recordUsecase.DeleteRecord(recordId, outsider)
+		},
+		"RecordUsecase.SetVisibility": func() error {
+			_, err := recordUsecase.SetVisibility(recordId, outsider, true)
+			return err
+		},
+		"RecordUsecase.SetLineup": func() error {
+			_, err := recordUsecase.SetLineup(recordId, outsider, entity.Lineup{})
+			return err
+		},
+		"RecordUsecase.AddSubstitution": func() error {
+			_, err := recordUsecase.AddSubstitution(recordId, outsider, entity.Substitution{})
+			return err
+		},
+
+		"TeamUsecase.UpdateTeam": func() error {
+			_, err := teamUsecase.UpdateTeam(teamId, outsider, name)
+			return err
+		},
+		"TeamUsecase.DeleteTeam": func() error {
+			return teamUsecase.DeleteTeam(teamId, outsider)
+		},
+		"TeamUsecase.InviteUsers": func() error {
+			return teamUsecase.InviteUsers(teamId, outsider, []string{"newcommer@gmail.com"})
+		},
+		"TeamUsecase.RemoveMember": func() error {
+			return teamUsecase.RemoveMember(teamId, outsider, "user-123")
+		},
+		"TeamUsecase.GetDetailsByTeamId": func() error {
+			_, err := teamUsecase.GetDetailsByTeamId(teamId, outsider)
+			return err
+		},
+		"TeamUsecase.GetMembersByTeamId": func() error {
+			_, err := teamUsecase.GetMembersByTeamId(teamId, outsider)
+			return err
+		},
+		"TeamUsecase.GetInvitedUsersByTeamId": func() error {
+			_, err := teamUsecase.GetInvitedUsersByTeamId(teamId, outsider)
+			return err
+		},
+		"TeamUsecase.GetRolesByTeamId": func() error {
+			_, err := teamUsecase.GetRolesByTeamId(teamId, outsider)
+			return err
+		},
+		"TeamUsecase.SetRole": func() error {
+			return teamUsecase.SetRole(teamId, outsider, "user-123", entity.RoleAdmin)
+		},
+		"TeamUsecase.RevokeInvitation": func() error {
+			return teamUsecase.RevokeInvitation(teamId, outsider, "user-123")
+		},
+		"TeamUsecase.TransferOwnership": func() error {
+			return teamUsecase.TransferOwnership(teamId, outsider, "user-123")
+		},
+
+		"StatsUsecase.GetShooterStats": func() error {
+			_, err := statsUsecase.GetShooterStats(outsider, teamId, usecase.StatsFilter{})
+			return err
+		},
+		"StatsUsecase.GetHammerStats": func() error {
+			_, err := statsUsecase.GetHammerStats(outsider, teamId, usecase.StatsFilter{})
+			return err
+		},
+		"StatsUsecase.GetHeadToHead": func() error {
+			_, err := statsUsecase.GetHeadToHead(outsider, teamId, usecase.StatsFilter{})
+			return err
+		},
+		"StatsUsecase.GetHeatmap": func() error {
+			_, err := statsUsecase.GetHeatmap(outsider, teamId, usecase.StatsFilter{}, usecase.HeatmapQuery{Grid: geometry.DefaultPolarGrid})
+			return err
+		},
+		"StatsUsecase.GetWinProbability": func() error {
+			_, err := statsUsecase.GetWinProbability(outsider, teamId, entity.GameFormatEightEnds, 1, 0, true)
+			return err
+		},
+		"StatsUsecase.GetWinProbabilityCurve": func() error {
+			_, err := statsUsecase.GetWinProbabilityCurve(outsider, recordId)
+			return err
+		},
+		"StatsUsecase.FindSimilarPositions": func() error {
+			_, err := statsUsecase.FindSimilarPositions(outsider, teamId, usecase.PositionQuery{})
+			return err
+		},
+
+		"EventUsecase.CreateEvent": func() error {
+			_, err := eventUsecase.CreateEvent(outsider, teamId, "Japan Championship", "Tokyo", entity.EventRoundRobin, date, date)
+			return err
+		},
+		"EventUsecase.GetEventsByTeamId": func() error {
+			_, err := eventUsecase.GetEventsByTeamId(outsider, teamId)
+			return err
+		},
+		"EventUsecase.GetEvent": func() error {
+			_, err := eventUsecase.GetEvent(outsider, teamId, event.GetId().Value())
+			return err
+		},
+		"EventUsecase.UpdateEvent": func() error {
+			_, err := eventUsecase.UpdateEvent(outsider, teamId, event.GetId().Value(), &name, nil, nil, nil, nil)
+			return err
+		},
+		"EventUsecase.DeleteEvent": func() error {
+			return eventUsecase.DeleteEvent(outsider, teamId, event.GetId().Value())
+		},
+		"EventUsecase.AttachRecord": func() error {
+			_, err := eventUsecase.AttachRecord(outsider, teamId, event.GetId().Value(), entity.EventGame{RecordId: recordId})
+			return err
+		},
+		"EventUsecase.DetachRecord": func() error {
+			_, err := eventUsecase.DetachRecord(outsider, teamId, event.GetId().Value(), recordId)
+			return err
+		},
+		"EventUsecase.GetStandings": func() error {
+			_, err := eventUsecase.GetStandings(outsider, teamId, event.GetId().Value())
+			return err
+		},
+
+		"SeasonUsecase.CreateSeason": func() error {
+			_, err := seasonUsecase.CreateSeason(outsider, teamId, "2025", date, date.AddDate(1, 0, 0))
+			return err
+		},
+		"SeasonUsecase.GetSeasonsByTeamId": func() error {
+			_, err := seasonUsecase.GetSeasonsByTeamId(outsider, teamId)
+			return err
+		},
+		"SeasonUsecase.UpdateSeason": func() error {
+			_, err := seasonUsecase.UpdateSeason(outsider, teamId, season.GetId().Value(), &name, nil, nil)
+			return err
+		},
+		"SeasonUsecase.DeleteSeason": func() error {
+			return seasonUsecase.DeleteSeason(outsider, teamId, season.GetId().Value())
+		},
+		"SeasonUsecase.GetSeasonSummary": func() error {
+			_, err := seasonUsecase.GetSeasonSummary(outsider, teamId, season.GetId().Value())
+			return err
+		},
+
+		"PracticeUsecase.CreatePracticeSession": func() error {
+			_, err := practiceUsecase.CreatePracticeSession(outsider, teamId, date, "Tokyo", nil, nil)
+			return err
+		},
+		"PracticeUsecase.GetPracticeSessionsByTeamId": func() error {
+			_, err := practiceUsecase.GetPracticeSessionsByTeamId(outsider, teamId)
+			return err
+		},
+		"PracticeUsecase.GetPracticeSession": func() error {
+			_, err := practiceUsecase.GetPracticeSession(outsider, teamId, session.GetId().Value())
+			return err
+		},
+		"PracticeUsecase.UpdatePracticeSession": func() error {
+			_, err := practiceUsecase.UpdatePracticeSession(outsider, teamId, session.GetId().Value(), nil, &name, nil, nil)
+			return err
+		},
+		"PracticeUsecase.DeletePracticeSession": func() error {
+			return practiceUsecase.DeletePracticeSession(outsider, teamId, session.GetId().Value())
+		},
+		"PracticeUsecase.GetPracticeStats": func() error {
+			_, err := practiceUsecase.GetPracticeStats(outsider, teamId, usecase.StatsFilter{})
+			return err
+		},
+		"PracticeUsecase.GetPlayerReports": func() error {
+			_, err := practiceUsecase.GetPlayerReports(outsider, teamId, usecase.StatsFilter{})
+			return err
+		},
+	}
+
+	// listings a user outside the team may call, which only return public records
+	publicOnly := map[string]func(t *testing.T){
+		"RecordUsecase.GetRecordIndicesByTeamId": func(t *testing.T) {
+			mockRecordRepo.EXPECT().FindIndicesByTeamId(teamId, gomock.Any()).DoAndReturn(
+				func(_ string, query repository.RecordQuery) (*repository.RecordIndexPage, error) {
+					assert.True(t, query.IsPublic != nil && *query.IsPublic)
+					return &repository.RecordIndexPage{}, nil
+				})
+			_, err := recordUsecase.GetRecordIndicesByTeamId(outsider, teamId, repository.RecordQuery{})
+			assert.NoError(t, err)
+		},
+		"RecordUsecase.GetRecordsByTeamId": func(t *testing.T) {
+			mockRecordRepo.EXPECT().FindByTeamId(teamId).Return(&[]entity.Record{*private, *public}, nil)
+			records, err := recordUsecase.GetRecordsByTeamId(outsider, teamId)
+			assert.NoError(t, err)
+			assert.Equal(t, []entity.Record{*public}, *records)
+		},
+	}
+
+	// methods acting only on the user making the request, or listing nothing more than team names
+	exempt := map[string]bool{
+		"TeamUsecase.CreateTeam":        true,
+		"TeamUsecase.GetAllTeams":       true,
+		"TeamUsecase.AcceptInvitation":  true,
+		"TeamUsecase.DeclineInvitation": true,
+		"TeamUsecase.GetTeamsByUserId":  true,
+		"TeamUsecase.GetInvitedTeams":   true,
+	}
+
+	interfaces := []reflect.Type{
+		reflect.TypeOf((*usecase.RecordUsecase)(nil)).Elem(),
+		reflect.TypeOf((*usecase.TeamUsecase)(nil)).Elem(),
+		reflect.TypeOf((*usecase.StatsUsecase)(nil)).Elem(),
+		reflect.TypeOf((*usecase.EventUsecase)(nil)).Elem(),
+		reflect.TypeOf((*usecase.SeasonUsecase)(nil)).Elem(),
+		reflect.TypeOf((*usecase.PracticeUsecase)(nil)).Elem(),
+	}
+	for _, i := range interfaces {
+		for m := 0; m < i.NumMethod(); m++ {
+			method := i.Name() + "." + i.Method(m).Name
+			_, isRefused := refused[method]
+			_, isPublicOnly := publicOnly[method]
+			assert.True(t, isRefused || isPublicOnly || exempt[method], "%s has no policy case", method)
+		}
+	}
+
+	for method, call := range refused {
+		t.Run("異常系: チーム外のユーザーは拒否される: "+method, func(t *testing.T) {
+			err := call()
+			assert.True(t, domainerr.IsKind(err, domainerr.KindForbidden) || domainerr.IsKind(err, domainerr.KindNotFound),
+				"%s returned %v", method, err)
+		})
+	}
+	for method, check := range publicOnly {
+		t.Run("正常系: チーム外のユーザーには公開されたレコードだけが返される: "+method, check)
+	}
+}
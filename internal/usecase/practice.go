@@ -0,0 +1,354 @@
+package usecase
+
+import (
+	"CurlARC/internal/domain/domainerr"
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/geometry"
+	"CurlARC/internal/domain/repository"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+type PracticeUsecase interface {
+	CreatePracticeSession(userId, teamId string, date time.Time, place string, attendees []entity.LineupPlayer, drills []entity.Drill) (*entity.PracticeSession, error)
+	GetPracticeSessionsByTeamId(userId, teamId string) ([]*entity.PracticeSession, error)
+	GetPracticeSession(userId, teamId, sessionId string) (*entity.PracticeSession, error)
+	UpdatePracticeSession(userId, teamId, sessionId string, date *time.Time, place *string, attendees *[]entity.LineupPlayer, drills *[]entity.Drill) (*entity.PracticeSession, error)
+	DeletePracticeSession(userId, teamId, sessionId string) error
+	GetPracticeStats(userId, teamId string, filter StatsFilter) (*PracticeStats, error)
+	GetPlayerReports(userId, teamId string, filter StatsFilter) ([]PlayerReport, error)
+}
+
+// DrillStats summarises the throws of one drill. In a positional drill, the throws whose stone stayed in play
+// are measured by their distance to the target, in metres; the others are counted as out of play.
+type DrillStats struct {
+	SuccessRate
+	Measured     int
+	MeanDistance float64
+	OutOfPlay    int
+}
+
+// PlayerPracticeStats holds the practice results of one player. Attendees are resolved to players,
+// so a registered user is tracked by ID whatever name the throws were recorded under.
+type PlayerPracticeStats struct {
+	Shooter  string
+	Player   *entity.LineupPlayer
+	Attended int
+	Overall  SuccessRate
+	ByDrill  map[entity.DrillType]DrillStats
+}
+
+// PracticeStats reports on the practice sessions of a team. It never includes games.
+type PracticeStats struct {
+	Sessions int
+	Players  []PlayerPracticeStats // sorted by shooter
+}
+
+// PlayerReport puts the game and practice results of one player side by side.
+// Combined is the average success rate over the shots of games and the throws of practice.
+type PlayerReport struct {
+	Shooter  string
+	Player   *entity.LineupPlayer
+	Match    *ShooterStats
+	Practice *PlayerPracticeStats
+	Combined SuccessRate
+}
+
+type practiceUsecase struct {
+	practiceRepo repository.PracticeSessionRepository
+	recordRepo   repository.RecordRepository
+	teamRepo     repository.TeamRepository
+	userTeamRepo repository.UserTeamRepository
+	policy       Policy
+}
+
+func NewPracticeUsecase(practiceRepo repository.PracticeSessionRepository, recordRepo repository.RecordRepository, teamRepo repository.TeamRepository, userTeamRepo repository.UserTeamRepository) PracticeUsecase {
+	return &practiceUsecase{practiceRepo: practiceRepo, recordRepo: recordRepo, teamRepo: teamRepo, userTeamRepo: userTeamRepo, policy: NewPolicy(userTeamRepo)}
+}
+
+// checkAttendees checks every registered attendee belongs to the team.
+func (u *practiceUsecase) checkAttendees(teamId string, session *entity.PracticeSession) error {
+	for _, attendeeId := range session.AttendeeUserIds() {
+		isMember, err := u.userTeamRepo.IsMember(attendeeId, teamId)
+		if err != nil {
+			return err
+		}
+		if !isMember {
+			return domainerr.Invalid("attendees", "attendee %s is not a member of the team", attendeeId)
+		}
+	}
+	return nil
+}
+
+// findSession returns a practice session of the team, after asking the policy whether the user may access the team.
+func (u *practiceUsecase) findSession(access func(userId, teamId string) error, userId, teamId, sessionId string) (*entity.PracticeSession, error) {
+	if err := access(userId, teamId); err != nil {
+		return nil, err
+	}
+	session, err := u.practiceRepo.FindById(sessionId)
+	if err != nil {
+		return nil, err
+	}
+	if session.GetTeamId() != teamId {
+		return nil, domainerr.ErrPracticeSessionNotFound.Errorf("practice session does not belong to the team")
+	}
+	return session, nil
+}
+
+func (u *practiceUsecase) CreatePracticeSession(userId, teamId string, date time.Time, place string, attendees []entity.LineupPlayer, drills []entity.Drill) (*entity.PracticeSession, error) {
+	if err := u.policy.CanWriteTeam(userId, teamId); err != nil {
+		return nil, err
+	}
+	if _, err := u.teamRepo.FindById(teamId); err != nil {
+		return nil, err
+	}
+
+	session, err := entity.NewPracticeSession(teamId, date,
+		entity.WithPracticePlace(place),
+		entity.WithAttendees(attendees),
+		entity.WithDrills(drills),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.checkAttendees(teamId, session); err != nil {
+		return nil, err
+	}
+	return u.practiceRepo.Save(session)
+}
+
+func (u *practiceUsecase) GetPracticeSessionsByTeamId(userId, teamId string) ([]*entity.PracticeSession, error) {
+	if err := u.policy.CanReadTeam(userId, teamId); err != nil {
+		return nil, err
+	}
+	return u.practiceRepo.FindByTeamId(teamId)
+}
+
+func (u *practiceUsecase) GetPracticeSession(userId, teamId, sessionId string) (*entity.PracticeSession, error) {
+	return u.findSession(u.policy.CanReadTeam, userId, teamId, sessionId)
+}
+
+// UpdatePracticeSession changes the given fields and keeps the others. Attendees and drills are replaced as a whole.
+func (u *practiceUsecase) UpdatePracticeSession(userId, teamId, sessionId string, date *time.Time, place *string, attendees *[]entity.LineupPlayer, drills *[]entity.Drill) (*entity.PracticeSession, error) {
+	session, err := u.findSession(u.policy.CanWriteTeam, userId, teamId, sessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if date != nil {
+		if err := session.SetDate(*date); err != nil {
+			return nil, err
+		}
+	}
+	if place != nil {
+		session.SetPlace(*place)
+	}
+	if attendees != nil {
+		if err := session.SetAttendees(*attendees); err != nil {
+			return nil, err
+		}
+		if err := u.checkAttendees(teamId, session); err != nil {
+			return nil, err
+		}
+	}
+	if drills != nil {
+		if err := session.SetDrills(*drills); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.practiceRepo.Update(session)
+}
+
+func (u *practiceUsecase) DeletePracticeSession(userId, teamId, sessionId string) error {
+	if _, err := u.findSession(u.policy.CanWriteTeam, userId, teamId, sessionId); err != nil {
+		return err
+	}
+	return u.practiceRepo.Delete(sessionId)
+}
+
+// findSessions returns the practice sessions of the team held within the period of the filter.
+func (u *practiceUsecase) findSessions(teamId string, filter StatsFilter) ([]*entity.PracticeSession, error) {
+	sessions, err := u.practiceRepo.FindByTeamId(teamId)
+	if err != nil {
+		return nil, err
+	}
+	var matched []*entity.PracticeSession
+	for _, session := range sessions {
+		if filter.coversDate(session.GetDate()) {
+			matched = append(matched, session)
+		}
+	}
+	return matched, nil
+}
+
+// GetPracticeStats computes the practice results of every player. Only the period of the filter applies.
+func (u *practiceUsecase) GetPracticeStats(userId, teamId string, filter StatsFilter) (*PracticeStats, error) {
+	if err := u.policy.CanReadTeam(userId, teamId); err != nil {
+		return nil, err
+	}
+	sessions, err := u.findSessions(teamId, filter)
+	if err != nil {
+		return nil, err
+	}
+	return practiceStatsOf(sessions), nil
+}
+
+// GetPlayerReports combines the game and practice results of every player. Practice sessions are only
+// filtered by period, as they have no opponent.
+func (u *practiceUsecase) GetPlayerReports(userId, teamId string, filter StatsFilter) ([]PlayerReport, error) {
+	if err := u.policy.CanReadTeam(userId, teamId); err != nil {
+		return nil, err
+	}
+	sessions, err := u.findSessions(teamId, filter)
+	if err != nil {
+		return nil, err
+	}
+	teamRecords, err := u.recordRepo.FindByTeamId(teamId)
+	if err != nil {
+		return nil, err
+	}
+	var records []entity.Record
+	for _, record := range *teamRecords {
+		if filter.matches(&record) {
+			records = append(records, record)
+		}
+	}
+
+	reports := map[string]*PlayerReport{}
+	report := func(key, shooter string, player *entity.LineupPlayer) *PlayerReport {
+		r, ok := reports[key]
+		if !ok {
+			r = &PlayerReport{Shooter: shooter, Player: player}
+			reports[key] = r
+		}
+		return r
+	}
+	for _, stats := range shooterStatsOf(records) {
+		stats := stats
+		report(playerKey(stats.Player, stats.Shooter), stats.Shooter, stats.Player).Match = &stats
+	}
+	for _, stats := range practiceStatsOf(sessions).Players {
+		stats := stats
+		report(playerKey(stats.Player, stats.Shooter), stats.Shooter, stats.Player).Practice = &stats
+	}
+
+	result := make([]PlayerReport, 0, len(reports))
+	for _, r := range reports {
+		var combined rateAccumulator
+		if r.Match != nil {
+			combined.merge(r.Match.Overall)
+		}
+		if r.Practice != nil {
+			combined.merge(r.Practice.Overall)
+		}
+		r.Combined = combined.rate()
+		result = append(result, *r)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Shooter < result[j].Shooter })
+	return result, nil
+}
+
+// playerKey identifies a player across games and practice: the resolved player when known, the shooter's name otherwise.
+func playerKey(player *entity.LineupPlayer, shooter string) string {
+	if player != nil {
+		return player.Key()
+	}
+	return "shooter:" + shooter
+}
+
+type drillAccumulator struct {
+	rate      rateAccumulator
+	measured  int
+	distance  float64
+	outOfPlay int
+}
+
+type practiceAccumulator struct {
+	shooter  string
+	player   *entity.LineupPlayer
+	attended int
+	overall  rateAccumulator
+	byDrill  map[entity.DrillType]*drillAccumulator
+}
+
+// practiceStatsOf computes the practice results of every attendee and thrower of the sessions.
+func practiceStatsOf(sessions []*entity.PracticeSession) *PracticeStats {
+	accumulators := map[string]*practiceAccumulator{}
+	accumulator := func(key, shooter string, player *entity.LineupPlayer) *practiceAccumulator {
+		acc, ok := accumulators[key]
+		if !ok {
+			acc = &practiceAccumulator{shooter: shooter, player: player, byDrill: map[entity.DrillType]*drillAccumulator{}}
+			if player != nil {
+				acc.shooter = player.UserId
+				if acc.shooter == "" {
+					acc.shooter = player.GuestName
+				}
+			}
+			accumulators[key] = acc
+		}
+		return acc
+	}
+
+	for _, session := range sessions {
+		for _, attendee := range session.GetAttendees() {
+			attendee := attendee
+			accumulator(attendee.Key(), "", &attendee).attended++
+		}
+
+		for _, drill := range session.GetDrills() {
+			target := geometry.DefaultSheet.ToCartesian(drill.TargetOf())
+			for _, throw := range drill.Throws {
+				shooter := strings.TrimSpace(throw.Shooter)
+				key := "shooter:" + shooter
+				var player *entity.LineupPlayer
+				if resolved, ok := session.ResolveShooter(shooter); ok {
+					key = resolved.Key()
+					player = &resolved
+				}
+
+				acc := accumulator(key, shooter, player)
+				acc.overall.add(throw.SuccessRate)
+				d := acc.byDrill[drill.Type]
+				if d == nil {
+					d = &drillAccumulator{}
+					acc.byDrill[drill.Type] = d
+				}
+				d.rate.add(throw.SuccessRate)
+				if !drill.Type.IsPositional() {
+					continue
+				}
+				if throw.Stone == nil {
+					d.outOfPlay++
+					continue
+				}
+				p := geometry.DefaultSheet.ToCartesian(*throw.Stone)
+				d.measured++
+				d.distance += math.Hypot(p.X-target.X, p.Y-target.Y)
+			}
+		}
+	}
+
+	stats := &PracticeStats{Sessions: len(sessions), Players: make([]PlayerPracticeStats, 0, len(accumulators))}
+	for _, acc := range accumulators {
+		s := PlayerPracticeStats{
+			Shooter:  acc.shooter,
+			Player:   acc.player,
+			Attended: acc.attended,
+			Overall:  acc.overall.rate(),
+			ByDrill:  make(map[entity.DrillType]DrillStats, len(acc.byDrill)),
+		}
+		for drillType, d := range acc.byDrill {
+			drillStats := DrillStats{SuccessRate: d.rate.rate(), Measured: d.measured, OutOfPlay: d.outOfPlay}
+			if d.measured > 0 {
+				drillStats.MeanDistance = d.distance / float64(d.measured)
+			}
+			s.ByDrill[drillType] = drillStats
+		}
+		stats.Players = append(stats.Players, s)
+	}
+	sort.Slice(stats.Players, func(i, j int) bool { return stats.Players[i].Shooter < stats.Players[j].Shooter })
+	return stats
+}
@@ -0,0 +1,195 @@
+package usecase_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/usecase"
+	"CurlARC/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePracticeSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPracticeRepo := mock.NewMockPracticeSessionRepository(ctrl)
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
+	mockUserTeamRepo := mock.NewMockUserTeamRepository(ctrl)
+
+	practiceUsecase := usecase.NewPracticeUsecase(mockPracticeRepo, mockRecordRepo, mockTeamRepo, mockUserTeamRepo)
+
+	userId := "user-123"
+	teamId := "team-123"
+	date := time.Date(2024, 11, 5, 19, 0, 0, 0, time.UTC)
+	attendees := []entity.LineupPlayer{{UserId: "user-456"}, {GuestName: "Sato"}}
+	drills := []entity.Drill{{
+		Type:   entity.DrillDrawToButton,
+		Throws: []entity.PracticeThrow{{Shot: entity.Shot{Shooter: "user-456", SuccessRate: 0.75}, Stone: &entity.Coordinate{R: 0.4}}},
+	}}
+
+	t.Run("正常系: 練習が作成される", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockTeamRepo.EXPECT().FindById(teamId).Return(entity.NewTeamFromDB(teamId, "Team A"), nil)
+		mockUserTeamRepo.EXPECT().IsMember("user-456", teamId).Return(true, nil)
+		mockPracticeRepo.EXPECT().Save(gomock.Any()).DoAndReturn(func(s *entity.PracticeSession) (*entity.PracticeSession, error) {
+			return s, nil
+		})
+
+		session, err := practiceUsecase.CreatePracticeSession(userId, teamId, date, " Tokyo ", attendees, drills)
+		assert.NoError(t, err)
+		assert.Equal(t, "Tokyo", session.GetPlace())
+		assert.Len(t, session.GetAttendees(), 2)
+		assert.Len(t, session.GetDrills(), 1)
+	})
+
+	t.Run("異常系: 参加者がチームに所属していない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockTeamRepo.EXPECT().FindById(teamId).Return(entity.NewTeamFromDB(teamId, "Team A"), nil)
+		mockUserTeamRepo.EXPECT().IsMember("user-456", teamId).Return(false, nil)
+
+		session, err := practiceUsecase.CreatePracticeSession(userId, teamId, date, "", attendees, drills)
+		assert.Error(t, err)
+		assert.Nil(t, session)
+	})
+
+	t.Run("異常系: ウェイトコントロールに目標がない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockTeamRepo.EXPECT().FindById(teamId).Return(entity.NewTeamFromDB(teamId, "Team A"), nil)
+
+		session, err := practiceUsecase.CreatePracticeSession(userId, teamId, date, "", attendees, []entity.Drill{{Type: entity.DrillWeightControl}})
+		assert.Error(t, err)
+		assert.Nil(t, session)
+	})
+
+	t.Run("異常系: 投球者がいない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockTeamRepo.EXPECT().FindById(teamId).Return(entity.NewTeamFromDB(teamId, "Team A"), nil)
+
+		invalid := []entity.Drill{{Type: entity.DrillTakeoutAccuracy, Throws: []entity.PracticeThrow{{Shot: entity.Shot{SuccessRate: 1}}}}}
+		session, err := practiceUsecase.CreatePracticeSession(userId, teamId, date, "", attendees, invalid)
+		assert.Error(t, err)
+		assert.Nil(t, session)
+	})
+}
+
+func TestGetPlayerReports(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPracticeRepo := mock.NewMockPracticeSessionRepository(ctrl)
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
+	mockUserTeamRepo := mock.NewMockUserTeamRepository(ctrl)
+
+	practiceUsecase := usecase.NewPracticeUsecase(mockPracticeRepo, mockRecordRepo, mockTeamRepo, mockUserTeamRepo)
+
+	userId := "user-123"
+	teamId := "team-123"
+	throw := func(shooter string, rate float64, stone *entity.Coordinate) entity.PracticeThrow {
+		return entity.PracticeThrow{Shot: entity.Shot{Shooter: shooter, SuccessRate: rate}, Stone: stone}
+	}
+	target := &entity.Coordinate{R: 2, Theta: math.Pi / 2}
+
+	sessions := []*entity.PracticeSession{
+		entity.NewPracticeSessionFromDB("practice-1", teamId, time.Date(2024, 11, 5, 0, 0, 0, 0, time.UTC), "Tokyo",
+			[]entity.LineupPlayer{{UserId: "user-lead"}, {GuestName: "Sato"}},
+			[]entity.Drill{
+				{Type: entity.DrillDrawToButton, Throws: []entity.PracticeThrow{
+					throw("user-lead", 1.0, &entity.Coordinate{R: 0.2}),
+					throw("user-lead", 0.5, &entity.Coordinate{R: 0.6, Theta: math.Pi}),
+					throw("user-lead", 0.0, nil),
+				}},
+				{Type: entity.DrillWeightControl, Target: target, Throws: []entity.PracticeThrow{
+					throw(" sato", 0.5, &entity.Coordinate{R: 2.3, Theta: math.Pi / 2}),
+				}},
+				{Type: entity.DrillTakeoutAccuracy, Throws: []entity.PracticeThrow{
+					throw("user-lead", 0.5, nil),
+				}},
+			}),
+		entity.NewPracticeSessionFromDB("practice-2", teamId, time.Date(2024, 12, 5, 0, 0, 0, 0, time.UTC), "Tokyo",
+			[]entity.LineupPlayer{{UserId: "user-lead"}}, nil),
+	}
+
+	lineup := entity.Lineup{Entries: []entity.LineupEntry{{Position: entity.PositionLead, LineupPlayer: entity.LineupPlayer{UserId: "user-lead"}}}}
+	records := []entity.Record{
+		*entity.NewRecordFromDB("record-1", teamId, "Team B", "Tokyo", entity.Win, time.Date(2024, 11, 10, 0, 0, 0, 0, time.UTC),
+			[]entity.DataPerEnd{{Score: 1, Shots: []entity.Shot{
+				{Type: entity.ShotDraw, SuccessRate: 1.0, Shooter: "Lead"},
+				{Type: entity.ShotDraw, SuccessRate: 0.0, Shooter: "Lead"},
+			}}}, false, false, false, entity.WithLineup(lineup)),
+	}
+
+	t.Run("正常系: 練習の成績は試合と別に集計される", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockPracticeRepo.EXPECT().FindByTeamId(teamId).Return(sessions, nil)
+
+		stats, err := practiceUsecase.GetPracticeStats(userId, teamId, usecase.StatsFilter{})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, stats.Sessions)
+		if assert.Len(t, stats.Players, 2) {
+			sato, lead := stats.Players[0], stats.Players[1]
+			assert.Equal(t, "Sato", sato.Shooter)
+			assert.Equal(t, 1, sato.Attended)
+			assert.InDelta(t, 0.3, sato.ByDrill[entity.DrillWeightControl].MeanDistance, 1e-9)
+
+			assert.Equal(t, "user-lead", lead.Shooter)
+			assert.Equal(t, 2, lead.Attended)
+			assert.Equal(t, 4, lead.Overall.Shots)
+			draw := lead.ByDrill[entity.DrillDrawToButton]
+			assert.Equal(t, 3, draw.Shots)
+			assert.Equal(t, 2, draw.Measured)
+			assert.Equal(t, 1, draw.OutOfPlay)
+			assert.InDelta(t, 0.4, draw.MeanDistance, 1e-9)
+			assert.Equal(t, 0, lead.ByDrill[entity.DrillTakeoutAccuracy].Measured)
+		}
+	})
+
+	t.Run("正常系: 期間で練習が絞り込まれる", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockPracticeRepo.EXPECT().FindByTeamId(teamId).Return(sessions, nil)
+
+		stats, err := practiceUsecase.GetPracticeStats(userId, teamId, usecase.StatsFilter{From: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, stats.Sessions)
+		if assert.Len(t, stats.Players, 1) {
+			assert.Equal(t, 1, stats.Players[0].Attended)
+			assert.Equal(t, 0, stats.Players[0].Overall.Shots)
+		}
+	})
+
+	t.Run("正常系: 試合と練習が選手ごとにまとめられる", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockPracticeRepo.EXPECT().FindByTeamId(teamId).Return(sessions, nil)
+		mockRecordRepo.EXPECT().FindByTeamId(teamId).Return(&records, nil)
+
+		reports, err := practiceUsecase.GetPlayerReports(userId, teamId, usecase.StatsFilter{})
+		assert.NoError(t, err)
+		if assert.Len(t, reports, 2) {
+			sato, lead := reports[0], reports[1]
+			assert.Nil(t, sato.Match)
+			assert.NotNil(t, sato.Practice)
+
+			assert.Equal(t, "user-lead", lead.Shooter)
+			if assert.NotNil(t, lead.Match) && assert.NotNil(t, lead.Practice) {
+				assert.Equal(t, 2, lead.Match.Overall.Shots)
+				assert.Equal(t, 4, lead.Practice.Overall.Shots)
+			}
+			assert.Equal(t, 6, lead.Combined.Shots)
+			assert.InDelta(t, 3.0/6, lead.Combined.Average, 1e-9)
+		}
+	})
+
+	t.Run("異常系: ユーザーがチームに所属していない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleNone, nil)
+
+		reports, err := practiceUsecase.GetPlayerReports(userId, teamId, usecase.StatsFilter{})
+		assert.Error(t, err)
+		assert.Nil(t, reports)
+	})
+}
@@ -1,44 +1,47 @@
 package usecase
 
 import (
+	"CurlARC/internal/domain/domainerr"
 	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/geometry"
 	"CurlARC/internal/domain/repository"
 	"CurlARC/internal/handler/response"
 	"errors"
+	"fmt"
 	"time"
 )
 
 type RecordUsecase interface {
-	CreateRecord(userId, teamId, enemyTeamName, place string, result entity.Result, date time.Time) (*entity.Record, error) // Create a new record which has no endsData
-	AppendEndData(recordId, userId string, endsData []entity.DataPerEnd) (*entity.Record, error)                            // Append endsData to an existing record
-	GetRecordDetailsByRecordId(recordId string) (*entity.Record, error)
-	GetRecordIndicesByTeamId(teamId string) (*[]response.RecordIndex, error)
-	GetRecordsByTeamId(teamId string) (*[]entity.Record, error)
-	UpdateRecord(recordId, userId string, result entity.Result, enemyTeamName, place string, endsData []entity.DataPerEnd, date time.Time, isRed bool, isFirst bool, isPublic bool) (*entity.Record, error)
-	DeleteRecord(id string) error
+	CreateRecord(userId, teamId, enemyTeamName, place string, result entity.Result, date time.Time, gameFormat entity.GameFormat) (*entity.Record, error) // Create a new record which has no endsData
+	AppendEndData(recordId, userId string, endsData []entity.DataPerEnd, fgzPolicy entity.FreeGuardZonePolicy) (*entity.Record, error) // Append endsData to an existing record
+	GetRecordDetailsByRecordId(recordId, userId string) (*entity.Record, error)
+	GetScoreboard(recordId, userId string) (*entity.Scoreboard, error)
+	GetRecordIndicesByTeamId(userId, teamId string, query repository.RecordQuery) (*repository.RecordIndexPage, error)
+	GetRecordsByTeamId(userId, teamId string) (*[]entity.Record, error)
+	UpdateRecord(recordId, userId string, result entity.Result, enemyTeamName, place string, endsData []entity.DataPerEnd, date time.Time, isRed bool, isFirst bool, isPublic bool, status entity.GameStatus, fgzPolicy entity.FreeGuardZonePolicy) (*entity.Record, error)
+	DeleteRecord(id, userId string) error
 	SetVisibility(recordId, userId string, isPublic bool) (*entity.Record, error)
+	SetLineup(recordId, userId string, lineup entity.Lineup) (*entity.Record, error)
+	AddSubstitution(recordId, userId string, substitution entity.Substitution) (*entity.Record, error)
 }
 
 type recordUsecase struct {
 	recordRepo   repository.RecordRepository
 	userTeamRepo repository.UserTeamRepository
 	teamRepo     repository.TeamRepository
+	policy       Policy
 }
 
 func NewRecordUsecase(recordRepo repository.RecordRepository, userTeamRepo repository.UserTeamRepository, teamRepo repository.TeamRepository) RecordUsecase {
-	return &recordUsecase{recordRepo: recordRepo, userTeamRepo: userTeamRepo, teamRepo: teamRepo}
+	return &recordUsecase{recordRepo: recordRepo, userTeamRepo: userTeamRepo, teamRepo: teamRepo, policy: NewPolicy(userTeamRepo)}
 }
 
-func (u *recordUsecase) CreateRecord(userId, teamId, enemyTeamName, place string, result entity.Result, date time.Time) (*entity.Record, error) {
+func (u *recordUsecase) CreateRecord(userId, teamId, enemyTeamName, place string, result entity.Result, date time.Time, gameFormat entity.GameFormat) (*entity.Record, error) {
 
-	// check if the user is a member of the team
-	isMember, err := u.userTeamRepo.IsMember(userId, teamId)
-	if err != nil {
+	// check if the user may write to the team
+	if err := u.policy.CanWriteTeam(userId, teamId); err != nil {
 		return nil, err
 	}
-	if !isMember {
-		return nil, errors.New("user is not a member of the team")
-	}
 
 	// check if the team exists
 	if _, err := u.teamRepo.FindById(teamId); err != nil {
@@ -52,6 +55,7 @@ func (u *recordUsecase) CreateRecord(userId, teamId, enemyTeamName, place string
 		entity.WithResult(result),
 		entity.WithPlace(place),
 		entity.WithDate(date),
+		entity.WithGameFormat(gameFormat),
 	)
 	if err != nil {
 		return nil, err
@@ -63,7 +67,7 @@ func (u *recordUsecase) CreateRecord(userId, teamId, enemyTeamName, place string
 	return savedRecord, err
 }
 
-func (u *recordUsecase) AppendEndData(recordId, userId string, endsData []entity.DataPerEnd) (*entity.Record, error) {
+func (u *recordUsecase) AppendEndData(recordId, userId string, endsData []entity.DataPerEnd, fgzPolicy entity.FreeGuardZonePolicy) (*entity.Record, error) {
 
 	// Get the record by ID
 	currentRecord, err := u.recordRepo.FindByRecordId(recordId)
@@ -71,17 +75,17 @@ func (u *recordUsecase) AppendEndData(recordId, userId string, endsData []entity
 		return nil, err
 	}
 
-	// Check if the user is a member of the team
-	isMember, err := u.userTeamRepo.IsMember(userId, currentRecord.GetTeamId())
-	if err != nil {
-		return nil, err
-	}
-	if !isMember {
-		return nil, errors.New("appender is not a member of the team")
+	// check if the user may write to the record
+	if err := u.policy.CanWriteRecord(userId, currentRecord); err != nil {
+		return nil, deniedAs(err, "appender")
 	}
 
 	// Append the new endsData to the record
+	storedEnds := len(currentRecord.GetEndsData())
 	newEndsData := append(currentRecord.GetEndsData(), endsData...)
+	if err := prepareEnds(currentRecord, newEndsData, fgzPolicy, storedEnds); err != nil {
+		return nil, err
+	}
 	err = currentRecord.ValidateEndsData(newEndsData)
 	if err != nil {
 		return nil, err
@@ -101,33 +105,190 @@ func (u *recordUsecase) AppendEndData(recordId, userId string, endsData []entity
 	return updatedRecord, nil
 }
 
-func (u *recordUsecase) GetRecordDetailsByRecordId(recordId string) (*entity.Record, error) {
-	return u.recordRepo.FindByRecordId(recordId)
-}
+// prepareEnds checks the free guard zone rule and scores the new ends, following the hammer from end to end.
+// The policy and the scoring apply to the ends from index from onwards; earlier ends, which are already stored,
+// are only flagged.
+func prepareEnds(record *entity.Record, endsData []entity.DataPerEnd, policy entity.FreeGuardZonePolicy, from int) error {
+	format := record.GetGameFormat()
+	friendHammer := !record.GetIsFirst()
+
+	var violations []entity.EndsDataViolation
+	for i := range endsData {
+		end := &endsData[i]
+		geometry.CheckFreeGuardZone(format.FreeGuardZone(), end, friendHammer)
+		if i >= from {
+			switch policy {
+			case entity.FreeGuardZoneReject:
+				for j, shot := range end.Shots {
+					if v := shot.FreeGuardZoneViolation; v != nil && !v.Restored {
+						violations = append(violations, entity.EndsDataViolation{
+							End:     i + 1,
+							Shot:    j + 1,
+							Field:   "free_guard_zone",
+							Message: fmt.Sprintf("stone %d of the end removed protected stones (friend %v, enemy %v)", v.Delivery, v.RemovedFriendStones, v.RemovedEnemyStones),
+						})
+					}
+				}
+			case entity.FreeGuardZoneRestore:
+				restoreFreeGuardZone(format, end, friendHammer)
+			}
+		}
+
+		if i >= from {
+			scoreEnd(record, end)
+		}
+		friendHammer = format.NextHammer(friendHammer, end.Score)
+	}
 
-func (u *recordUsecase) GetRecordIndicesByTeamId(teamId string) (*[]response.RecordIndex, error) {
-	return u.recordRepo.FindIndicesByTeamId(teamId)
+	if len(violations) > 0 {
+		return &entity.EndsDataValidationError{Violations: violations}
+	}
+	return nil
 }
 
-func (u *recordUsecase) GetRecordsByTeamId(teamId string) (*[]entity.Record, error) {
-	return u.recordRepo.FindByTeamId(teamId)
+// restoreFreeGuardZone applies the penalty shot by shot, since restoring a position changes what the next shots did.
+func restoreFreeGuardZone(format entity.GameFormat, end *entity.DataPerEnd, friendHammer bool) {
+	for {
+		flagged := 0
+		for j, shot := range end.Shots {
+			if v := shot.FreeGuardZoneViolation; v != nil && !v.Restored {
+				flagged = j + 1
+				break
+			}
+		}
+		if flagged == 0 {
+			return
+		}
+		geometry.RestoreFreeGuardZone(end, flagged)
+		geometry.CheckFreeGuardZone(format.FreeGuardZone(), end, friendHammer)
+	}
 }
 
-func (u *recordUsecase) UpdateRecord(recordId, userId string, result entity.Result, enemyTeamName, place string, endsData []entity.DataPerEnd, date time.Time, isRed bool, isFirst, isPublic bool) (*entity.Record, error) {
+// scoreEnd counts the final stone positions of a complete end.
+// An end entered without a score gets the counted score, and an end whose entered score, 0 included, disagrees is flagged.
+func scoreEnd(record *entity.Record, end *entity.DataPerEnd) {
+	end.ComputedScore = nil
+	end.ScoreMismatch = false
 
-	// Get the record by ID
+	if len(end.Shots) < record.ShotsPerEnd() {
+		return
+	}
+	computed := geometry.CountScore(end.Shots[len(end.Shots)-1].Stones)
+	end.ComputedScore = &computed
+
+	if end.ScoreFilled {
+		end.Score = computed
+	} else if end.Score != computed {
+		end.ScoreMismatch = true
+	}
+}
+
+func (u *recordUsecase) GetRecordDetailsByRecordId(recordId, userId string) (*entity.Record, error) {
 	record, err := u.recordRepo.FindByRecordId(recordId)
 	if err != nil {
 		return nil, err
 	}
+	if err := u.policy.CanReadRecord(userId, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (u *recordUsecase) GetScoreboard(recordId, userId string) (*entity.Scoreboard, error) {
+	record, err := u.GetRecordDetailsByRecordId(recordId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	scoreboard := record.Scoreboard()
+	return &scoreboard, nil
+}
+
+// Page sizes of the record listing.
+const (
+	DefaultRecordPageSize = 50
+	MaxRecordPageSize     = 200
+)
+
+// GetRecordIndicesByTeamId lists one page of the team's records, the most recent first unless the query sorts otherwise.
+// Users outside the team only see its public records.
+func (u *recordUsecase) GetRecordIndicesByTeamId(userId, teamId string, query repository.RecordQuery) (*repository.RecordIndexPage, error) {
+	if query.Sort == "" {
+		query.Sort = repository.SortDateDesc
+	}
+	if !query.Sort.IsValid() {
+		return nil, domainerr.Invalid("sort", "invalid sort: %s", query.Sort)
+	}
+	if query.Cursor != nil && query.Cursor.Sort != query.Sort {
+		return nil, domainerr.Invalid("cursor", "the cursor was made for another sort")
+	}
+	switch {
+	case query.Limit == 0:
+		query.Limit = DefaultRecordPageSize
+	case query.Limit < 0 || query.Limit > MaxRecordPageSize:
+		return nil, domainerr.Invalid("limit", "limit must be between 1 and %d", MaxRecordPageSize)
+	}
+	if query.Result != "" && query.Result != entity.Win && query.Result != entity.Loss && query.Result != entity.Draw {
+		return nil, domainerr.Invalid("result", "invalid result: %s", query.Result)
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && query.To.Before(query.From) {
+		return nil, domainerr.Invalid("to", "the end of the date range is before its start")
+	}
+
+	publicOnly, err := u.publicOnly(userId, teamId)
+	if err != nil {
+		return nil, err
+	}
+	if publicOnly {
+		if query.IsPublic != nil && !*query.IsPublic {
+			return &repository.RecordIndexPage{Indices: []response.RecordIndex{}}, nil
+		}
+		isPublic := true
+		query.IsPublic = &isPublic
+	}
+
+	return u.recordRepo.FindIndicesByTeamId(teamId, query)
+}
+
+func (u *recordUsecase) GetRecordsByTeamId(userId, teamId string) (*[]entity.Record, error) {
+	publicOnly, err := u.publicOnly(userId, teamId)
+	if err != nil {
+		return nil, err
+	}
+	records, err := u.recordRepo.FindByTeamId(teamId)
+	if err != nil || !publicOnly {
+		return records, err
+	}
+
+	public := make([]entity.Record, 0, len(*records))
+	for _, record := range *records {
+		if record.IsPublic() {
+			public = append(public, record)
+		}
+	}
+	return &public, nil
+}
+
+// publicOnly tells whether the user may only see the public records of the team.
+func (u *recordUsecase) publicOnly(userId, teamId string) (bool, error) {
+	err := u.policy.CanReadTeam(userId, teamId)
+	if errors.Is(err, domainerr.ErrNotTeamMember) {
+		return true, nil
+	}
+	return false, err
+}
+
+func (u *recordUsecase) UpdateRecord(recordId, userId string, result entity.Result, enemyTeamName, place string, endsData []entity.DataPerEnd, date time.Time, isRed bool, isFirst, isPublic bool, status entity.GameStatus, fgzPolicy entity.FreeGuardZonePolicy) (*entity.Record, error) {
 
-	// Check if the user is a member of the team
-	isMember, err := u.userTeamRepo.IsMember(userId, record.GetTeamId())
+	// Get the record by ID
+	record, err := u.recordRepo.FindByRecordId(recordId)
 	if err != nil {
 		return nil, err
 	}
-	if !isMember {
-		return nil, errors.New("updater is not a member of the team")
+
+	// check if the user may write to the record
+	if err := u.policy.CanWriteRecord(userId, record); err != nil {
+		return nil, deniedAs(err, "updater")
 	}
 
 	// Prepare the update struct
@@ -136,6 +297,13 @@ func (u *recordUsecase) UpdateRecord(recordId, userId string, result entity.Resu
   if result != "" {
     newRecord.SetResult(result)
   }
+	// the status is checked again since a conceded game cannot become a draw
+	if status == "" {
+		status = newRecord.GetStatus()
+	}
+	if err := newRecord.SetStatus(status); err != nil {
+		return nil, err
+	}
 	if enemyTeamName != "" {
 		newRecord.SetEnemyTeamName(enemyTeamName)
 	}
@@ -145,7 +313,13 @@ func (u *recordUsecase) UpdateRecord(recordId, userId string, result entity.Resu
 	if !date.IsZero() {
 		newRecord.SetDate(date)
 	}
+	// hammer follows the team throwing first, so it is set before the ends data is checked
+	newRecord.SetIsRed(isRed)
+	newRecord.SetIsFirst(isFirst)
 	if len(endsData) > 0 {
+		if err := prepareEnds(newRecord, endsData, fgzPolicy, 0); err != nil {
+			return nil, err
+		}
 		err = newRecord.ValidateEndsData(endsData)
 		if err != nil {
 			return nil, err
@@ -155,8 +329,6 @@ func (u *recordUsecase) UpdateRecord(recordId, userId string, result entity.Resu
 			return nil, err
 		}
 	}
-	newRecord.SetIsRed(isRed)
-	newRecord.SetIsFirst(isFirst)
 	newRecord.SetVisibility(isPublic)
 
 	// Update the record with only the fields provided in the updates
@@ -168,7 +340,14 @@ func (u *recordUsecase) UpdateRecord(recordId, userId string, result entity.Resu
 	return updatedRecord, nil
 }
 
-func (u *recordUsecase) DeleteRecord(id string) error {
+func (u *recordUsecase) DeleteRecord(id, userId string) error {
+	record, err := u.recordRepo.FindByRecordId(id)
+	if err != nil {
+		return err
+	}
+	if err := u.policy.CanWriteRecord(userId, record); err != nil {
+		return err
+	}
 	return u.recordRepo.Delete(id)
 }
 
@@ -180,13 +359,9 @@ func (u *recordUsecase) SetVisibility(recordId, userId string, isPublic bool) (*
 		return nil, err
 	}
 
-	// check if the user is the member of the record
-	isMember, err := u.userTeamRepo.IsMember(userId, record.GetTeamId())
-	if err != nil {
-		return nil, err
-	}
-	if !isMember {
-		return nil, errors.New("inviter is not a member of the team")
+	// check if the user may write to the record
+	if err := u.policy.CanWriteRecord(userId, record); err != nil {
+		return nil, deniedAs(err, "updater")
 	}
 
 	// update the record
@@ -200,3 +375,72 @@ func (u *recordUsecase) SetVisibility(recordId, userId string, isPublic bool) (*
 
 	return updatedRecord, nil
 }
+
+func (u *recordUsecase) SetLineup(recordId, userId string, lineup entity.Lineup) (*entity.Record, error) {
+
+	// check if the record exists
+	record, err := u.recordRepo.FindByRecordId(recordId)
+	if err != nil {
+		return nil, err
+	}
+
+	// check if the user may write to the record
+	if err := u.policy.CanWriteRecord(userId, record); err != nil {
+		return nil, deniedAs(err, "updater")
+	}
+
+	// registered players must belong to the team
+	if err := u.checkLineupMembers(record.GetTeamId(), lineup); err != nil {
+		return nil, err
+	}
+
+	if err := record.SetLineup(lineup); err != nil {
+		return nil, err
+	}
+
+	return u.recordRepo.Update(*record)
+}
+
+func (u *recordUsecase) AddSubstitution(recordId, userId string, substitution entity.Substitution) (*entity.Record, error) {
+
+	// check if the record exists
+	record, err := u.recordRepo.FindByRecordId(recordId)
+	if err != nil {
+		return nil, err
+	}
+
+	// check if the user may write to the record
+	if err := u.policy.CanWriteRecord(userId, record); err != nil {
+		return nil, deniedAs(err, "updater")
+	}
+
+	// the substitute must belong to the team unless they are a guest
+	if substitution.UserId != "" {
+		isMember, err := u.userTeamRepo.IsMember(substitution.UserId, record.GetTeamId())
+		if err != nil {
+			return nil, err
+		}
+		if !isMember {
+			return nil, domainerr.Invalid("user_id", "substitute %s is not a member of the team", substitution.UserId)
+		}
+	}
+
+	if err := record.AddSubstitution(substitution); err != nil {
+		return nil, err
+	}
+
+	return u.recordRepo.Update(*record)
+}
+
+func (u *recordUsecase) checkLineupMembers(teamId string, lineup entity.Lineup) error {
+	for _, playerId := range lineup.UserIds() {
+		isMember, err := u.userTeamRepo.IsMember(playerId, teamId)
+		if err != nil {
+			return err
+		}
+		if !isMember {
+			return domainerr.Invalid("lineup", "player %s is not a member of the team", playerId)
+		}
+	}
+	return nil
+}
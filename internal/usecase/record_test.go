@@ -1,9 +1,13 @@
 package usecase_test
 
 import (
+	"CurlARC/internal/domain/domainerr"
 	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/repository"
+	"CurlARC/internal/handler/response"
 	"CurlARC/internal/usecase"
 	"CurlARC/mock"
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
@@ -39,7 +43,7 @@ func TestCreateRecord(t *testing.T) {
 	)
 
 	t.Run("正常系: レコードが正常に作成される", func(t *testing.T) {
-		mockUserTEamRepo.EXPECT().IsMember(userId, teamId).Return(true, nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
 		mockTeamRepo.EXPECT().FindById(teamId).Return(nil, nil)
 		mockRecordRepo.EXPECT().Save(gomock.Any()).Return(record, nil)
 
@@ -50,13 +54,52 @@ func TestCreateRecord(t *testing.T) {
 			place,
 			entity.Win,
 			date,
+			"",
 		)
 		assert.NoError(t, err)
 		assert.Equal(t, record, createdRecord)
 	})
 
+	t.Run("正常系: 試合形式を指定して作成される", func(t *testing.T) {
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockTeamRepo.EXPECT().FindById(teamId).Return(nil, nil)
+		mockRecordRepo.EXPECT().Save(gomock.Any()).DoAndReturn(func(r entity.Record) (*entity.Record, error) {
+			return &r, nil
+		})
+
+		createdRecord, err := recordUsecase.CreateRecord(
+			userId,
+			teamId,
+			enemyTeamName,
+			place,
+			entity.Win,
+			date,
+			entity.GameFormatMixedDoubles,
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.GameFormatMixedDoubles, createdRecord.GetGameFormat())
+		assert.Equal(t, 5, createdRecord.ShotsPerEnd())
+	})
+
+	t.Run("異常系: 不明な試合形式", func(t *testing.T) {
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockTeamRepo.EXPECT().FindById(teamId).Return(nil, nil)
+
+		createdRecord, err := recordUsecase.CreateRecord(
+			userId,
+			teamId,
+			enemyTeamName,
+			place,
+			entity.Win,
+			date,
+			"SIX_ENDS",
+		)
+		assert.Error(t, err)
+		assert.Nil(t, createdRecord)
+	})
+
 	t.Run("異常系: dbへの保存に失敗する", func(t *testing.T) {
-		mockUserTEamRepo.EXPECT().IsMember(userId, teamId).Return(true, nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
 		mockTeamRepo.EXPECT().FindById(teamId).Return(nil, nil)
 		mockRecordRepo.EXPECT().Save(gomock.Any()).Return(nil, errors.New("failed to save record"))
 
@@ -67,6 +110,7 @@ func TestCreateRecord(t *testing.T) {
 			place,
 			entity.Win,
 			date,
+			"",
 		)
 
 		assert.Error(t, err)
@@ -75,7 +119,7 @@ func TestCreateRecord(t *testing.T) {
 	})
 
 	t.Run("異常系: チームが見つからない", func(t *testing.T) {
-		mockUserTEamRepo.EXPECT().IsMember(userId, teamId).Return(true, nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
 		mockTeamRepo.EXPECT().FindById(teamId).Return(nil, errors.New("team not found"))
 
 		createdRecord, err := recordUsecase.CreateRecord(
@@ -85,6 +129,7 @@ func TestCreateRecord(t *testing.T) {
 			place,
 			entity.Win,
 			date,
+			"",
 		)
 
 		assert.Error(t, err)
@@ -93,7 +138,7 @@ func TestCreateRecord(t *testing.T) {
 	})
 
 	t.Run("異常系: ユーザーがチームに所属していない", func(t *testing.T) {
-		mockUserTEamRepo.EXPECT().IsMember(userId, teamId).Return(false, nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleNone, nil)
 
 		createdRecord, err := recordUsecase.CreateRecord(
 			userId,
@@ -102,246 +147,953 @@ func TestCreateRecord(t *testing.T) {
 			place,
 			entity.Win,
 			date,
+			"",
 		)
 
 		assert.Error(t, err)
 		assert.Nil(t, createdRecord)
 		assert.Equal(t, "user is not a member of the team", err.Error())
+		assert.ErrorIs(t, err, domainerr.ErrNotTeamMember)
+	})
+
+	t.Run("異常系: 閲覧者はレコードを作成できない", func(t *testing.T) {
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleViewer, nil)
+
+		createdRecord, err := recordUsecase.CreateRecord(userId, teamId, enemyTeamName, place, entity.Win, date, "")
+
+		assert.Nil(t, createdRecord)
+		assert.ErrorIs(t, err, domainerr.ErrInsufficientRole)
+		assert.True(t, domainerr.IsKind(err, domainerr.KindForbidden))
+	})
+}
+
+func TestAppendEndData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockUserTEamRepo := mock.NewMockUserTeamRepository(ctrl)
+	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
+
+	recordUsecase := usecase.NewRecordUsecase(
+		mockRecordRepo,
+		mockUserTEamRepo,
+		mockTeamRepo,
+	)
+
+	recordId := "record-123"
+	userId := "user-123"
+	teamId := "team-123"
+	enemyTeamName := "Team B"
+	place := "Tokyo"
+	date := time.Date(2023, 9, 19, 0, 0, 0, 0, time.UTC)
+
+	record, _ := entity.NewRecord(
+		teamId,
+		entity.WithEnemyTeamName(enemyTeamName),
+		entity.WithPlace(place),
+		entity.WithDate(date),
+	)
+
+	endsData := []entity.DataPerEnd{
+		{
+			Score: 1,
+			Shots: []entity.Shot{
+				{
+					Type:        entity.ShotDraw,
+					SuccessRate: 0.85,
+					Shooter:     "Lead",
+					Stones: entity.Stones{
+						FriendStones: []entity.Coordinate{
+							{Index: 1, R: 2.1, Theta: 0.1},
+						},
+						EnemyStones: []entity.Coordinate{},
+					},
+				},
+				{
+					Type:        entity.ShotGuard,
+					SuccessRate: 0.80,
+					Shooter:     "Lead",
+					Stones: entity.Stones{
+						FriendStones: []entity.Coordinate{
+							{Index: 1, R: 2.1, Theta: 0.1},
+						},
+						EnemyStones: []entity.Coordinate{
+							{Index: 1, R: 2.5, Theta: 3.14},
+						},
+					},
+				},
+				{
+					Type:        entity.ShotDraw,
+					SuccessRate: 0.82,
+					Shooter:     "Second",
+					Stones: entity.Stones{
+						FriendStones: []entity.Coordinate{
+							{Index: 1, R: 2.1, Theta: 0.1},
+							{Index: 2, R: 1.5, Theta: 0.2},
+						},
+						EnemyStones: []entity.Coordinate{
+							{Index: 1, R: 2.5, Theta: 3.14},
+						},
+					},
+				},
+				{
+					Type:        entity.ShotTakeout,
+					SuccessRate: 0.78,
+					Shooter:     "Second",
+					Stones: entity.Stones{
+						FriendStones: []entity.Coordinate{
+							{Index: 1, R: 2.1, Theta: 0.1},
+							{Index: 2, R: 1.5, Theta: 0.2},
+						},
+						EnemyStones: []entity.Coordinate{
+							{Index: 1, R: 2.5, Theta: 3.14},
+						},
+					},
+				},
+				{
+					Type:        entity.ShotDraw,
+					SuccessRate: 0.88,
+					Shooter:     "Third",
+					Stones: entity.Stones{
+						FriendStones: []entity.Coordinate{
+							{Index: 1, R: 2.1, Theta: 0.1},
+							{Index: 2, R: 1.5, Theta: 0.2},
+							{Index: 3, R: 0.8, Theta: 0.1},
+						},
+						EnemyStones: []entity.Coordinate{
+							{Index: 1, R: 2.5, Theta: 3.14},
+						},
+					},
+				},
+				{
+					Type:        entity.ShotHitAndRoll,
+					SuccessRate: 0.75,
+					Shooter:     "Third",
+					Stones: entity.Stones{
+						FriendStones: []entity.Coordinate{
+							{Index: 1, R: 2.1, Theta: 0.1},
+							{Index: 2, R: 1.5, Theta: 0.2},
+							{Index: 3, R: 0.8, Theta: 0.1},
+						},
+						EnemyStones: []entity.Coordinate{
+							{Index: 1, R: 1.2, Theta: 3.0},
+						},
+					},
+				},
+				{
+					Type:        entity.ShotFreeze,
+					SuccessRate: 0.90,
+					Shooter:     "Skip",
+					Stones: entity.Stones{
+						FriendStones: []entity.Coordinate{
+							{Index: 1, R: 2.1, Theta: 0.1},
+							{Index: 2, R: 1.5, Theta: 0.2},
+							{Index: 3, R: 0.8, Theta: 0.1},
+							{Index: 4, R: 0.3, Theta: 0.05},
+						},
+						EnemyStones: []entity.Coordinate{
+							{Index: 1, R: 1.2, Theta: 3.0},
+						},
+					},
+				},
+				{
+					Type:        entity.ShotDraw,
+					SuccessRate: 0.92,
+					Shooter:     "Skip",
+					Stones: entity.Stones{
+						FriendStones: []entity.Coordinate{
+							{Index: 1, R: 2.1, Theta: 0.1},
+							{Index: 2, R: 1.5, Theta: 0.2},
+							{Index: 3, R: 0.8, Theta: 0.1},
+							{Index: 4, R: 0.3, Theta: 0.05},
+							{Index: 5, R: 0.5, Theta: 3.14},
+						},
+						EnemyStones: []entity.Coordinate{
+							{Index: 1, R: 1.2, Theta: 3.0},
+						},
+					},
+				},
+			},
+		},
+		// 追加のエンドをここに挿入（省略）
+	}
+
+	invalidEndsData := []entity.DataPerEnd{
+		{
+			Score: 2,
+			Shots: []entity.Shot{
+				{
+					Type:        "",
+					SuccessRate: 0,
+					Shooter:     "",
+					Stones: entity.Stones{
+						FriendStones: []entity.Coordinate{
+							{Index: 0, R: 0, Theta: 0},
+							{Index: 0, R: 0, Theta: 0},
+						},
+						EnemyStones: []entity.Coordinate{
+							{Index: 0, R: 0, Theta: 0},
+							{Index: 0, R: 0, Theta: 0},
+						},
+					},
+				},
+			},
+		},
+		{
+			Score: 2,
+			Shots: []entity.Shot{
+				{
+					Type:        "",
+					SuccessRate: 0,
+					Shooter:     "",
+					Stones: entity.Stones{
+						FriendStones: []entity.Coordinate{
+							{Index: 0, R: 0, Theta: 0},
+							{Index: 0, R: 0, Theta: 0},
+						},
+						EnemyStones: []entity.Coordinate{
+							{Index: 0, R: 0, Theta: 0},
+							{Index: 0, R: 0, Theta: 0},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("正常系: endsDataが正常に追加される", func(t *testing.T) {
+
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(record, nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockRecordRepo.EXPECT().Update(gomock.Any()).Return(record, nil)
+
+		updatedRecord, err := recordUsecase.AppendEndData(recordId, userId, endsData, entity.FreeGuardZoneFlag)
+		assert.NoError(t, err)
+		assert.NotNil(t, updatedRecord)
+		assert.Equal(t, 1, len(updatedRecord.GetEndsData()))
+
+		// the final position counts 3 friend stones while 1 was entered
+		end := updatedRecord.GetEndsData()[0]
+		assert.Equal(t, 1, end.Score)
+		assert.Equal(t, 3, *end.ComputedScore)
+		assert.True(t, end.ScoreMismatch)
+	})
+
+	t.Run("正常系: スコア未入力のエンドは石の配置から補完される", func(t *testing.T) {
+		unscored := []entity.DataPerEnd{{Shots: endsData[0].Shots, ScoreFilled: true}}
+		emptyRecord, _ := entity.NewRecord(teamId, entity.WithDate(date))
+
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(emptyRecord, nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockRecordRepo.EXPECT().Update(gomock.Any()).Return(emptyRecord, nil)
+
+		updatedRecord, err := recordUsecase.AppendEndData(recordId, userId, unscored, entity.FreeGuardZoneFlag)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, updatedRecord.GetEndsData()[0].Score)
+		assert.False(t, updatedRecord.GetEndsData()[0].ScoreMismatch)
+	})
+
+	t.Run("正常系: scoreを省略したJSONのエンドは補完の対象になる", func(t *testing.T) {
+		var decoded []entity.DataPerEnd
+		assert.NoError(t, json.Unmarshal([]byte(`[{"shots": []}, {"score": null, "shots": []}, {"score": 0, "shots": []}]`), &decoded))
+
+		assert.True(t, decoded[0].ScoreFilled)
+		assert.True(t, decoded[1].ScoreFilled)
+		assert.False(t, decoded[2].ScoreFilled)
+	})
+
+	t.Run("正常系: 0と入力されたエンドは上書きされず不一致として記録される", func(t *testing.T) {
+		blank := []entity.DataPerEnd{{Score: 0, Shots: endsData[0].Shots}}
+		emptyRecord, _ := entity.NewRecord(teamId, entity.WithDate(date))
+
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(emptyRecord, nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockRecordRepo.EXPECT().Update(gomock.Any()).Return(emptyRecord, nil)
+
+		updatedRecord, err := recordUsecase.AppendEndData(recordId, userId, blank, entity.FreeGuardZoneFlag)
+		assert.NoError(t, err)
+
+		end := updatedRecord.GetEndsData()[0]
+		assert.Equal(t, 0, end.Score)
+		assert.Equal(t, 3, *end.ComputedScore)
+		assert.True(t, end.ScoreMismatch)
+	})
+
+	t.Run("正常系: 保存済みのエンドは追加時に採点し直されない", func(t *testing.T) {
+		storedRecord, _ := entity.NewRecord(teamId, entity.WithDate(date))
+		// stored before the end was scored: no computed score and no mismatch flag, although the stones count 3
+		assert.NoError(t, storedRecord.SetEndsData([]entity.DataPerEnd{{Score: 1, Shots: endsData[0].Shots}}))
+		next := []entity.DataPerEnd{{Score: 0, Shots: []entity.Shot{{Type: entity.ShotDraw, SuccessRate: 0.5}}}}
+
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(storedRecord, nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockRecordRepo.EXPECT().Update(gomock.Any()).Return(storedRecord, nil)
+
+		updatedRecord, err := recordUsecase.AppendEndData(recordId, userId, next, entity.FreeGuardZoneFlag)
+		assert.NoError(t, err)
+
+		stored := updatedRecord.GetEndsData()[0]
+		assert.Equal(t, 1, stored.Score)
+		assert.Nil(t, stored.ComputedScore)
+		assert.False(t, stored.ScoreMismatch)
+	})
+
+	t.Run("異常系: レコードが見つからない", func(t *testing.T) {
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(nil, errors.New("record not found"))
+
+		updatedRecord, err := recordUsecase.AppendEndData(recordId, userId, endsData, entity.FreeGuardZoneFlag)
+		assert.Error(t, err)
+		assert.Nil(t, updatedRecord)
+		assert.Equal(t, "record not found", err.Error())
+	})
+
+	t.Run("異常系: ユーザーがチームに所属していない", func(t *testing.T) {
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(record, nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleNone, nil)
+
+		updatedRecord, err := recordUsecase.AppendEndData(recordId, userId, endsData, entity.FreeGuardZoneFlag)
+		assert.Error(t, err)
+		assert.Nil(t, updatedRecord)
+		assert.Equal(t, "appender is not a member of the team", err.Error())
+	})
+
+	t.Run("異常系: 未知のショット種別は拒否される", func(t *testing.T) {
+		unknownType := []entity.DataPerEnd{{Shots: []entity.Shot{{Type: "ドロー", SuccessRate: 0.5}}}}
+
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(record, nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+
+		updatedRecord, err := recordUsecase.AppendEndData(recordId, userId, unknownType, entity.FreeGuardZoneFlag)
+		assert.Nil(t, updatedRecord)
+
+		var validationErr *entity.EndsDataValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "type", validationErr.Violations[0].Field)
+	})
+
+	t.Run("正常系: 移行前のショット種別が保存されていても追加できる", func(t *testing.T) {
+		legacy := entity.NewRecordFromDB(recordId, teamId, enemyTeamName, place, entity.Win, date,
+			[]entity.DataPerEnd{{Shots: []entity.Shot{{Type: "ドロー", Rotation: "cw", SuccessRate: 0.5}}}}, false, false, false)
+
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(legacy, nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockRecordRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(record entity.Record) (*entity.Record, error) {
+			return &record, nil
+		})
+
+		appended := []entity.DataPerEnd{{Shots: []entity.Shot{{Type: entity.ShotDraw, SuccessRate: 0.5}}}}
+		updatedRecord, err := recordUsecase.AppendEndData(recordId, userId, appended, entity.FreeGuardZoneFlag)
+		assert.NoError(t, err)
+		assert.Len(t, updatedRecord.GetEndsData(), 2)
+		assert.Equal(t, entity.ShotType("ドロー"), updatedRecord.GetEndsData()[0].Shots[0].Type)
+	})
+
+	t.Run("異常系: 保存済みのエンドでも変更したショット種別は検証される", func(t *testing.T) {
+		legacy := entity.NewRecordFromDB(recordId, teamId, enemyTeamName, place, entity.Win, date,
+			[]entity.DataPerEnd{{Shots: []entity.Shot{{Type: "ドロー", SuccessRate: 0.5}}}}, false, false, false)
+
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(legacy, nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+
+		changed := []entity.DataPerEnd{{Shots: []entity.Shot{{Type: "ガード", SuccessRate: 0.5}}}}
+		updatedRecord, err := recordUsecase.UpdateRecord(recordId, userId, "", "", "", changed, time.Time{}, false, false, false, "", entity.FreeGuardZoneFlag)
+		assert.Nil(t, updatedRecord)
+
+		var validationErr *entity.EndsDataValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "type", validationErr.Violations[0].Field)
+	})
+
+	t.Run("異常系: endsDataの検証に失敗する", func(t *testing.T) {
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(record, nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+
+		updatedRecord, err := recordUsecase.AppendEndData(recordId, userId, invalidEndsData, entity.FreeGuardZoneFlag)
+		assert.Error(t, err)
+		assert.Nil(t, updatedRecord)
+
+		var validationErr *entity.EndsDataValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.NotEmpty(t, validationErr.Violations)
 	})
 }
 
-// func TestAppendEndData(t *testing.T) {
-// 	ctrl := gomock.NewController(t)
-// 	defer ctrl.Finish()
-
-// 	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
-// 	mockUserTEamRepo := mock.NewMockUserTeamRepository(ctrl)
-// 	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
-
-// 	recordUsecase := usecase.NewRecordUsecase(
-// 		mockRecordRepo,
-// 		mockUserTEamRepo,
-// 		mockTeamRepo,
-// 	)
-
-// 	recordId := "record-123"
-// 	userId := "user-123"
-// 	teamId := "team-123"
-// 	enemyTeamName := "Team B"
-// 	place := "Tokyo"
-// 	date := time.Date(2023, 9, 19, 0, 0, 0, 0, time.UTC)
-
-// 	record, _ := entity.NewRecord(
-// 		teamId,
-// 		entity.WithEnemyTeamName(enemyTeamName),
-// 		entity.WithPlace(place),
-// 		entity.WithDate(date),
-// 	)
-
-// 	endsData := []entity.DataPerEnd{
-// 		{
-// 			Score: 1,
-// 			Shots: []entity.Shot{
-// 				{
-// 					Type:        "Draw",
-// 					SuccessRate: 0.85,
-// 					Shooter:     "Lead",
-// 					Stones: entity.Stones{
-// 						FriendStones: []entity.Coordinate{
-// 							{Index: 1, R: 2.1, Theta: 0.1},
-// 						},
-// 						EnemyStones: []entity.Coordinate{},
-// 					},
-// 				},
-// 				{
-// 					Type:        "Guard",
-// 					SuccessRate: 0.80,
-// 					Shooter:     "Lead",
-// 					Stones: entity.Stones{
-// 						FriendStones: []entity.Coordinate{
-// 							{Index: 1, R: 2.1, Theta: 0.1},
-// 						},
-// 						EnemyStones: []entity.Coordinate{
-// 							{Index: 1, R: 2.5, Theta: 3.14},
-// 						},
-// 					},
-// 				},
-// 				{
-// 					Type:        "Draw",
-// 					SuccessRate: 0.82,
-// 					Shooter:     "Second",
-// 					Stones: entity.Stones{
-// 						FriendStones: []entity.Coordinate{
-// 							{Index: 1, R: 2.1, Theta: 0.1},
-// 							{Index: 2, R: 1.5, Theta: 0.2},
-// 						},
-// 						EnemyStones: []entity.Coordinate{
-// 							{Index: 1, R: 2.5, Theta: 3.14},
-// 						},
-// 					},
-// 				},
-// 				{
-// 					Type:        "Takeout",
-// 					SuccessRate: 0.78,
-// 					Shooter:     "Second",
-// 					Stones: entity.Stones{
-// 						FriendStones: []entity.Coordinate{
-// 							{Index: 1, R: 2.1, Theta: 0.1},
-// 							{Index: 2, R: 1.5, Theta: 0.2},
-// 						},
-// 						EnemyStones: []entity.Coordinate{
-// 							{Index: 1, R: 2.5, Theta: 3.14},
-// 						},
-// 					},
-// 				},
-// 				{
-// 					Type:        "Draw",
-// 					SuccessRate: 0.88,
-// 					Shooter:     "Third",
-// 					Stones: entity.Stones{
-// 						FriendStones: []entity.Coordinate{
-// 							{Index: 1, R: 2.1, Theta: 0.1},
-// 							{Index: 2, R: 1.5, Theta: 0.2},
-// 							{Index: 3, R: 0.8, Theta: 0.1},
-// 						},
-// 						EnemyStones: []entity.Coordinate{
-// 							{Index: 1, R: 2.5, Theta: 3.14},
-// 						},
-// 					},
-// 				},
-// 				{
-// 					Type:        "Hit and Roll",
-// 					SuccessRate: 0.75,
-// 					Shooter:     "Third",
-// 					Stones: entity.Stones{
-// 						FriendStones: []entity.Coordinate{
-// 							{Index: 1, R: 2.1, Theta: 0.1},
-// 							{Index: 2, R: 1.5, Theta: 0.2},
-// 							{Index: 3, R: 0.8, Theta: 0.1},
-// 						},
-// 						EnemyStones: []entity.Coordinate{
-// 							{Index: 1, R: 1.2, Theta: 3.0},
-// 						},
-// 					},
-// 				},
-// 				{
-// 					Type:        "Freeze",
-// 					SuccessRate: 0.90,
-// 					Shooter:     "Skip",
-// 					Stones: entity.Stones{
-// 						FriendStones: []entity.Coordinate{
-// 							{Index: 1, R: 2.1, Theta: 0.1},
-// 							{Index: 2, R: 1.5, Theta: 0.2},
-// 							{Index: 3, R: 0.8, Theta: 0.1},
-// 							{Index: 4, R: 0.3, Theta: 0.05},
-// 						},
-// 						EnemyStones: []entity.Coordinate{
-// 							{Index: 1, R: 1.2, Theta: 3.0},
-// 						},
-// 					},
-// 				},
-// 				{
-// 					Type:        "Draw",
-// 					SuccessRate: 0.92,
-// 					Shooter:     "Skip",
-// 					Stones: entity.Stones{
-// 						FriendStones: []entity.Coordinate{
-// 							{Index: 1, R: 2.1, Theta: 0.1},
-// 							{Index: 2, R: 1.5, Theta: 0.2},
-// 							{Index: 3, R: 0.8, Theta: 0.1},
-// 							{Index: 4, R: 0.3, Theta: 0.05},
-// 							{Index: 5, R: 0.5, Theta: 3.14},
-// 						},
-// 						EnemyStones: []entity.Coordinate{
-// 							{Index: 1, R: 1.2, Theta: 3.0},
-// 						},
-// 					},
-// 				},
-// 			},
-// 		},
-// 		// 追加のエンドをここに挿入（省略）
-// 	}
-
-// 	invalidEndsData := []entity.DataPerEnd{
-// 		{
-// 			Score: 2,
-// 			Shots: []entity.Shot{
-// 				{
-// 					Type:        "",
-// 					SuccessRate: 0,
-// 					Shooter:     "",
-// 					Stones: entity.Stones{
-// 						FriendStones: []entity.Coordinate{
-// 							{Index: 0, R: 0, Theta: 0},
-// 							{Index: 0, R: 0, Theta: 0},
-// 						},
-// 						EnemyStones: []entity.Coordinate{
-// 							{Index: 0, R: 0, Theta: 0},
-// 							{Index: 0, R: 0, Theta: 0},
-// 						},
-// 					},
-// 				},
-// 			},
-// 		},
-// 		{
-// 			Score: 2,
-// 			Shots: []entity.Shot{
-// 				{
-// 					Type:        "",
-// 					SuccessRate: 0,
-// 					Shooter:     "",
-// 					Stones: entity.Stones{
-// 						FriendStones: []entity.Coordinate{
-// 							{Index: 0, R: 0, Theta: 0},
-// 							{Index: 0, R: 0, Theta: 0},
-// 						},
-// 						EnemyStones: []entity.Coordinate{
-// 							{Index: 0, R: 0, Theta: 0},
-// 							{Index: 0, R: 0, Theta: 0},
-// 						},
-// 					},
-// 				},
-// 			},
-// 		},
-// 	}
-
-// 	// t.Run("正常系: endsDataが正常に追加される", func(t *testing.T) {
-
-// 	// 	mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(record, nil)
-// 	// 	mockUserTEamRepo.EXPECT().IsMember(userId, teamId).Return(true, nil)
-// 	// 	mockRecordRepo.EXPECT().Update(gomock.Any()).Return(record, nil)
-
-// 	// 	updatedRecord, err := recordUsecase.AppendEndData(recordId, userId, endsData)
-// 	// 	assert.NoError(t, err)
-// 	// 	assert.NotNil(t, updatedRecord)
-// 	// 	assert.Equal(t, 1, len(updatedRecord.GetEndsData()))
-// 	// })
-
-// 	t.Run("異常系: レコードが見つからない", func(t *testing.T) {
-// 		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(nil, errors.New("record not found"))
-
-// 		updatedRecord, err := recordUsecase.AppendEndData(recordId, userId, endsData)
-// 		assert.Error(t, err)
-// 		assert.Nil(t, updatedRecord)
-// 		assert.Equal(t, "record not found", err.Error())
-// 	})
-
-// 	t.Run("異常系: ユーザーがチームに所属していない", func(t *testing.T) {
-// 		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(record, nil)
-// 		mockUserTEamRepo.EXPECT().IsMember(userId, teamId).Return(false, nil)
-
-// 		updatedRecord, err := recordUsecase.AppendEndData(recordId, userId, endsData)
-// 		assert.Error(t, err)
-// 		assert.Nil(t, updatedRecord)
-// 		assert.Equal(t, "appender is not a member of the team", err.Error())
-// 	})
-
-// 	t.Run("異常系: endsDataの検証に失敗する", func(t *testing.T) {
-// 		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(record, nil)
-// 		mockUserTEamRepo.EXPECT().IsMember(userId, teamId).Return(true, nil)
-
-// 		updatedRecord, err := recordUsecase.AppendEndData(recordId, userId, invalidEndsData)
-// 		assert.Error(t, err)
-// 		assert.Nil(t, updatedRecord)
-// 	})
-// }
+func TestAppendEndDataMixedDoubles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockUserTEamRepo := mock.NewMockUserTeamRepository(ctrl)
+	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
+
+	recordUsecase := usecase.NewRecordUsecase(
+		mockRecordRepo,
+		mockUserTEamRepo,
+		mockTeamRepo,
+	)
+
+	recordId := "record-123"
+	userId := "user-123"
+	teamId := "team-123"
+	newRecord := func(format entity.GameFormat) *entity.Record {
+		return entity.NewRecordFromDB(recordId, teamId, "Team B", "Tokyo", entity.Win, time.Now(), nil, false, false, false, entity.WithGameFormat(format))
+	}
+
+	// both pre-placed stones stay, and our last stone ends up closest to the button
+	prePlaced := &entity.Stones{
+		FriendStones: []entity.Coordinate{{Index: 6, R: 1.0, Theta: 1.57}},
+		EnemyStones:  []entity.Coordinate{{Index: 6, R: 4.0, Theta: 1.57}},
+	}
+	shots := make([]entity.Shot, 0, 5)
+	for i := 1; i <= 5; i++ {
+		stones := entity.Stones{
+			FriendStones: []entity.Coordinate{{Index: 6, R: 1.0, Theta: 1.57}},
+			EnemyStones:  []entity.Coordinate{{Index: 6, R: 4.0, Theta: 1.57}},
+		}
+		if i == 5 {
+			stones.FriendStones = append(stones.FriendStones, entity.Coordinate{Index: 5, R: 0.1, Theta: 0})
+		}
+		shots = append(shots, entity.Shot{Type: entity.ShotDraw, SuccessRate: 1, Shooter: "A", Stones: stones})
+	}
+
+	t.Run("正常系: 5投で完了したエンドのスコアが計算される", func(t *testing.T) {
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(newRecord(entity.GameFormatMixedDoubles), nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockRecordRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(r entity.Record) (*entity.Record, error) {
+			return &r, nil
+		})
+
+		endsData := []entity.DataPerEnd{{PrePlaced: prePlaced, PowerPlay: true, Shots: shots, ScoreFilled: true}}
+		updatedRecord, err := recordUsecase.AppendEndData(recordId, userId, endsData, entity.FreeGuardZoneFlag)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, updatedRecord.GetEndsData()[0].Score)
+	})
+
+	t.Run("異常系: 同じチームが2回パワープレーを使う", func(t *testing.T) {
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(newRecord(entity.GameFormatMixedDoubles), nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+
+		// we keep hammer by being scored on in the first end
+		endsData := []entity.DataPerEnd{{Score: -1, PowerPlay: true}, {Score: 1, PowerPlay: true}}
+		updatedRecord, err := recordUsecase.AppendEndData(recordId, userId, endsData, entity.FreeGuardZoneFlag)
+		assert.Nil(t, updatedRecord)
+
+		var validationErr *entity.EndsDataValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "power_play", validationErr.Violations[0].Field)
+		assert.Equal(t, 2, validationErr.Violations[0].End)
+	})
+
+	t.Run("異常系: 4人制ではプレースドストーンを使えない", func(t *testing.T) {
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(newRecord(entity.GameFormatTenEnds), nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+
+		endsData := []entity.DataPerEnd{{PrePlaced: prePlaced}}
+		updatedRecord, err := recordUsecase.AppendEndData(recordId, userId, endsData, entity.FreeGuardZoneFlag)
+		assert.Nil(t, updatedRecord)
+
+		var validationErr *entity.EndsDataValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "pre_placed", validationErr.Violations[0].Field)
+	})
+}
+
+func TestAppendEndDataFreeGuardZone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockUserTEamRepo := mock.NewMockUserTeamRepository(ctrl)
+	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
+
+	recordUsecase := usecase.NewRecordUsecase(
+		mockRecordRepo,
+		mockUserTEamRepo,
+		mockTeamRepo,
+	)
+
+	recordId := "record-123"
+	userId := "user-123"
+	teamId := "team-123"
+
+	// we throw first, so our shots are the 1st, 3rd, 5th and 7th stones of the end
+	newRecord := func() *entity.Record {
+		return entity.NewRecordFromDB(recordId, teamId, "Team B", "Tokyo", entity.Win, time.Now(), nil, false, true, false)
+	}
+	ourGuard := entity.Coordinate{Index: 1, R: 3.0, Theta: 1.57}
+	theirGuard := entity.Coordinate{Index: 1, R: 3.5, Theta: 1.4}
+	ourDraw := entity.Coordinate{Index: 2, R: 0.2, Theta: 0}
+	theirDraw := entity.Coordinate{Index: 2, R: 0.5, Theta: 3}
+	peeled := entity.Coordinate{Index: 3, R: 3.5, Theta: 1.4} // our third stone took their guard's place
+	theirSecondDraw := entity.Coordinate{Index: 4, R: 1.0, Theta: 2}
+	newEnd := func() []entity.DataPerEnd {
+		return []entity.DataPerEnd{{Shots: []entity.Shot{
+			{Type: entity.ShotGuard, SuccessRate: 1, Stones: entity.Stones{FriendStones: []entity.Coordinate{ourGuard}}},
+			{Type: entity.ShotDraw, SuccessRate: 1, Stones: entity.Stones{
+				FriendStones: []entity.Coordinate{ourGuard, ourDraw},
+				EnemyStones:  []entity.Coordinate{theirGuard},
+			}},
+			{Type: entity.ShotPeel, SuccessRate: 1, Stones: entity.Stones{
+				FriendStones: []entity.Coordinate{ourGuard, ourDraw, peeled},
+				EnemyStones:  []entity.Coordinate{theirDraw},
+			}},
+			{Type: entity.ShotGuard, SuccessRate: 1, Stones: entity.Stones{
+				FriendStones: []entity.Coordinate{ourGuard, ourDraw, peeled},
+				EnemyStones:  []entity.Coordinate{theirDraw},
+			}},
+			{Type: entity.ShotDraw, SuccessRate: 1, Stones: entity.Stones{
+				FriendStones: []entity.Coordinate{ourGuard, ourDraw, peeled},
+				EnemyStones:  []entity.Coordinate{theirDraw, theirSecondDraw},
+			}},
+		}}}
+	}
+
+	t.Run("正常系: フリーガードゾーン違反がショットに記録される", func(t *testing.T) {
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(newRecord(), nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockRecordRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(r entity.Record) (*entity.Record, error) {
+			return &r, nil
+		})
+
+		updatedRecord, err := recordUsecase.AppendEndData(recordId, userId, newEnd(), entity.FreeGuardZoneFlag)
+		assert.NoError(t, err)
+
+		shots := updatedRecord.GetEndsData()[0].Shots
+		assert.Nil(t, shots[1].FreeGuardZoneViolation)
+		violation := shots[2].FreeGuardZoneViolation
+		assert.NotNil(t, violation)
+		assert.Equal(t, 5, violation.Delivery)
+		assert.True(t, violation.ByFriend)
+		assert.Equal(t, []int{1}, violation.RemovedEnemyStones)
+		assert.Nil(t, shots[3].FreeGuardZoneViolation)
+	})
+
+	t.Run("正常系: 違反したショットの前の配置に戻される", func(t *testing.T) {
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(newRecord(), nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockRecordRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(r entity.Record) (*entity.Record, error) {
+			return &r, nil
+		})
+
+		updatedRecord, err := recordUsecase.AppendEndData(recordId, userId, newEnd(), entity.FreeGuardZoneRestore)
+		assert.NoError(t, err)
+
+		shots := updatedRecord.GetEndsData()[0].Shots
+		assert.True(t, shots[2].FreeGuardZoneViolation.Restored)
+		assert.Equal(t, []entity.Coordinate{ourGuard, ourDraw}, shots[2].Stones.FriendStones)
+		assert.ElementsMatch(t, []entity.Coordinate{theirGuard, theirDraw}, shots[2].Stones.EnemyStones)
+		// their guard stays in play for the rest of the end, and our peel stays out of it
+		assert.Equal(t, []entity.Coordinate{ourGuard, ourDraw}, shots[3].Stones.FriendStones)
+		assert.ElementsMatch(t, []entity.Coordinate{theirGuard, theirDraw}, shots[3].Stones.EnemyStones)
+		assert.Equal(t, []entity.Coordinate{ourGuard, ourDraw}, shots[4].Stones.FriendStones)
+		assert.ElementsMatch(t, []entity.Coordinate{theirGuard, theirDraw, theirSecondDraw}, shots[4].Stones.EnemyStones)
+		for _, shot := range shots[3:] {
+			assert.Nil(t, shot.FreeGuardZoneViolation)
+		}
+	})
+
+	t.Run("異常系: フリーガードゾーン違反が拒否される", func(t *testing.T) {
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(newRecord(), nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+
+		updatedRecord, err := recordUsecase.AppendEndData(recordId, userId, newEnd(), entity.FreeGuardZoneReject)
+		assert.Nil(t, updatedRecord)
+
+		var validationErr *entity.EndsDataValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "free_guard_zone", validationErr.Violations[0].Field)
+		assert.Equal(t, 3, validationErr.Violations[0].Shot)
+	})
+}
+
+func TestUpdateRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockUserTEamRepo := mock.NewMockUserTeamRepository(ctrl)
+	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
+
+	recordUsecase := usecase.NewRecordUsecase(
+		mockRecordRepo,
+		mockUserTEamRepo,
+		mockTeamRepo,
+	)
+
+	recordId := "record-123"
+	userId := "user-123"
+	teamId := "team-123"
+	newRecord := func() *entity.Record {
+		endsData := []entity.DataPerEnd{{Score: 3}, {Score: -1}, {Score: 4}}
+		return entity.NewRecordFromDB(recordId, teamId, "Team B", "Tokyo", entity.Win, time.Now(), endsData, false, false, false)
+	}
+
+	t.Run("正常系: 相手の棄権で試合が終了する", func(t *testing.T) {
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(newRecord(), nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockRecordRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(r entity.Record) (*entity.Record, error) {
+			return &r, nil
+		})
+
+		updatedRecord, err := recordUsecase.UpdateRecord(recordId, userId, "", "", "", nil, time.Time{}, false, false, false, entity.GameConceded, entity.FreeGuardZoneFlag)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.GameConceded, updatedRecord.GetStatus())
+
+		scoreboard := updatedRecord.Scoreboard()
+		assert.Equal(t, 3, scoreboard.ConcededAfter)
+		assert.Equal(t, 8, scoreboard.RegulationEnds)
+	})
+
+	t.Run("異常系: 引き分けの試合は棄権にできない", func(t *testing.T) {
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(newRecord(), nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+
+		updatedRecord, err := recordUsecase.UpdateRecord(recordId, userId, entity.Draw, "", "", nil, time.Time{}, false, false, false, entity.GameConceded, entity.FreeGuardZoneFlag)
+		assert.Error(t, err)
+		assert.Nil(t, updatedRecord)
+	})
+
+	t.Run("異常系: 同点でないのにエキストラエンドがある", func(t *testing.T) {
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(newRecord(), nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+
+		endsData := make([]entity.DataPerEnd, 9)
+		endsData[0].Score = 1
+		updatedRecord, err := recordUsecase.UpdateRecord(recordId, userId, "", "", "", endsData, time.Time{}, false, false, false, "", entity.FreeGuardZoneFlag)
+		assert.Nil(t, updatedRecord)
+
+		var validationErr *entity.EndsDataValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, 9, validationErr.Violations[0].End)
+	})
+}
+
+func TestGetScoreboard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockUserTEamRepo := mock.NewMockUserTeamRepository(ctrl)
+	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
+
+	recordUsecase := usecase.NewRecordUsecase(
+		mockRecordRepo,
+		mockUserTEamRepo,
+		mockTeamRepo,
+	)
+
+	recordId := "record-123"
+	userId := "user-123"
+	endsData := []entity.DataPerEnd{{Score: 1}, {Score: 0}, {Score: -2}, {Score: -1}, {Score: 3}}
+	record := entity.NewRecordFromDB(recordId, "team-123", "Team B", "Tokyo", entity.Win, time.Now(), endsData, false, false, false)
+
+	t.Run("正常系: スコアボードが計算される", func(t *testing.T) {
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(record, nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, "team-123").Return(entity.RoleMember, nil)
+
+		scoreboard, err := recordUsecase.GetScoreboard(recordId, userId)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, scoreboard.FriendTotal)
+		assert.Equal(t, 3, scoreboard.EnemyTotal)
+		assert.Equal(t, 1, scoreboard.BlankEnds)
+		assert.Equal(t, 0, scoreboard.FriendSteals)
+		assert.Equal(t, 1, scoreboard.EnemySteals)
+		assert.Equal(t, entity.Win, scoreboard.ComputedResult)
+		assert.True(t, scoreboard.ResultMatches)
+
+		hammers := []bool{}
+		for _, end := range scoreboard.Ends {
+			hammers = append(hammers, end.FriendHammer)
+		}
+		assert.Equal(t, []bool{true, false, false, true, true}, hammers)
+		assert.True(t, scoreboard.Ends[3].Steal)
+	})
+
+	t.Run("正常系: エキストラエンドが区別される", func(t *testing.T) {
+		endsData := []entity.DataPerEnd{{Score: 2}, {Score: -1}, {Score: -1}, {}, {}, {}, {}, {}, {Score: 1}}
+		record := entity.NewRecordFromDB(recordId, "team-123", "Team B", "Tokyo", entity.Win, time.Now(), endsData, false, false, false)
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(record, nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, "team-123").Return(entity.RoleMember, nil)
+
+		scoreboard, err := recordUsecase.GetScoreboard(recordId, userId)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.GameCompleted, scoreboard.Status)
+		assert.Equal(t, 1, scoreboard.ExtraEnds)
+		assert.False(t, scoreboard.Ends[7].Extra)
+		assert.True(t, scoreboard.Ends[8].Extra)
+		assert.Equal(t, 0, scoreboard.ConcededAfter)
+	})
+
+	t.Run("正常系: ミックスダブルスではブランクエンドでハンマーが移る", func(t *testing.T) {
+		endsData := []entity.DataPerEnd{{Score: 0}, {Score: 0, PowerPlay: true}, {Score: 2}}
+		record := entity.NewRecordFromDB(recordId, "team-123", "Team B", "Tokyo", entity.Win, time.Now(), endsData, false, false, false, entity.WithGameFormat(entity.GameFormatMixedDoubles))
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(record, nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, "team-123").Return(entity.RoleMember, nil)
+
+		scoreboard, err := recordUsecase.GetScoreboard(recordId, userId)
+		assert.NoError(t, err)
+
+		hammers := []bool{}
+		for _, end := range scoreboard.Ends {
+			hammers = append(hammers, end.FriendHammer)
+		}
+		assert.Equal(t, []bool{true, false, true}, hammers)
+		assert.True(t, scoreboard.Ends[1].PowerPlay)
+	})
+
+	t.Run("異常系: レコードが見つからない", func(t *testing.T) {
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(nil, errors.New("record not found"))
+
+		scoreboard, err := recordUsecase.GetScoreboard(recordId, userId)
+		assert.Error(t, err)
+		assert.Nil(t, scoreboard)
+	})
+
+	t.Run("異常系: 非公開のレコードはチーム外から見えない", func(t *testing.T) {
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(record, nil)
+		mockUserTEamRepo.EXPECT().FindRole("user-456", "team-123").Return(entity.RoleNone, nil)
+
+		scoreboard, err := recordUsecase.GetScoreboard(recordId, "user-456")
+		assert.ErrorIs(t, err, domainerr.ErrRecordNotFound)
+		assert.Nil(t, scoreboard)
+	})
+
+	t.Run("正常系: 公開されたレコードはチーム外からも見える", func(t *testing.T) {
+		public := entity.NewRecordFromDB(recordId, "team-123", "Team B", "Tokyo", entity.Win, time.Now(), endsData, false, false, true)
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(public, nil)
+
+		scoreboard, err := recordUsecase.GetScoreboard(recordId, "user-456")
+		assert.NoError(t, err)
+		assert.Equal(t, 4, scoreboard.FriendTotal)
+	})
+}
+
+func TestGetRecordIndicesByTeamId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockUserTEamRepo := mock.NewMockUserTeamRepository(ctrl)
+	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
+
+	recordUsecase := usecase.NewRecordUsecase(
+		mockRecordRepo,
+		mockUserTEamRepo,
+		mockTeamRepo,
+	)
+
+	teamId := "team-123"
+	userId := "user-123"
+	page := &repository.RecordIndexPage{
+		Indices:    []response.RecordIndex{{Id: "record-1", Result: entity.Win}},
+		NextCursor: &repository.RecordCursor{Sort: repository.SortDateDesc, Value: "2024-01-10T00:00:00Z", Id: "record-1"},
+	}
+
+	t.Run("正常系: 既定の並び順と件数で検索される", func(t *testing.T) {
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockRecordRepo.EXPECT().FindIndicesByTeamId(teamId, repository.RecordQuery{
+			Result: entity.Win,
+			Sort:   repository.SortDateDesc,
+			Limit:  usecase.DefaultRecordPageSize,
+		}).Return(page, nil)
+
+		result, err := recordUsecase.GetRecordIndicesByTeamId(userId, teamId, repository.RecordQuery{Result: entity.Win})
+		assert.NoError(t, err)
+		assert.Equal(t, page, result)
+	})
+
+	t.Run("正常系: カーソルが往復する", func(t *testing.T) {
+		cursor, err := repository.DecodeRecordCursor(page.NextCursor.Encode())
+		assert.NoError(t, err)
+		query := repository.RecordQuery{Sort: repository.SortDateDesc, Cursor: cursor, Limit: 10}
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockRecordRepo.EXPECT().FindIndicesByTeamId(teamId, query).Return(&repository.RecordIndexPage{}, nil)
+
+		_, err = recordUsecase.GetRecordIndicesByTeamId(userId, teamId, query)
+		assert.NoError(t, err)
+	})
+
+	t.Run("正常系: チーム外のユーザーには公開されたレコードだけが返される", func(t *testing.T) {
+		isPublic := true
+		mockUserTEamRepo.EXPECT().FindRole("user-456", teamId).Return(entity.RoleNone, nil)
+		mockRecordRepo.EXPECT().FindIndicesByTeamId(teamId, repository.RecordQuery{
+			IsPublic: &isPublic,
+			Sort:     repository.SortDateDesc,
+			Limit:    usecase.DefaultRecordPageSize,
+		}).Return(page, nil)
+
+		result, err := recordUsecase.GetRecordIndicesByTeamId("user-456", teamId, repository.RecordQuery{})
+		assert.NoError(t, err)
+		assert.Equal(t, page, result)
+	})
+
+	t.Run("正常系: チーム外のユーザーが非公開のレコードを求めると空になる", func(t *testing.T) {
+		isPublic := false
+		mockUserTEamRepo.EXPECT().FindRole("user-456", teamId).Return(entity.RoleNone, nil)
+
+		result, err := recordUsecase.GetRecordIndicesByTeamId("user-456", teamId, repository.RecordQuery{IsPublic: &isPublic})
+		assert.NoError(t, err)
+		assert.Empty(t, result.Indices)
+	})
+
+	t.Run("異常系: 並び順とカーソルが一致しない", func(t *testing.T) {
+		query := repository.RecordQuery{Sort: repository.SortOpponentAsc, Cursor: page.NextCursor}
+
+		result, err := recordUsecase.GetRecordIndicesByTeamId(userId, teamId, query)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("異常系: 不正な条件", func(t *testing.T) {
+		from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		for _, query := range []repository.RecordQuery{
+			{Sort: "score"},
+			{Limit: usecase.MaxRecordPageSize + 1},
+			{Result: "FORFEIT"},
+			{From: from, To: from.AddDate(0, 0, -1)},
+		} {
+			result, err := recordUsecase.GetRecordIndicesByTeamId(userId, teamId, query)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		}
+	})
+
+	t.Run("異常系: 不正なカーソル", func(t *testing.T) {
+		cursor, err := repository.DecodeRecordCursor("not-a-cursor")
+		assert.Error(t, err)
+		assert.Nil(t, cursor)
+	})
+}
+
+func TestSetLineup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockUserTEamRepo := mock.NewMockUserTeamRepository(ctrl)
+	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
+
+	recordUsecase := usecase.NewRecordUsecase(
+		mockRecordRepo,
+		mockUserTEamRepo,
+		mockTeamRepo,
+	)
+
+	recordId := "record-123"
+	userId := "user-123"
+	teamId := "team-123"
+	endsData := []entity.DataPerEnd{
+		{Score: 0, Shots: []entity.Shot{
+			{Type: entity.ShotGuard, SuccessRate: 0.5, Shooter: "Lead"},
+			{Type: entity.ShotDraw, SuccessRate: 0.5, Shooter: "Skip"},
+		}},
+	}
+	newRecord := func() *entity.Record {
+		return entity.NewRecordFromDB(recordId, teamId, "Team B", "Tokyo", entity.Win, time.Now(), endsData, false, false, false)
+	}
+	lineup := entity.Lineup{
+		Entries: []entity.LineupEntry{
+			{Position: entity.PositionLead, LineupPlayer: entity.LineupPlayer{UserId: "user-lead"}},
+			{Position: entity.PositionSkip, LineupPlayer: entity.LineupPlayer{GuestName: "Taro"}},
+		},
+	}
+
+	t.Run("正常系: ラインナップが設定される", func(t *testing.T) {
+		record := newRecord()
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(record, nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockUserTEamRepo.EXPECT().IsMember("user-lead", teamId).Return(true, nil)
+		mockRecordRepo.EXPECT().Update(gomock.Any()).Return(record, nil)
+
+		updatedRecord, err := recordUsecase.SetLineup(recordId, userId, lineup)
+		assert.NoError(t, err)
+		assert.Equal(t, lineup, updatedRecord.GetLineup())
+	})
+
+	t.Run("異常系: 選手がチームに所属していない", func(t *testing.T) {
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(newRecord(), nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockUserTEamRepo.EXPECT().IsMember("user-lead", teamId).Return(false, nil)
+
+		updatedRecord, err := recordUsecase.SetLineup(recordId, userId, lineup)
+		assert.Error(t, err)
+		assert.Nil(t, updatedRecord)
+		assert.Equal(t, "player user-lead is not a member of the team", err.Error())
+		assert.True(t, domainerr.IsKind(err, domainerr.KindValidation))
+	})
+
+	t.Run("異常系: ラインナップで解決できないシューターがいる", func(t *testing.T) {
+		leadOnly := entity.Lineup{Entries: lineup.Entries[:1]}
+
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(newRecord(), nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockUserTEamRepo.EXPECT().IsMember("user-lead", teamId).Return(true, nil)
+
+		updatedRecord, err := recordUsecase.SetLineup(recordId, userId, leadOnly)
+		assert.Nil(t, updatedRecord)
+
+		var validationErr *entity.EndsDataValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "shooter", validationErr.Violations[0].Field)
+		assert.Equal(t, 2, validationErr.Violations[0].Shot)
+	})
+}
+
+func TestAddSubstitution(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockUserTEamRepo := mock.NewMockUserTeamRepository(ctrl)
+	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
+
+	recordUsecase := usecase.NewRecordUsecase(
+		mockRecordRepo,
+		mockUserTEamRepo,
+		mockTeamRepo,
+	)
+
+	recordId := "record-123"
+	userId := "user-123"
+	teamId := "team-123"
+	lineup := entity.Lineup{
+		Entries: []entity.LineupEntry{
+			{Position: entity.PositionLead, LineupPlayer: entity.LineupPlayer{UserId: "user-lead"}},
+		},
+	}
+	substitution := entity.Substitution{
+		End:          3,
+		Position:     entity.PositionLead,
+		LineupPlayer: entity.LineupPlayer{UserId: "user-alternate"},
+	}
+
+	t.Run("正常系: 交代が記録される", func(t *testing.T) {
+		record := entity.NewRecordFromDB(recordId, teamId, "Team B", "Tokyo", entity.Win, time.Now(), nil, false, false, false, entity.WithLineup(lineup))
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(record, nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockUserTEamRepo.EXPECT().IsMember("user-alternate", teamId).Return(true, nil)
+		mockRecordRepo.EXPECT().Update(gomock.Any()).Return(record, nil)
+
+		updatedRecord, err := recordUsecase.AddSubstitution(recordId, userId, substitution)
+		assert.NoError(t, err)
+
+		player, ok := updatedRecord.GetLineup().Resolve(2, 1, "Lead")
+		assert.True(t, ok)
+		assert.Equal(t, "user-lead", player.UserId)
+		player, ok = updatedRecord.GetLineup().Resolve(3, 1, "Lead")
+		assert.True(t, ok)
+		assert.Equal(t, "user-alternate", player.UserId)
+	})
+
+	t.Run("異常系: ラインナップが未設定", func(t *testing.T) {
+		record := entity.NewRecordFromDB(recordId, teamId, "Team B", "Tokyo", entity.Win, time.Now(), nil, false, false, false)
+		mockRecordRepo.EXPECT().FindByRecordId(recordId).Return(record, nil)
+		mockUserTEamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockUserTEamRepo.EXPECT().IsMember("user-alternate", teamId).Return(true, nil)
+
+		updatedRecord, err := recordUsecase.AddSubstitution(recordId, userId, substitution)
+		assert.Error(t, err)
+		assert.Nil(t, updatedRecord)
+	})
+}
@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"CurlARC/internal/domain/domainerr"
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/repository"
+	"sort"
+	"strings"
+	"time"
+)
+
+type SeasonUsecase interface {
+	CreateSeason(userId, teamId, name string, startDate, endDate time.Time) (*entity.Season, error)
+	GetSeasonsByTeamId(userId, teamId string) ([]*entity.Season, error)
+	UpdateSeason(userId, teamId, seasonId string, name *string, startDate, endDate *time.Time) (*entity.Season, error)
+	DeleteSeason(userId, teamId, seasonId string) error
+	GetSeasonSummary(userId, teamId, seasonId string) (*SeasonSummary, error)
+}
+
+// SeasonTopShooters is how many shooters the season summary lists.
+const SeasonTopShooters = 5
+
+// Streak is a run of consecutive games with the same result. Abandoned games do not break a streak.
+type Streak struct {
+	Result entity.Result
+	Games  int
+}
+
+type VenueSummary struct {
+	Place  string
+	Games  int
+	Wins   int
+	Losses int
+	Draws  int
+}
+
+// SeasonSummary reports on the games of a season. Abandoned games are counted apart and left out of
+// the results, the streaks and the points.
+type SeasonSummary struct {
+	Season            *entity.Season
+	TeamName          string
+	Games             int
+	Wins              int
+	Losses            int
+	Draws             int
+	Abandoned         int
+	PointsFor         int
+	PointsAgainst     int
+	LongestWinStreak  int
+	LongestLossStreak int
+	CurrentStreak     Streak
+	TopShooters       []ShooterStats // best average success rate first
+	Hammer            HammerStats
+	Opponents         []HeadToHead
+	Venues            []VenueSummary // most visited first
+}
+
+type seasonUsecase struct {
+	seasonRepo   repository.SeasonRepository
+	recordRepo   repository.RecordRepository
+	teamRepo     repository.TeamRepository
+	userTeamRepo repository.UserTeamRepository
+	policy       Policy
+}
+
+func NewSeasonUsecase(seasonRepo repository.SeasonRepository, recordRepo repository.RecordRepository, teamRepo repository.TeamRepository, userTeamRepo repository.UserTeamRepository) SeasonUsecase {
+	return &seasonUsecase{seasonRepo: seasonRepo, recordRepo: recordRepo, teamRepo: teamRepo, userTeamRepo: userTeamRepo, policy: NewPolicy(userTeamRepo)}
+}
+
+// findSeason returns a season of the team, after asking the policy whether the user may access the team.
+func (u *seasonUsecase) findSeason(access func(userId, teamId string) error, userId, teamId, seasonId string) (*entity.Season, error) {
+	if err := access(userId, teamId); err != nil {
+		return nil, err
+	}
+	season, err := u.seasonRepo.FindById(seasonId)
+	if err != nil {
+		return nil, err
+	}
+	if season.GetTeamId() != teamId {
+		return nil, domainerr.ErrSeasonNotFound.Errorf("season does not belong to the team")
+	}
+	return season, nil
+}
+
+func (u *seasonUsecase) CreateSeason(userId, teamId, name string, startDate, endDate time.Time) (*entity.Season, error) {
+	if err := u.policy.CanWriteTeam(userId, teamId); err != nil {
+		return nil, err
+	}
+	if _, err := u.teamRepo.FindById(teamId); err != nil {
+		return nil, err
+	}
+
+	season, err := entity.NewSeason(teamId, name, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return u.seasonRepo.Save(season)
+}
+
+func (u *seasonUsecase) GetSeasonsByTeamId(userId, teamId string) ([]*entity.Season, error) {
+	if err := u.policy.CanReadTeam(userId, teamId); err != nil {
+		return nil, err
+	}
+	return u.seasonRepo.FindByTeamId(teamId)
+}
+
+// UpdateSeason changes the given fields and keeps the others.
+func (u *seasonUsecase) UpdateSeason(userId, teamId, seasonId string, name *string, startDate, endDate *time.Time) (*entity.Season, error) {
+	season, err := u.findSeason(u.policy.CanWriteTeam, userId, teamId, seasonId)
+	if err != nil {
+		return nil, err
+	}
+
+	if name != nil {
+		if err := season.SetName(*name); err != nil {
+			return nil, err
+		}
+	}
+	start, end := season.GetStartDate(), season.GetEndDate()
+	if startDate != nil {
+		start = *startDate
+	}
+	if endDate != nil {
+		end = *endDate
+	}
+	if err := season.SetDates(start, end); err != nil {
+		return nil, err
+	}
+
+	return u.seasonRepo.Update(season)
+}
+
+func (u *seasonUsecase) DeleteSeason(userId, teamId, seasonId string) error {
+	if _, err := u.findSeason(u.policy.CanWriteTeam, userId, teamId, seasonId); err != nil {
+		return err
+	}
+	return u.seasonRepo.Delete(seasonId)
+}
+
+func (u *seasonUsecase) GetSeasonSummary(userId, teamId, seasonId string) (*SeasonSummary, error) {
+	season, err := u.findSeason(u.policy.CanReadTeam, userId, teamId, seasonId)
+	if err != nil {
+		return nil, err
+	}
+	team, err := u.teamRepo.FindById(teamId)
+	if err != nil {
+		return nil, err
+	}
+	teamRecords, err := u.recordRepo.FindByTeamId(teamId)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []entity.Record
+	for _, record := range *teamRecords {
+		if season.Contains(record.GetDate()) {
+			records = append(records, record)
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].GetDate().Before(records[j].GetDate()) })
+
+	summary := &SeasonSummary{
+		Season:      season,
+		TeamName:    team.GetName(),
+		TopShooters: shooterStatsOf(records),
+		Hammer:      hammerStatsOf(records),
+		Opponents:   headToHeadOf(append([]entity.Record(nil), records...)),
+	}
+
+	var venueKeys []string
+	venues := map[string]*VenueSummary{}
+	for _, record := range records {
+		if !record.GetStatus().IsFinished() {
+			summary.Abandoned++
+			continue
+		}
+
+		result := record.GetResult()
+		summary.Games++
+		scoreboard := record.Scoreboard()
+		summary.PointsFor += scoreboard.FriendTotal
+		summary.PointsAgainst += scoreboard.EnemyTotal
+
+		if result == summary.CurrentStreak.Result {
+			summary.CurrentStreak.Games++
+		} else {
+			summary.CurrentStreak = Streak{Result: result, Games: 1}
+		}
+
+		key := strings.ToLower(strings.Join(strings.Fields(record.GetPlace()), " "))
+		venue, ok := venues[key]
+		if !ok {
+			venue = &VenueSummary{Place: strings.TrimSpace(record.GetPlace())}
+			venues[key] = venue
+			venueKeys = append(venueKeys, key)
+		}
+		venue.Games++
+
+		switch result {
+		case entity.Win:
+			summary.Wins++
+			venue.Wins++
+			summary.LongestWinStreak = max(summary.LongestWinStreak, summary.CurrentStreak.Games)
+		case entity.Loss:
+			summary.Losses++
+			venue.Losses++
+			summary.LongestLossStreak = max(summary.LongestLossStreak, summary.CurrentStreak.Games)
+		case entity.Draw:
+			summary.Draws++
+			venue.Draws++
+		}
+	}
+
+	for _, key := range venueKeys {
+		summary.Venues = append(summary.Venues, *venues[key])
+	}
+	sort.SliceStable(summary.Venues, func(i, j int) bool { return summary.Venues[i].Games > summary.Venues[j].Games })
+
+	sort.SliceStable(summary.TopShooters, func(i, j int) bool {
+		a, b := summary.TopShooters[i].Overall, summary.TopShooters[j].Overall
+		if a.Average != b.Average {
+			return a.Average > b.Average
+		}
+		return a.Shots > b.Shots
+	})
+	if len(summary.TopShooters) > SeasonTopShooters {
+		summary.TopShooters = summary.TopShooters[:SeasonTopShooters]
+	}
+
+	return summary, nil
+}
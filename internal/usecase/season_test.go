@@ -0,0 +1,146 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/usecase"
+	"CurlARC/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSeason(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSeasonRepo := mock.NewMockSeasonRepository(ctrl)
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
+	mockUserTeamRepo := mock.NewMockUserTeamRepository(ctrl)
+
+	seasonUsecase := usecase.NewSeasonUsecase(mockSeasonRepo, mockRecordRepo, mockTeamRepo, mockUserTeamRepo)
+
+	userId := "user-123"
+	teamId := "team-123"
+	start := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常系: シーズンが作成される", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockTeamRepo.EXPECT().FindById(teamId).Return(entity.NewTeamFromDB(teamId, "Team A"), nil)
+		mockSeasonRepo.EXPECT().Save(gomock.Any()).DoAndReturn(func(s *entity.Season) (*entity.Season, error) {
+			return s, nil
+		})
+
+		season, err := seasonUsecase.CreateSeason(userId, teamId, " 2024-25 ", start, end)
+		assert.NoError(t, err)
+		assert.Equal(t, "2024-25", season.GetName())
+		assert.Equal(t, teamId, season.GetTeamId())
+		assert.True(t, season.Contains(end.Add(23*time.Hour)))
+		assert.False(t, season.Contains(end.AddDate(0, 0, 1)))
+	})
+
+	t.Run("異常系: 終了日が開始日より前", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockTeamRepo.EXPECT().FindById(teamId).Return(entity.NewTeamFromDB(teamId, "Team A"), nil)
+
+		season, err := seasonUsecase.CreateSeason(userId, teamId, "2024-25", end, start)
+		assert.Error(t, err)
+		assert.Nil(t, season)
+	})
+
+	t.Run("異常系: ユーザーがチームに所属していない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleNone, nil)
+
+		season, err := seasonUsecase.CreateSeason(userId, teamId, "2024-25", start, end)
+		assert.Error(t, err)
+		assert.Nil(t, season)
+	})
+}
+
+func TestGetSeasonSummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSeasonRepo := mock.NewMockSeasonRepository(ctrl)
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
+	mockUserTeamRepo := mock.NewMockUserTeamRepository(ctrl)
+
+	seasonUsecase := usecase.NewSeasonUsecase(mockSeasonRepo, mockRecordRepo, mockTeamRepo, mockUserTeamRepo)
+
+	userId := "user-123"
+	teamId := "team-123"
+	season := entity.NewSeasonFromDB("season-1", teamId, "2024-25",
+		time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC))
+	day := func(month time.Month, d int) time.Time {
+		year := 2024
+		if month < time.September {
+			year = 2025
+		}
+		return time.Date(year, month, d, 18, 0, 0, 0, time.UTC)
+	}
+	ends := func(shooter string, rate float64, scores ...int) []entity.DataPerEnd {
+		data := make([]entity.DataPerEnd, 0, len(scores))
+		for _, score := range scores {
+			data = append(data, entity.DataPerEnd{Score: score, Shots: []entity.Shot{{Type: entity.ShotDraw, SuccessRate: rate, Shooter: shooter}}})
+		}
+		return data
+	}
+
+	records := []entity.Record{
+		*entity.NewRecordFromDB("record-1", teamId, "Team B", " tokyo", entity.Win, day(10, 1), ends("Lead", 0.5, 2, -1), false, false, false),
+		*entity.NewRecordFromDB("record-2", teamId, "Team C", "Tokyo", entity.Win, day(9, 1), ends("Skip", 0.9, 3), false, false, false),
+		*entity.NewRecordFromDB("record-3", teamId, "Team B", "Sapporo", entity.Loss, day(11, 1), ends("Lead", 0.7, -2, 1), false, false, false),
+		*entity.NewRecordFromDB("record-4", teamId, "Team D", "Tokyo", entity.Draw, day(12, 1), ends("Lead", 0.5, 1), false, false, false,
+			entity.WithStatus(entity.GameAbandoned)),
+		*entity.NewRecordFromDB("record-5", teamId, "Team C", "Sapporo", entity.Loss, day(1, 10), ends("Skip", 0.6, -1), false, false, false),
+		*entity.NewRecordFromDB("record-6", teamId, "Team B", "Tokyo", entity.Win, day(4, 1), ends("Skip", 1.0, 5), false, false, false),
+	}
+
+	t.Run("正常系: シーズン内の試合が集計される", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockSeasonRepo.EXPECT().FindById("season-1").Return(season, nil)
+		mockTeamRepo.EXPECT().FindById(teamId).Return(entity.NewTeamFromDB(teamId, "Team A"), nil)
+		mockRecordRepo.EXPECT().FindByTeamId(teamId).Return(&records, nil)
+
+		summary, err := seasonUsecase.GetSeasonSummary(userId, teamId, "season-1")
+		assert.NoError(t, err)
+		assert.Equal(t, "Team A", summary.TeamName)
+
+		// record-6 is played after the season and record-4 is abandoned
+		assert.Equal(t, 4, summary.Games)
+		assert.Equal(t, 1, summary.Abandoned)
+		assert.Equal(t, 2, summary.Wins)
+		assert.Equal(t, 2, summary.Losses)
+		assert.Equal(t, 0, summary.Draws)
+		assert.Equal(t, 6, summary.PointsFor)
+		assert.Equal(t, 4, summary.PointsAgainst)
+		assert.Equal(t, 2, summary.LongestWinStreak)
+		assert.Equal(t, 2, summary.LongestLossStreak)
+		assert.Equal(t, usecase.Streak{Result: entity.Loss, Games: 2}, summary.CurrentStreak)
+
+		if assert.Len(t, summary.TopShooters, 2) {
+			assert.Equal(t, "Skip", summary.TopShooters[0].Shooter)
+			assert.Equal(t, "Lead", summary.TopShooters[1].Shooter)
+		}
+
+		if assert.Len(t, summary.Venues, 2) {
+			assert.Equal(t, usecase.VenueSummary{Place: "Tokyo", Games: 2, Wins: 2}, summary.Venues[0])
+			assert.Equal(t, usecase.VenueSummary{Place: "Sapporo", Games: 2, Losses: 2}, summary.Venues[1])
+		}
+		assert.Len(t, summary.Opponents, 3)
+	})
+
+	t.Run("異常系: 他チームのシーズン", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockSeasonRepo.EXPECT().FindById("season-2").Return(entity.NewSeasonFromDB("season-2", "team-456", "2024-25", day(9, 1), day(3, 31)), nil)
+
+		summary, err := seasonUsecase.GetSeasonSummary(userId, teamId, "season-2")
+		assert.Error(t, err)
+		assert.Nil(t, summary)
+	})
+}
@@ -0,0 +1,898 @@
+package usecase
+
+import (
+	"CurlARC/internal/domain/domainerr"
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/geometry"
+	"CurlARC/internal/domain/repository"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+type StatsUsecase interface {
+	GetShooterStats(userId, teamId string, filter StatsFilter) ([]ShooterStats, error)
+	GetHammerStats(userId, teamId string, filter StatsFilter) (*HammerStats, error)
+	GetHeadToHead(userId, teamId string, filter StatsFilter) ([]HeadToHead, error)
+	GetHeatmap(userId, teamId string, filter StatsFilter, query HeatmapQuery) (*Heatmap, error)
+	GetWinProbability(userId, teamId string, format entity.GameFormat, end, scoreDifference int, hammer bool) (*WinProbability, error)
+	GetWinProbabilityCurve(userId, recordId string) (*WinProbabilityCurve, error)
+	FindSimilarPositions(userId, teamId string, query PositionQuery) ([]SimilarPosition, error)
+}
+
+// StatsFilter narrows down the records a statistic is computed from.
+// Zero values mean no restriction.
+type StatsFilter struct {
+	From     time.Time
+	To       time.Time
+	Opponent string
+}
+
+// SuccessRate is the average success rate over a number of shots.
+type SuccessRate struct {
+	Shots   int
+	Average float64
+}
+
+// ShooterStats holds the success rates of one shooter. When the record has a lineup the shooter is resolved
+// to a player, so a registered user is tracked by ID whatever name or position the shot was recorded under.
+type ShooterStats struct {
+	Shooter       string
+	Player        *entity.LineupPlayer
+	Overall       SuccessRate
+	ByShotType    map[string]SuccessRate
+	ByEnd         map[int]SuccessRate
+	WithHammer    SuccessRate
+	WithoutHammer SuccessRate
+}
+
+type statsUsecase struct {
+	recordRepo   repository.RecordRepository
+	userTeamRepo repository.UserTeamRepository
+	policy       Policy
+}
+
+func NewStatsUsecase(recordRepo repository.RecordRepository, userTeamRepo repository.UserTeamRepository) StatsUsecase {
+	return &statsUsecase{recordRepo: recordRepo, userTeamRepo: userTeamRepo, policy: NewPolicy(userTeamRepo)}
+}
+
+// findRecords returns the records of the team matching the filter, after checking the user may read the team.
+func (u *statsUsecase) findRecords(userId, teamId string, filter StatsFilter) ([]entity.Record, error) {
+	if err := u.policy.CanReadTeam(userId, teamId); err != nil {
+		return nil, err
+	}
+
+	records, err := u.recordRepo.FindByTeamId(teamId)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []entity.Record
+	for _, record := range *records {
+		if filter.matches(&record) {
+			matched = append(matched, record)
+		}
+	}
+	return matched, nil
+}
+
+func (f StatsFilter) matches(record *entity.Record) bool {
+	if !f.coversDate(record.GetDate()) {
+		return false
+	}
+	if f.Opponent != "" && entity.NormalizeTeamName(record.GetEnemyTeamName()) != entity.NormalizeTeamName(f.Opponent) {
+		return false
+	}
+	return true
+}
+
+// coversDate tells whether a date falls within the period of the filter.
+func (f StatsFilter) coversDate(date time.Time) bool {
+	return (f.From.IsZero() || !date.Before(f.From)) && (f.To.IsZero() || !date.After(f.To))
+}
+
+// rateAccumulator sums success rates until the average is taken.
+type rateAccumulator struct {
+	shots int
+	sum   float64
+}
+
+func (a *rateAccumulator) add(rate float64) {
+	a.shots++
+	a.sum += rate
+}
+
+// merge adds the shots behind an average already taken.
+func (a *rateAccumulator) merge(rate SuccessRate) {
+	a.shots += rate.Shots
+	a.sum += rate.Average * float64(rate.Shots)
+}
+
+func (a rateAccumulator) rate() SuccessRate {
+	if a.shots == 0 {
+		return SuccessRate{}
+	}
+	return SuccessRate{Shots: a.shots, Average: a.sum / float64(a.shots)}
+}
+
+type shooterAccumulator struct {
+	shooter       string
+	player        *entity.LineupPlayer
+	overall       rateAccumulator
+	byShotType    map[string]*rateAccumulator
+	byEnd         map[int]*rateAccumulator
+	withHammer    rateAccumulator
+	withoutHammer rateAccumulator
+}
+
+func (u *statsUsecase) GetShooterStats(userId, teamId string, filter StatsFilter) ([]ShooterStats, error) {
+	records, err := u.findRecords(userId, teamId, filter)
+	if err != nil {
+		return nil, err
+	}
+	return shooterStatsOf(records), nil
+}
+
+// shooterStatsOf computes the success rates of every shooter of the records, sorted by shooter.
+func shooterStatsOf(records []entity.Record) []ShooterStats {
+	accumulators := map[string]*shooterAccumulator{}
+	for _, record := range records {
+		scoreboard := record.Scoreboard()
+		for i, end := range record.GetEndsData() {
+			hasHammer := scoreboard.Ends[i].FriendHammer
+			for j, shot := range end.Shots {
+				shooter := strings.TrimSpace(shot.Shooter)
+				if shooter == "" {
+					continue
+				}
+
+				key := "shooter:" + shooter
+				var player *entity.LineupPlayer
+				if resolved, ok := record.GetLineup().Resolve(i+1, j+1, shooter); ok {
+					key = resolved.Key()
+					player = &resolved
+				}
+
+				acc, ok := accumulators[key]
+				if !ok {
+					acc = &shooterAccumulator{
+						shooter:    shooter,
+						player:     player,
+						byShotType: map[string]*rateAccumulator{},
+						byEnd:      map[int]*rateAccumulator{},
+					}
+					if player != nil {
+						acc.shooter = player.UserId
+						if acc.shooter == "" {
+							acc.shooter = player.GuestName
+						}
+					}
+					accumulators[key] = acc
+				}
+
+				acc.overall.add(shot.SuccessRate)
+				shotType := string(shot.Type)
+				if acc.byShotType[shotType] == nil {
+					acc.byShotType[shotType] = &rateAccumulator{}
+				}
+				acc.byShotType[shotType].add(shot.SuccessRate)
+				if acc.byEnd[i+1] == nil {
+					acc.byEnd[i+1] = &rateAccumulator{}
+				}
+				acc.byEnd[i+1].add(shot.SuccessRate)
+				if hasHammer {
+					acc.withHammer.add(shot.SuccessRate)
+				} else {
+					acc.withoutHammer.add(shot.SuccessRate)
+				}
+			}
+		}
+	}
+
+	stats := make([]ShooterStats, 0, len(accumulators))
+	for _, acc := range accumulators {
+		s := ShooterStats{
+			Shooter:       acc.shooter,
+			Player:        acc.player,
+			Overall:       acc.overall.rate(),
+			ByShotType:    make(map[string]SuccessRate, len(acc.byShotType)),
+			ByEnd:         make(map[int]SuccessRate, len(acc.byEnd)),
+			WithHammer:    acc.withHammer.rate(),
+			WithoutHammer: acc.withoutHammer.rate(),
+		}
+		for shotType, a := range acc.byShotType {
+			s.ByShotType[shotType] = a.rate()
+		}
+		for end, a := range acc.byEnd {
+			s.ByEnd[end] = a.rate()
+		}
+		stats = append(stats, s)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Shooter < stats[j].Shooter })
+
+	return stats
+}
+
+// ScoreSituation is the score difference from our point of view at the start of an end.
+type ScoreSituation string
+
+const (
+	SituationUp   ScoreSituation = "UP"
+	SituationDown ScoreSituation = "DOWN"
+	SituationTied ScoreSituation = "TIED"
+)
+
+// EndPhase groups ends of a game: the first three ends are early, the last three regulation ends
+// are late, and the ends in between are the middle of the game. Extra ends are kept apart.
+type EndPhase string
+
+const (
+	PhaseEarly  EndPhase = "EARLY"
+	PhaseMiddle EndPhase = "MIDDLE"
+	PhaseLate   EndPhase = "LATE"
+	PhaseExtra  EndPhase = "EXTRA"
+)
+
+func endPhase(end, regulationEnds int) EndPhase {
+	switch {
+	case end > regulationEnds:
+		return PhaseExtra
+	case end > regulationEnds-3:
+		return PhaseLate
+	case end <= 3:
+		return PhaseEarly
+	default:
+		return PhaseMiddle
+	}
+}
+
+// HammerEfficiency summarises the ends played with hammer.
+type HammerEfficiency struct {
+	Ends           int
+	TwoOrMore      int // scored two or more points
+	ForcedToOne    int // held to a single point
+	Blanks         int
+	StolenAgainst  int // the opponent scored
+	ConversionRate float64
+	ForcedRate     float64
+	BlankRate      float64
+	StolenRate     float64
+}
+
+// StealDefense summarises the ends played without hammer.
+type StealDefense struct {
+	Ends                 int
+	Steals               int // we scored
+	ForcedOpponentToOne  int // the opponent scored a single point
+	AllowedTwoOrMore     int // the opponent scored two or more points
+	Blanks               int
+	StealRate            float64
+	ForceRate            float64
+	AllowedTwoOrMoreRate float64
+	BlankRate            float64
+}
+
+type HammerSplit struct {
+	WithHammer    HammerEfficiency
+	WithoutHammer StealDefense
+}
+
+type HammerStats struct {
+	Records     int
+	Overall     HammerSplit
+	BySituation map[ScoreSituation]HammerSplit
+	ByPhase     map[EndPhase]HammerSplit
+}
+
+func (s *HammerSplit) add(score int, hasHammer bool) {
+	if hasHammer {
+		h := &s.WithHammer
+		h.Ends++
+		switch {
+		case score >= 2:
+			h.TwoOrMore++
+		case score == 1:
+			h.ForcedToOne++
+		case score == 0:
+			h.Blanks++
+		default:
+			h.StolenAgainst++
+		}
+		return
+	}
+
+	d := &s.WithoutHammer
+	d.Ends++
+	switch {
+	case score > 0:
+		d.Steals++
+	case score == -1:
+		d.ForcedOpponentToOne++
+	case score <= -2:
+		d.AllowedTwoOrMore++
+	default:
+		d.Blanks++
+	}
+}
+
+func (s HammerSplit) withRates() HammerSplit {
+	h := &s.WithHammer
+	h.ConversionRate = ratio(h.TwoOrMore, h.Ends)
+	h.ForcedRate = ratio(h.ForcedToOne, h.Ends)
+	h.BlankRate = ratio(h.Blanks, h.Ends)
+	h.StolenRate = ratio(h.StolenAgainst, h.Ends)
+
+	d := &s.WithoutHammer
+	d.StealRate = ratio(d.Steals, d.Ends)
+	d.ForceRate = ratio(d.ForcedOpponentToOne, d.Ends)
+	d.AllowedTwoOrMoreRate = ratio(d.AllowedTwoOrMore, d.Ends)
+	d.BlankRate = ratio(d.Blanks, d.Ends)
+	return s
+}
+
+func ratio(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
+
+func (u *statsUsecase) GetHammerStats(userId, teamId string, filter StatsFilter) (*HammerStats, error) {
+	records, err := u.findRecords(userId, teamId, filter)
+	if err != nil {
+		return nil, err
+	}
+	stats := hammerStatsOf(records)
+	return &stats, nil
+}
+
+func hammerStatsOf(records []entity.Record) HammerStats {
+	var overall HammerSplit
+	bySituation := map[ScoreSituation]*HammerSplit{}
+	byPhase := map[EndPhase]*HammerSplit{}
+	for _, record := range records {
+		for _, end := range record.Scoreboard().Ends {
+			score := end.FriendPoints - end.EnemyPoints
+
+			// the score difference before this end was played
+			diff := (end.FriendTotal - end.EnemyTotal) - score
+			situation := SituationTied
+			if diff > 0 {
+				situation = SituationUp
+			} else if diff < 0 {
+				situation = SituationDown
+			}
+			phase := endPhase(end.End, record.RegulationEnds())
+
+			if bySituation[situation] == nil {
+				bySituation[situation] = &HammerSplit{}
+			}
+			if byPhase[phase] == nil {
+				byPhase[phase] = &HammerSplit{}
+			}
+			overall.add(score, end.FriendHammer)
+			bySituation[situation].add(score, end.FriendHammer)
+			byPhase[phase].add(score, end.FriendHammer)
+		}
+	}
+
+	stats := HammerStats{
+		Records:     len(records),
+		Overall:     overall.withRates(),
+		BySituation: make(map[ScoreSituation]HammerSplit, len(bySituation)),
+		ByPhase:     make(map[EndPhase]HammerSplit, len(byPhase)),
+	}
+	for situation, split := range bySituation {
+		stats.BySituation[situation] = split.withRates()
+	}
+	for phase, split := range byPhase {
+		stats.ByPhase[phase] = split.withRates()
+	}
+
+	return stats
+}
+
+type HeadToHeadMatch struct {
+	RecordId      string
+	Date          time.Time
+	Place         string
+	Result        entity.Result
+	Status        entity.GameStatus
+	EndsPlayed    int
+	PointsFor     int
+	PointsAgainst int
+}
+
+// HeadToHead is the history against one opponent.
+// Opponent is the most recent spelling of the name among the grouped records.
+// Abandoned games are listed but left out of the results and the per-game averages; the per-end averages
+// cover every end played, so a conceded game weighs only as much as the ends it lasted.
+type HeadToHead struct {
+	Opponent                   string
+	Wins                       int
+	Losses                     int
+	Draws                      int
+	Abandoned                  int
+	AveragePointsFor           float64
+	AveragePointsAgainst       float64
+	AveragePointsForPerEnd     float64
+	AveragePointsAgainstPerEnd float64
+	Hammer                     HammerSplit
+	Matches                    []HeadToHeadMatch
+}
+
+func (u *statsUsecase) GetHeadToHead(userId, teamId string, filter StatsFilter) ([]HeadToHead, error) {
+	records, err := u.findRecords(userId, teamId, filter)
+	if err != nil {
+		return nil, err
+	}
+	return headToHeadOf(records), nil
+}
+
+// headToHeadOf groups the records by opponent, the opponents met most often first.
+func headToHeadOf(records []entity.Record) []HeadToHead {
+	// most recent matches first, so the first spelling seen is the latest one
+	sort.SliceStable(records, func(i, j int) bool { return records[i].GetDate().After(records[j].GetDate()) })
+
+	var keys []string
+	histories := map[string]*HeadToHead{}
+	for _, record := range records {
+		key := entity.NormalizeTeamName(record.GetEnemyTeamName())
+		history, ok := histories[key]
+		if !ok {
+			history = &HeadToHead{Opponent: strings.TrimSpace(record.GetEnemyTeamName())}
+			histories[key] = history
+			keys = append(keys, key)
+		}
+
+		switch {
+		case !record.GetStatus().IsFinished():
+			history.Abandoned++
+		case record.GetResult() == entity.Win:
+			history.Wins++
+		case record.GetResult() == entity.Loss:
+			history.Losses++
+		case record.GetResult() == entity.Draw:
+			history.Draws++
+		}
+
+		scoreboard := record.Scoreboard()
+		for _, end := range scoreboard.Ends {
+			history.Hammer.add(end.FriendPoints-end.EnemyPoints, end.FriendHammer)
+		}
+		history.Matches = append(history.Matches, HeadToHeadMatch{
+			RecordId:      record.GetId().Value(),
+			Date:          record.GetDate(),
+			Place:         record.GetPlace(),
+			Result:        record.GetResult(),
+			Status:        scoreboard.Status,
+			EndsPlayed:    scoreboard.EndsPlayed,
+			PointsFor:     scoreboard.FriendTotal,
+			PointsAgainst: scoreboard.EnemyTotal,
+		})
+	}
+
+	result := make([]HeadToHead, 0, len(keys))
+	for _, key := range keys {
+		history := histories[key]
+		var games, ends, pointsFor, pointsAgainst, endPointsFor, endPointsAgainst int
+		for _, match := range history.Matches {
+			ends += match.EndsPlayed
+			endPointsFor += match.PointsFor
+			endPointsAgainst += match.PointsAgainst
+			if match.Status.IsFinished() {
+				games++
+				pointsFor += match.PointsFor
+				pointsAgainst += match.PointsAgainst
+			}
+		}
+		if games > 0 {
+			history.AveragePointsFor = float64(pointsFor) / float64(games)
+			history.AveragePointsAgainst = float64(pointsAgainst) / float64(games)
+		}
+		if ends > 0 {
+			history.AveragePointsForPerEnd = float64(endPointsFor) / float64(ends)
+			history.AveragePointsAgainstPerEnd = float64(endPointsAgainst) / float64(ends)
+		}
+		history.Hammer = history.Hammer.withRates()
+		result = append(result, *history)
+	}
+	sort.SliceStable(result, func(i, j int) bool { return len(result[i].Matches) > len(result[j].Matches) })
+
+	return result
+}
+
+// HeatmapQuery selects the shots of a heatmap and the grid they are binned into.
+// Zero values mean no restriction; Shooter matches the recorded shooter or, when the record has a lineup,
+// the user ID or guest name of the player behind the shot.
+type HeatmapQuery struct {
+	Grid     geometry.Grid
+	Shooter  string
+	ShotType entity.ShotType
+	End      int
+	Hammer   *bool
+}
+
+// HeatmapCell is where the stones of a number of shots came to rest, with the average success rate of those shots.
+type HeatmapCell struct {
+	geometry.Cell
+	Bounds geometry.CellBounds
+	SuccessRate
+}
+
+// Heatmap bins the position each of our stones came to rest at right after it was delivered.
+// Shots whose stone left play or rested outside the grid are counted apart.
+type Heatmap struct {
+	Grid      geometry.Grid
+	Records   int
+	Shots     int
+	OutOfPlay int
+	Cells     []HeatmapCell
+}
+
+func (q HeatmapQuery) matches(record *entity.Record, end, shotNo int, shot entity.Shot, hasHammer bool) bool {
+	if q.ShotType != "" && shot.Type != q.ShotType {
+		return false
+	}
+	if q.End != 0 && end != q.End {
+		return false
+	}
+	if q.Hammer != nil && hasHammer != *q.Hammer {
+		return false
+	}
+	if q.Shooter == "" {
+		return true
+	}
+
+	shooter := strings.TrimSpace(shot.Shooter)
+	if strings.EqualFold(shooter, strings.TrimSpace(q.Shooter)) {
+		return true
+	}
+	player, ok := record.GetLineup().Resolve(end, shotNo, shooter)
+	return ok && (player.UserId == q.Shooter || (player.GuestName != "" && strings.EqualFold(player.GuestName, strings.TrimSpace(q.Shooter))))
+}
+
+func (u *statsUsecase) GetHeatmap(userId, teamId string, filter StatsFilter, query HeatmapQuery) (*Heatmap, error) {
+	if err := query.Grid.Validate(); err != nil {
+		return nil, err
+	}
+	records, err := u.findRecords(userId, teamId, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	heatmap := &Heatmap{Grid: query.Grid, Records: len(records)}
+	cells := map[geometry.Cell]*rateAccumulator{}
+	for _, record := range records {
+		scoreboard := record.Scoreboard()
+		for i, end := range record.GetEndsData() {
+			hasHammer := scoreboard.Ends[i].FriendHammer
+			for j, diff := range geometry.DiffEnd(end) {
+				shot := end.Shots[j]
+				if !query.matches(&record, i+1, j+1, shot, hasHammer) {
+					continue
+				}
+				heatmap.Shots++
+
+				var cell geometry.Cell
+				located := false
+				for _, m := range diff.Movements {
+					if m.Side == geometry.SideFriend && m.Movement == geometry.MovementPlaced {
+						cell, located = query.Grid.Locate(*m.To)
+						break
+					}
+				}
+				if !located {
+					heatmap.OutOfPlay++
+					continue
+				}
+				if cells[cell] == nil {
+					cells[cell] = &rateAccumulator{}
+				}
+				cells[cell].add(shot.SuccessRate)
+			}
+		}
+	}
+
+	heatmap.Cells = make([]HeatmapCell, 0, len(cells))
+	for cell, acc := range cells {
+		heatmap.Cells = append(heatmap.Cells, HeatmapCell{Cell: cell, Bounds: query.Grid.Bounds(cell), SuccessRate: acc.rate()})
+	}
+	sort.Slice(heatmap.Cells, func(i, j int) bool {
+		if heatmap.Cells[i].Row != heatmap.Cells[j].Row {
+			return heatmap.Cells[i].Row < heatmap.Cells[j].Row
+		}
+		return heatmap.Cells[i].Column < heatmap.Cells[j].Column
+	})
+
+	return heatmap, nil
+}
+
+// The prior of the win-probability model is a logistic curve of the lead, counting the hammer as part of it.
+// Its spread widens with the square root of the ends remaining, as the final margin is a sum of end scores.
+const (
+	winProbabilitySmoothing   = 4.0 // weight of the prior, in games
+	winProbabilityHammerValue = 0.8 // points the hammer is worth
+	winProbabilitySpread      = 1.2 // spread of the margin over one end, in points
+)
+
+// WinProbabilityState is the state of a game before an end, from the point of view of one team.
+// An extra end counts as one end remaining, like the last regulation end.
+type WinProbabilityState struct {
+	EndsRemaining   int
+	ScoreDifference int // the team's score minus the opponent's
+	Hammer          bool
+}
+
+// NewWinProbabilityState returns the state before an end, numbered from 1, of a game played under a format.
+func NewWinProbabilityState(format entity.GameFormat, end, scoreDifference int, hammer bool) WinProbabilityState {
+	return WinProbabilityState{EndsRemaining: max(format.Ends()-end+1, 1), ScoreDifference: scoreDifference, Hammer: hammer}
+}
+
+func (s WinProbabilityState) opponent() WinProbabilityState {
+	return WinProbabilityState{EndsRemaining: s.EndsRemaining, ScoreDifference: -s.ScoreDifference, Hammer: !s.Hammer}
+}
+
+func (s WinProbabilityState) prior() float64 {
+	lead := float64(s.ScoreDifference) - winProbabilityHammerValue
+	if s.Hammer {
+		lead = float64(s.ScoreDifference) + winProbabilityHammerValue
+	}
+	return 1 / (1 + math.Exp(-lead/(winProbabilitySpread*math.Sqrt(float64(s.EndsRemaining)))))
+}
+
+// WinProbability is the estimated chance of winning from a state. Games is the number of games which went
+// through the state; the fewer there are, the closer the estimate stays to the prior. A draw counts as half a win.
+type WinProbability struct {
+	Probability float64
+	Games       int
+	Wins        float64
+	Prior       float64
+}
+
+// winProbabilityTable counts the outcome of games by the states they went through.
+type winProbabilityTable map[WinProbabilityState]*WinProbability
+
+// add records the states of a finished game from the point of view of both teams.
+func (t winProbabilityTable) add(record *entity.Record) {
+	var outcome float64
+	switch {
+	case !record.GetStatus().IsFinished():
+		return
+	case record.GetResult() == entity.Win:
+		outcome = 1
+	case record.GetResult() == entity.Draw:
+		outcome = 0.5
+	case record.GetResult() != entity.Loss:
+		return
+	}
+
+	for _, end := range record.Scoreboard().Ends {
+		diff := (end.FriendTotal - end.EnemyTotal) - (end.FriendPoints - end.EnemyPoints)
+		state := NewWinProbabilityState(record.GetGameFormat(), end.End, diff, end.FriendHammer)
+		t.count(state, outcome)
+		t.count(state.opponent(), 1-outcome)
+	}
+}
+
+func (t winProbabilityTable) count(state WinProbabilityState, outcome float64) {
+	if t[state] == nil {
+		t[state] = &WinProbability{}
+	}
+	t[state].Games++
+	t[state].Wins += outcome
+}
+
+// estimate smooths the observed win rate of a state towards the prior.
+func (t winProbabilityTable) estimate(state WinProbabilityState) WinProbability {
+	estimate := WinProbability{Prior: state.prior()}
+	if observed := t[state]; observed != nil {
+		estimate.Games, estimate.Wins = observed.Games, observed.Wins
+	}
+	estimate.Probability = (estimate.Wins + winProbabilitySmoothing*estimate.Prior) / (float64(estimate.Games) + winProbabilitySmoothing)
+	return estimate
+}
+
+// WinProbabilityPoint is the estimate before an end. Swing is how much the end changed it,
+// so the ends where the game was decided stand out.
+type WinProbabilityPoint struct {
+	End          int
+	FriendTotal  int // score before the end
+	EnemyTotal   int // score before the end
+	FriendHammer bool
+	WinProbability
+	Swing float64
+}
+
+// WinProbabilityCurve follows the estimate through a record. Final is 1 for a win, 0 for a loss and 0.5 for a draw.
+type WinProbabilityCurve struct {
+	RecordId string
+	Result   entity.Result
+	Points   []WinProbabilityPoint
+	Final    float64
+}
+
+// buildWinProbabilityTable counts the games of the team and every public record, reading them in batches.
+// A record left out by its ID is not counted, so a game's own result does not leak into its curve.
+func (u *statsUsecase) buildWinProbabilityTable(teamId, excludedRecordId string) (winProbabilityTable, error) {
+	table := winProbabilityTable{}
+	err := u.recordRepo.EachWithEndsData(teamId, true, func(record entity.Record) error {
+		if record.GetId().Value() != excludedRecordId {
+			table.add(&record)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+func (u *statsUsecase) GetWinProbability(userId, teamId string, format entity.GameFormat, end, scoreDifference int, hammer bool) (*WinProbability, error) {
+	if !format.IsValid() {
+		return nil, domainerr.Invalid("format", "unknown game format %q", format)
+	}
+	if end < 1 {
+		return nil, domainerr.Invalid("end", "the end must be at least 1")
+	}
+	if err := u.policy.CanReadTeam(userId, teamId); err != nil {
+		return nil, err
+	}
+
+	table, err := u.buildWinProbabilityTable(teamId, "")
+	if err != nil {
+		return nil, err
+	}
+	estimate := table.estimate(NewWinProbabilityState(format, end, scoreDifference, hammer))
+	return &estimate, nil
+}
+
+func (u *statsUsecase) GetWinProbabilityCurve(userId, recordId string) (*WinProbabilityCurve, error) {
+	record, err := u.recordRepo.FindByRecordId(recordId)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.policy.CanReadRecord(userId, record); err != nil {
+		return nil, err
+	}
+	if !record.GetStatus().IsFinished() {
+		return nil, domainerr.Conflict("GAME_ABANDONED", "the game was abandoned")
+	}
+
+	curve := &WinProbabilityCurve{RecordId: recordId, Result: record.GetResult()}
+	switch record.GetResult() {
+	case entity.Win:
+		curve.Final = 1
+	case entity.Draw:
+		curve.Final = 0.5
+	}
+
+	table, err := u.buildWinProbabilityTable(record.GetTeamId(), recordId)
+	if err != nil {
+		return nil, err
+	}
+	for _, end := range record.Scoreboard().Ends {
+		friendTotal, enemyTotal := end.FriendTotal-end.FriendPoints, end.EnemyTotal-end.EnemyPoints
+		curve.Points = append(curve.Points, WinProbabilityPoint{
+			End:            end.End,
+			FriendTotal:    friendTotal,
+			EnemyTotal:     enemyTotal,
+			FriendHammer:   end.FriendHammer,
+			WinProbability: table.estimate(NewWinProbabilityState(record.GetGameFormat(), end.End, friendTotal-enemyTotal, end.FriendHammer)),
+		})
+	}
+	for i := range curve.Points {
+		next := curve.Final
+		if i+1 < len(curve.Points) {
+			next = curve.Points[i+1].Probability
+		}
+		curve.Points[i].Swing = next - curve.Points[i].Probability
+	}
+
+	return curve, nil
+}
+
+const (
+	DefaultSimilarPositions = 10
+	MaxSimilarPositions     = 50
+)
+
+// PositionQuery is a position to look for in past ends. Hammer restricts the search to ends played with
+// or without hammer, and public records of other teams are searched too when IncludePublic is set.
+type PositionQuery struct {
+	Stones        entity.Stones
+	Hammer        *bool
+	IncludePublic bool
+	Limit         int
+}
+
+// SimilarPosition is a stored position close to the one searched for, seen from the team of its record.
+// Shot is the number of shots that team had played in the end, 0 for the stones placed before it;
+// NextShot is the shot it played next and is nil when the end was over.
+type SimilarPosition struct {
+	RecordId      string
+	TeamId        string
+	EnemyTeamName string
+	Date          time.Time
+	End           int
+	Shot          int
+	FriendHammer  bool
+	Distance      float64
+	Stones        entity.Stones
+	NextShot      *entity.Shot
+	EndScore      int
+}
+
+func (u *statsUsecase) FindSimilarPositions(userId, teamId string, query PositionQuery) ([]SimilarPosition, error) {
+	if query.Limit == 0 {
+		query.Limit = DefaultSimilarPositions
+	}
+	if query.Limit < 0 || query.Limit > MaxSimilarPositions {
+		return nil, domainerr.Invalid("limit", "the limit must be between 1 and %d", MaxSimilarPositions)
+	}
+	if len(query.Stones.FriendStones) > entity.GameFormatEightEnds.StonesPerTeam() || len(query.Stones.EnemyStones) > entity.GameFormatEightEnds.StonesPerTeam() {
+		return nil, domainerr.Invalid("stones", "a position has at most 8 stones per team")
+	}
+	if err := u.policy.CanReadTeam(userId, teamId); err != nil {
+		return nil, err
+	}
+
+	// the closest positions so far, kept sorted by distance
+	var nearest []SimilarPosition
+	consider := func(candidate SimilarPosition) {
+		if len(nearest) == query.Limit && candidate.Distance >= nearest[len(nearest)-1].Distance {
+			return
+		}
+		i := sort.Search(len(nearest), func(i int) bool { return nearest[i].Distance > candidate.Distance })
+		nearest = append(nearest[:i], append([]SimilarPosition{candidate}, nearest[i:]...)...)
+		if len(nearest) > query.Limit {
+			nearest = nearest[:query.Limit]
+		}
+	}
+
+	err := u.recordRepo.EachWithEndsData(teamId, query.IncludePublic, func(record entity.Record) error {
+		scoreboard := record.Scoreboard()
+		for i, end := range record.GetEndsData() {
+			hasHammer := scoreboard.Ends[i].FriendHammer
+			if query.Hammer != nil && hasHammer != *query.Hammer {
+				continue
+			}
+
+			var positions []entity.Stones
+			first := 1
+			if end.PrePlaced != nil {
+				positions = append(positions, *end.PrePlaced)
+				first = 0
+			}
+			for _, shot := range end.Shots {
+				positions = append(positions, shot.Stones)
+			}
+			for j, stones := range positions {
+				position := SimilarPosition{
+					RecordId:      record.GetId().Value(),
+					TeamId:        record.GetTeamId(),
+					EnemyTeamName: record.GetEnemyTeamName(),
+					Date:          record.GetDate(),
+					End:           i + 1,
+					Shot:          first + j,
+					FriendHammer:  hasHammer,
+					Distance:      geometry.PositionDistance(query.Stones, stones),
+					Stones:        stones,
+					EndScore:      end.Score,
+				}
+				if position.Shot < len(end.Shots) {
+					next := end.Shots[position.Shot]
+					position.NextShot = &next
+				}
+				consider(position)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nearest, nil
+}
@@ -0,0 +1,469 @@
+package usecase_test
+
+import (
+	"CurlARC/internal/domain/domainerr"
+	"CurlARC/internal/domain/entity"
+	"CurlARC/internal/domain/geometry"
+	"CurlARC/internal/usecase"
+	"CurlARC/mock"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetShooterStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockUserTeamRepo := mock.NewMockUserTeamRepository(ctrl)
+
+	statsUsecase := usecase.NewStatsUsecase(mockRecordRepo, mockUserTeamRepo)
+
+	userId := "user-123"
+	teamId := "team-123"
+
+	// we have hammer in the first end (isFirst = false) and lose it after scoring
+	endsData := []entity.DataPerEnd{
+		{
+			Score: 1,
+			Shots: []entity.Shot{
+				{Type: entity.ShotDraw, SuccessRate: 0.8, Shooter: "Lead"},
+				{Type: entity.ShotGuard, SuccessRate: 0.6, Shooter: "Lead"},
+				{Type: entity.ShotDraw, SuccessRate: 1.0, Shooter: "Skip"},
+			},
+		},
+		{
+			Score: -1,
+			Shots: []entity.Shot{
+				{Type: entity.ShotDraw, SuccessRate: 0.4, Shooter: "Lead"},
+			},
+		},
+	}
+	record := entity.NewRecordFromDB("record-1", teamId, "Team B", "Tokyo", entity.Loss, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), endsData, false, false, false)
+	other := entity.NewRecordFromDB("record-2", teamId, "Team C", "Sapporo", entity.Win, time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC), endsData, false, false, false)
+	records := []entity.Record{*record, *other}
+
+	t.Run("正常系: シューターごとの成功率が集計される", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockRecordRepo.EXPECT().FindByTeamId(teamId).Return(&records, nil)
+
+		stats, err := statsUsecase.GetShooterStats(userId, teamId, usecase.StatsFilter{Opponent: " team b "})
+		assert.NoError(t, err)
+		assert.Len(t, stats, 2)
+
+		lead := stats[0]
+		assert.Equal(t, "Lead", lead.Shooter)
+		assert.Equal(t, 3, lead.Overall.Shots)
+		assert.InDelta(t, 0.6, lead.Overall.Average, 1e-9)
+		assert.InDelta(t, 0.6, lead.ByShotType[string(entity.ShotDraw)].Average, 1e-9)
+		assert.Equal(t, 2, lead.ByEnd[1].Shots)
+		assert.InDelta(t, 0.7, lead.WithHammer.Average, 1e-9)
+		assert.InDelta(t, 0.4, lead.WithoutHammer.Average, 1e-9)
+	})
+
+	t.Run("正常系: 期間で絞り込まれる", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockRecordRepo.EXPECT().FindByTeamId(teamId).Return(&records, nil)
+
+		filter := usecase.StatsFilter{From: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}
+		stats, err := statsUsecase.GetShooterStats(userId, teamId, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, stats[0].Overall.Shots)
+	})
+
+	t.Run("正常系: ラインナップのある試合は選手単位で集計される", func(t *testing.T) {
+		lineup := entity.Lineup{
+			Entries: []entity.LineupEntry{
+				{Position: entity.PositionLead, LineupPlayer: entity.LineupPlayer{UserId: "user-lead"}},
+				{Position: entity.PositionSkip, LineupPlayer: entity.LineupPlayer{UserId: "user-skip"}},
+			},
+			Substitutions: []entity.Substitution{
+				{End: 2, Position: entity.PositionLead, LineupPlayer: entity.LineupPlayer{UserId: "user-alternate"}},
+			},
+		}
+		withLineup := []entity.Record{
+			*entity.NewRecordFromDB("record-3", teamId, "Team B", "Tokyo", entity.Loss, time.Now(), endsData, false, false, false, entity.WithLineup(lineup)),
+		}
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockRecordRepo.EXPECT().FindByTeamId(teamId).Return(&withLineup, nil)
+
+		stats, err := statsUsecase.GetShooterStats(userId, teamId, usecase.StatsFilter{})
+		assert.NoError(t, err)
+		assert.Len(t, stats, 3)
+
+		assert.Equal(t, "user-alternate", stats[0].Shooter)
+		assert.Equal(t, "user-alternate", stats[0].Player.UserId)
+		assert.Equal(t, 1, stats[0].Overall.Shots)
+		assert.Equal(t, "user-lead", stats[1].Shooter)
+		assert.Equal(t, 2, stats[1].Overall.Shots)
+	})
+
+	t.Run("異常系: ユーザーがチームに所属していない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleNone, nil)
+
+		stats, err := statsUsecase.GetShooterStats(userId, teamId, usecase.StatsFilter{})
+		assert.Error(t, err)
+		assert.Nil(t, stats)
+		assert.Equal(t, "user is not a member of the team", err.Error())
+	})
+
+	t.Run("異常系: レコードの取得に失敗する", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockRecordRepo.EXPECT().FindByTeamId(teamId).Return(nil, errors.New("failed to get records"))
+
+		stats, err := statsUsecase.GetShooterStats(userId, teamId, usecase.StatsFilter{})
+		assert.Error(t, err)
+		assert.Nil(t, stats)
+	})
+}
+
+func TestGetHammerStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockUserTeamRepo := mock.NewMockUserTeamRepository(ctrl)
+
+	statsUsecase := usecase.NewStatsUsecase(mockRecordRepo, mockUserTeamRepo)
+
+	userId := "user-123"
+	teamId := "team-123"
+
+	endsData := []entity.DataPerEnd{{Score: 2}, {Score: -1}, {Score: 0}, {Score: 1}, {Score: -2}}
+	record := entity.NewRecordFromDB("record-1", teamId, "Team B", "Tokyo", entity.Draw, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), endsData, false, false, false)
+	records := []entity.Record{*record}
+
+	t.Run("正常系: ハンマー効率が集計される", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockRecordRepo.EXPECT().FindByTeamId(teamId).Return(&records, nil)
+
+		stats, err := statsUsecase.GetHammerStats(userId, teamId, usecase.StatsFilter{})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, stats.Records)
+
+		withHammer := stats.Overall.WithHammer
+		assert.Equal(t, 3, withHammer.Ends)
+		assert.Equal(t, 1, withHammer.TwoOrMore)
+		assert.Equal(t, 1, withHammer.ForcedToOne)
+		assert.Equal(t, 1, withHammer.Blanks)
+		assert.InDelta(t, 1.0/3, withHammer.ConversionRate, 1e-9)
+
+		withoutHammer := stats.Overall.WithoutHammer
+		assert.Equal(t, 2, withoutHammer.Ends)
+		assert.Equal(t, 0, withoutHammer.Steals)
+		assert.Equal(t, 1, withoutHammer.ForcedOpponentToOne)
+		assert.Equal(t, 1, withoutHammer.AllowedTwoOrMore)
+
+		assert.Equal(t, 1, stats.BySituation[usecase.SituationTied].WithHammer.Ends)
+		assert.Equal(t, 2, stats.BySituation[usecase.SituationUp].WithHammer.Ends)
+		assert.Equal(t, 2, stats.BySituation[usecase.SituationUp].WithoutHammer.Ends)
+		assert.Equal(t, 3, stats.ByPhase[usecase.PhaseEarly].WithHammer.Ends+stats.ByPhase[usecase.PhaseEarly].WithoutHammer.Ends)
+		assert.Equal(t, 2, stats.ByPhase[usecase.PhaseMiddle].WithHammer.Ends+stats.ByPhase[usecase.PhaseMiddle].WithoutHammer.Ends)
+	})
+
+	t.Run("異常系: ユーザーがチームに所属していない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleNone, nil)
+
+		stats, err := statsUsecase.GetHammerStats(userId, teamId, usecase.StatsFilter{})
+		assert.Error(t, err)
+		assert.Nil(t, stats)
+	})
+}
+
+func TestGetHeadToHead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockUserTeamRepo := mock.NewMockUserTeamRepository(ctrl)
+
+	statsUsecase := usecase.NewStatsUsecase(mockRecordRepo, mockUserTeamRepo)
+
+	userId := "user-123"
+	teamId := "team-123"
+
+	won := []entity.DataPerEnd{{Score: 2}, {Score: -1}, {Score: 1}}
+	lost := []entity.DataPerEnd{{Score: -3}, {Score: 1}}
+	records := []entity.Record{
+		*entity.NewRecordFromDB("record-1", teamId, "Team B", "Tokyo", entity.Win, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), won, false, false, false),
+		*entity.NewRecordFromDB("record-2", teamId, " team  b", "Tokyo", entity.Loss, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), lost, false, true, false),
+		*entity.NewRecordFromDB("record-3", teamId, "Team C", "Sapporo", entity.Win, time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC), won, false, false, false),
+		*entity.NewRecordFromDB("record-4", teamId, "Team B", "Tokyo", entity.Draw, time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC), []entity.DataPerEnd{{Score: 2}}, false, false, false, entity.WithStatus(entity.GameAbandoned)),
+	}
+
+	t.Run("正常系: 対戦相手ごとに集計される", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockRecordRepo.EXPECT().FindByTeamId(teamId).Return(&records, nil)
+
+		histories, err := statsUsecase.GetHeadToHead(userId, teamId, usecase.StatsFilter{})
+		assert.NoError(t, err)
+		assert.Len(t, histories, 2)
+
+		teamB := histories[0]
+		assert.Equal(t, "Team B", teamB.Opponent)
+		assert.Equal(t, 1, teamB.Wins)
+		assert.Equal(t, 1, teamB.Losses)
+		assert.Equal(t, 0, teamB.Draws)
+		assert.Equal(t, 1, teamB.Abandoned)
+		assert.InDelta(t, 2.0, teamB.AveragePointsFor, 1e-9)
+		assert.InDelta(t, 2.0, teamB.AveragePointsAgainst, 1e-9)
+		assert.InDelta(t, 1.0, teamB.AveragePointsForPerEnd, 1e-9)
+		assert.InDelta(t, 4.0/6, teamB.AveragePointsAgainstPerEnd, 1e-9)
+		assert.Equal(t, "record-4", teamB.Matches[0].RecordId)
+		assert.Equal(t, entity.GameAbandoned, teamB.Matches[0].Status)
+		assert.Equal(t, 6, teamB.Hammer.WithHammer.Ends+teamB.Hammer.WithoutHammer.Ends)
+
+		assert.Equal(t, "Team C", histories[1].Opponent)
+	})
+
+	t.Run("異常系: ユーザーがチームに所属していない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleNone, nil)
+
+		histories, err := statsUsecase.GetHeadToHead(userId, teamId, usecase.StatsFilter{})
+		assert.Error(t, err)
+		assert.Nil(t, histories)
+	})
+}
+
+func TestGetHeatmap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockUserTeamRepo := mock.NewMockUserTeamRepository(ctrl)
+
+	statsUsecase := usecase.NewStatsUsecase(mockRecordRepo, mockUserTeamRepo)
+
+	userId := "user-123"
+	teamId := "team-123"
+
+	// the first draw rests near the button, the takeout goes through and the guard stops in front of the house
+	button := entity.Coordinate{Index: 1, R: 0.1, Theta: math.Pi/2 + 0.1}
+	guard := entity.Coordinate{Index: 3, R: 3.0, Theta: math.Pi/2 + 0.1}
+	endsData := []entity.DataPerEnd{
+		{
+			Score: 1,
+			Shots: []entity.Shot{
+				{Type: entity.ShotDraw, SuccessRate: 0.8, Shooter: "Lead", Stones: entity.Stones{FriendStones: []entity.Coordinate{button}}},
+				{Type: entity.ShotTakeout, SuccessRate: 0.5, Shooter: "Lead", Stones: entity.Stones{FriendStones: []entity.Coordinate{button}}},
+				{Type: entity.ShotGuard, SuccessRate: 1.0, Shooter: "Skip", Stones: entity.Stones{FriendStones: []entity.Coordinate{button, guard}}},
+			},
+		},
+	}
+	record := entity.NewRecordFromDB("record-1", teamId, "Team B", "Tokyo", entity.Win, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), endsData, false, false, false)
+	other := entity.NewRecordFromDB("record-2", teamId, "Team C", "Sapporo", entity.Win, time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC), endsData, false, false, false)
+	records := []entity.Record{*record, *other}
+
+	t.Run("正常系: 極座標のグリッドに集計される", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockRecordRepo.EXPECT().FindByTeamId(teamId).Return(&records, nil)
+
+		heatmap, err := statsUsecase.GetHeatmap(userId, teamId, usecase.StatsFilter{}, usecase.HeatmapQuery{Grid: geometry.DefaultPolarGrid})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, heatmap.Records)
+		assert.Equal(t, 6, heatmap.Shots)
+		assert.Equal(t, 2, heatmap.OutOfPlay)
+		assert.Len(t, heatmap.Cells, 2)
+
+		assert.Equal(t, geometry.Cell{Row: 0, Column: 2}, heatmap.Cells[0].Cell)
+		assert.Equal(t, 2, heatmap.Cells[0].Shots)
+		assert.InDelta(t, 0.8, heatmap.Cells[0].Average, 1e-9)
+		assert.Equal(t, geometry.Cell{Row: 9, Column: 2}, heatmap.Cells[1].Cell)
+		assert.InDelta(t, 0.3048*9, heatmap.Cells[1].Bounds.MinR, 1e-9)
+	})
+
+	t.Run("正常系: シューターと対戦相手で絞り込まれる", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockRecordRepo.EXPECT().FindByTeamId(teamId).Return(&records, nil)
+
+		query := usecase.HeatmapQuery{Grid: geometry.DefaultCartesianGrid, Shooter: "lead"}
+		heatmap, err := statsUsecase.GetHeatmap(userId, teamId, usecase.StatsFilter{Opponent: "Team B"}, query)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, heatmap.Shots)
+		assert.Equal(t, 1, heatmap.OutOfPlay)
+		assert.Len(t, heatmap.Cells, 1)
+		// the stone is 1.93m from the back line, just left of the centre line
+		assert.Equal(t, geometry.Cell{Row: 3, Column: 4}, heatmap.Cells[0].Cell)
+	})
+
+	t.Run("異常系: グリッドが不正", func(t *testing.T) {
+		heatmap, err := statsUsecase.GetHeatmap(userId, teamId, usecase.StatsFilter{}, usecase.HeatmapQuery{Grid: geometry.Grid{Kind: geometry.GridPolar, Sheet: geometry.DefaultSheet}})
+		assert.Error(t, err)
+		assert.Nil(t, heatmap)
+	})
+}
+
+func TestGetWinProbability(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockUserTeamRepo := mock.NewMockUserTeamRepository(ctrl)
+
+	statsUsecase := usecase.NewStatsUsecase(mockRecordRepo, mockUserTeamRepo)
+
+	userId := "user-123"
+	teamId := "team-123"
+
+	// both games start tied with hammer; we win the first and another team loses the second
+	endsData := []entity.DataPerEnd{{Score: 1}, {Score: -1}, {Score: 2}}
+	won := entity.NewRecordFromDB("record-1", teamId, "Team B", "Tokyo", entity.Win, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), endsData, false, false, true)
+	lost := entity.NewRecordFromDB("record-2", "team-456", "Team C", "Sapporo", entity.Loss, time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC), endsData, false, false, true)
+	each := func(teamId string, includePublic bool, fn func(entity.Record) error) error {
+		for _, record := range []*entity.Record{won, lost} {
+			if err := fn(*record); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	t.Run("正常系: 自チームと公開レコードから勝率が推定される", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockRecordRepo.EXPECT().EachWithEndsData(teamId, true, gomock.Any()).DoAndReturn(each)
+
+		estimate, err := statsUsecase.GetWinProbability(userId, teamId, entity.GameFormatEightEnds, 1, 0, true)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, estimate.Games)
+		assert.InDelta(t, 1.0, estimate.Wins, 1e-9)
+		assert.Greater(t, estimate.Prior, 0.5)
+		assert.InDelta(t, (1+4*estimate.Prior)/6, estimate.Probability, 1e-9)
+	})
+
+	t.Run("正常系: 記録のない状態は事前分布になり、両チームの勝率の和は1になる", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil).Times(2)
+		mockRecordRepo.EXPECT().EachWithEndsData(teamId, true, gomock.Any()).DoAndReturn(each).Times(2)
+
+		ours, err := statsUsecase.GetWinProbability(userId, teamId, entity.GameFormatTenEnds, 9, 3, false)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, ours.Games)
+		assert.InDelta(t, ours.Prior, ours.Probability, 1e-9)
+
+		theirs, err := statsUsecase.GetWinProbability(userId, teamId, entity.GameFormatTenEnds, 9, -3, true)
+		assert.NoError(t, err)
+		assert.InDelta(t, 1.0, ours.Probability+theirs.Probability, 1e-9)
+	})
+
+	t.Run("異常系: ゲーム形式が不正", func(t *testing.T) {
+		estimate, err := statsUsecase.GetWinProbability(userId, teamId, entity.GameFormat("CURLING_SIX"), 1, 0, true)
+		assert.Error(t, err)
+		assert.Nil(t, estimate)
+	})
+
+	t.Run("正常系: 試合の勝率推移は自身を除いて推定される", func(t *testing.T) {
+		// the record is public, so the curve is open to anyone
+		mockRecordRepo.EXPECT().FindByRecordId("record-1").Return(won, nil)
+		mockRecordRepo.EXPECT().EachWithEndsData(teamId, true, gomock.Any()).DoAndReturn(each)
+
+		curve, err := statsUsecase.GetWinProbabilityCurve("user-456", "record-1")
+		assert.NoError(t, err)
+		assert.Equal(t, 1.0, curve.Final)
+		assert.Len(t, curve.Points, 3)
+
+		assert.Equal(t, 1, curve.Points[0].Games)
+		assert.InDelta(t, 0.0, curve.Points[0].Wins, 1e-9)
+		assert.Equal(t, 1, curve.Points[1].FriendTotal)
+		assert.False(t, curve.Points[1].FriendHammer)
+		assert.InDelta(t, curve.Points[1].Probability-curve.Points[0].Probability, curve.Points[0].Swing, 1e-9)
+		assert.InDelta(t, 1-curve.Points[2].Probability, curve.Points[2].Swing, 1e-9)
+	})
+
+	t.Run("異常系: 中断された試合の勝率推移は求められない", func(t *testing.T) {
+		abandoned := entity.NewRecordFromDB("record-3", teamId, "Team B", "Tokyo", entity.Draw, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), endsData, false, false, false,
+			entity.WithStatus(entity.GameAbandoned))
+		mockRecordRepo.EXPECT().FindByRecordId("record-3").Return(abandoned, nil)
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+
+		curve, err := statsUsecase.GetWinProbabilityCurve(userId, "record-3")
+		assert.Error(t, err)
+		assert.Nil(t, curve)
+	})
+
+	t.Run("異常系: 非公開の試合の勝率推移はチーム外からは見つからない", func(t *testing.T) {
+		private := entity.NewRecordFromDB("record-4", teamId, "Team B", "Tokyo", entity.Win, time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC), endsData, false, false, false)
+		mockRecordRepo.EXPECT().FindByRecordId("record-4").Return(private, nil)
+		mockUserTeamRepo.EXPECT().FindRole("user-456", teamId).Return(entity.RoleNone, nil)
+
+		curve, err := statsUsecase.GetWinProbabilityCurve("user-456", "record-4")
+		assert.ErrorIs(t, err, domainerr.ErrRecordNotFound)
+		assert.Nil(t, curve)
+	})
+}
+
+func TestFindSimilarPositions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecordRepo := mock.NewMockRecordRepository(ctrl)
+	mockUserTeamRepo := mock.NewMockUserTeamRepository(ctrl)
+
+	statsUsecase := usecase.NewStatsUsecase(mockRecordRepo, mockUserTeamRepo)
+
+	userId := "user-123"
+	teamId := "team-123"
+
+	draw := entity.Coordinate{Index: 1, R: 0.3, Theta: math.Pi / 2}
+	guard := entity.Coordinate{Index: 1, R: 3.0, Theta: math.Pi / 2}
+	endsData := []entity.DataPerEnd{
+		{
+			Score: 2,
+			Shots: []entity.Shot{
+				{Type: entity.ShotDraw, SuccessRate: 1.0, Stones: entity.Stones{FriendStones: []entity.Coordinate{draw}}},
+				{Type: entity.ShotGuard, SuccessRate: 0.8, Stones: entity.Stones{FriendStones: []entity.Coordinate{draw}, EnemyStones: []entity.Coordinate{guard}}},
+			},
+		},
+	}
+	record := entity.NewRecordFromDB("record-1", teamId, "Team B", "Tokyo", entity.Win, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), endsData, false, false, false)
+	each := func(teamId string, includePublic bool, fn func(entity.Record) error) error {
+		return fn(*record)
+	}
+
+	t.Run("正常系: 距離の近い順に局面が返される", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil)
+		mockRecordRepo.EXPECT().EachWithEndsData(teamId, false, gomock.Any()).DoAndReturn(each)
+
+		// the enemy guard is a few centimetres off and the indices differ
+		query := entity.Stones{
+			FriendStones: []entity.Coordinate{{Index: 4, R: 0.3, Theta: math.Pi / 2}},
+			EnemyStones:  []entity.Coordinate{{Index: 2, R: 3.05, Theta: math.Pi / 2}},
+		}
+		positions, err := statsUsecase.FindSimilarPositions(userId, teamId, usecase.PositionQuery{Stones: query})
+		assert.NoError(t, err)
+		assert.Len(t, positions, 2)
+
+		assert.Equal(t, 2, positions[0].Shot)
+		assert.InDelta(t, 0.05, positions[0].Distance, 1e-9)
+		assert.Nil(t, positions[0].NextShot)
+		assert.Equal(t, 2, positions[0].EndScore)
+
+		assert.Equal(t, 1, positions[1].Shot)
+		assert.InDelta(t, geometry.UnmatchedStonePenalty, positions[1].Distance, 1e-9)
+		assert.Equal(t, entity.ShotGuard, positions[1].NextShot.Type)
+	})
+
+	t.Run("正常系: 件数とハンマーで絞り込まれる", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole(userId, teamId).Return(entity.RoleMember, nil).Times(2)
+		mockRecordRepo.EXPECT().EachWithEndsData(teamId, true, gomock.Any()).DoAndReturn(each).Times(2)
+
+		positions, err := statsUsecase.FindSimilarPositions(userId, teamId, usecase.PositionQuery{IncludePublic: true, Limit: 1})
+		assert.NoError(t, err)
+		assert.Len(t, positions, 1)
+		assert.Equal(t, 1, positions[0].Shot)
+
+		withoutHammer := false
+		positions, err = statsUsecase.FindSimilarPositions(userId, teamId, usecase.PositionQuery{IncludePublic: true, Hammer: &withoutHammer})
+		assert.NoError(t, err)
+		assert.Empty(t, positions)
+	})
+
+	t.Run("異常系: 件数の上限を超える", func(t *testing.T) {
+		positions, err := statsUsecase.FindSimilarPositions(userId, teamId, usecase.PositionQuery{Limit: usecase.MaxSimilarPositions + 1})
+		assert.Error(t, err)
+		assert.Nil(t, positions)
+	})
+}
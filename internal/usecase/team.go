@@ -1,39 +1,58 @@
 package usecase
 
 import (
+	"CurlARC/internal/domain/domainerr"
 	"CurlARC/internal/domain/entity"
 	"CurlARC/internal/domain/repository"
 	"errors"
 	"fmt"
+	"time"
 )
 
 type TeamUsecase interface {
 	// CRUD
 	CreateTeam(name, userId string) (*entity.Team, error)
 	GetAllTeams() ([]*entity.Team, error)
-	UpdateTeam(id, name string) (*entity.Team, error)
-	DeleteTeam(id string) error
+	UpdateTeam(id, userId, name string) (*entity.Team, error)
+	DeleteTeam(id, userId string) error
 
 	// User関連
 	InviteUsers(teamId, userId string, targetUserEmails []string) error
 	AcceptInvitation(teamId, userId string) error
-	RemoveMember(teamId, userId string) error
-	GetDetailsByTeamId(teamId string) (*entity.Team, error)
-	GetMembersByTeamId(teamId string) ([]*entity.User, error)
-	GetInvitedUsersByTeamId(teamId string) ([]*entity.User, error)
+	DeclineInvitation(teamId, userId string) error
+	RevokeInvitation(teamId, userId, inviteeId string) error
+	RemoveMember(teamId, userId, memberId string) error
+	GetDetailsByTeamId(teamId, userId string) (*entity.Team, error)
+	GetMembersByTeamId(teamId, userId string) ([]*entity.User, error)
+	GetInvitedUsersByTeamId(teamId, userId string) ([]Invitation, error)
+
+	// Role関連
+	GetRolesByTeamId(teamId, userId string) ([]*entity.UserTeam, error)
+	SetRole(teamId, userId, memberId string, role entity.TeamRole) error
+	TransferOwnership(teamId, userId, newOwnerId string) error
 
 	GetTeamsByUserId(userId string) ([]*entity.Team, error)
-	GetInvitedTeams(userId string) ([]*entity.Team, error)
+	GetInvitedTeams(userId string) ([]Invitation, error)
+}
+
+// Invitation is a pending invitation of a user to a team.
+type Invitation struct {
+	Team      *entity.Team
+	User      *entity.User // the invited user
+	InvitedBy *entity.User // nil if the inviter is unknown
+	InvitedAt time.Time
+	ExpiresAt time.Time
 }
 
 type teamUsecase struct {
 	teamRepo     repository.TeamRepository
 	userRepo     repository.UserRepository
 	userTeamRepo repository.UserTeamRepository
+	policy       Policy
 }
 
 func NewTeamUsecase(teamRepo repository.TeamRepository, userRepo repository.UserRepository, userTeamRepo repository.UserTeamRepository) TeamUsecase {
-	return &teamUsecase{teamRepo: teamRepo, userRepo: userRepo, userTeamRepo: userTeamRepo}
+	return &teamUsecase{teamRepo: teamRepo, userRepo: userRepo, userTeamRepo: userTeamRepo, policy: NewPolicy(userTeamRepo)}
 }
 
 func (usecase *teamUsecase) CreateTeam(name, userId string) (*entity.Team, error) {
@@ -46,6 +65,7 @@ func (usecase *teamUsecase) CreateTeam(name, userId string) (*entity.Team, error
 	// Create entities
 	team := entity.NewTeam(name)
 	userTeam := entity.NewUserTeam(*entity.NewUserId(userId), *team.GetId(), entity.Member)
+	userTeam.SetRole(entity.RoleOwner)
 
 	// 永続化
 	savedTeam, err := usecase.teamRepo.Save(team)
@@ -67,7 +87,11 @@ func (usecase *teamUsecase) GetAllTeams() ([]*entity.Team, error) {
 	return teams, nil
 }
 
-func (usecase *teamUsecase) UpdateTeam(id, name string) (*entity.Team, error) {
+func (usecase *teamUsecase) UpdateTeam(id, userId, name string) (*entity.Team, error) {
+	if err := usecase.policy.CanManageTeam(userId, id); err != nil {
+		return nil, err
+	}
+
 	team, err := usecase.teamRepo.FindById(id)
 	if err != nil {
 		return nil, err
@@ -82,7 +106,11 @@ func (usecase *teamUsecase) UpdateTeam(id, name string) (*entity.Team, error) {
 	return updatedTeam, nil
 }
 
-func (usecase *teamUsecase) DeleteTeam(id string) error {
+func (usecase *teamUsecase) DeleteTeam(id, userId string) error {
+	if err := usecase.policy.CanOwnTeam(userId, id); err != nil {
+		return err
+	}
+
 	// Check existence of team
 	_, err := usecase.teamRepo.FindById(id)
 	if err != nil {
@@ -107,38 +135,48 @@ func (usecase *teamUsecase) InviteUsers(teamId, userId string, targetUserEmails
 		return err
 	}
 
-	// Check if the inviter is a member of the team
-	isMember, err := usecase.userTeamRepo.IsMember(userId, teamId)
-	if err != nil {
-		return err
-	}
-	if !isMember {
-		return errors.New("inviter is not a member of the team")
+	// Check if the inviter may manage the members of the team
+	if err := usecase.policy.CanManageTeam(userId, teamId); err != nil {
+		return deniedAs(err, "inviter")
 	}
 
+	// targets which cannot be invited are reported per address, unless the database failed
+	var invalidTargets []domainerr.FieldError
 	var inviteErrors []error
+	now := time.Now()
+
+	for i, targetEmail := range targetUserEmails {
+		field := fmt.Sprintf("target_user_emails[%d]", i)
 
-	for _, targetEmail := range targetUserEmails {
 		// Check existence of target user
 		targetUser, err := usecase.userRepo.FindByEmail(targetEmail)
+		if errors.Is(err, domainerr.ErrUserNotFound) {
+			invalidTargets = append(invalidTargets, domainerr.FieldError{Field: field, Message: fmt.Sprintf("target user %s not found", targetEmail)})
+			continue
+		}
 		if err != nil {
 			inviteErrors = append(inviteErrors, fmt.Errorf("target user %s not found: %v", targetEmail, err))
 			continue
 		}
 
 		// Check if the target user is already a member of the team
-		isMember, err = usecase.userTeamRepo.IsMember(targetUser.GetId().Value(), teamId)
+		isMember, err := usecase.userTeamRepo.IsMember(targetUser.GetId().Value(), teamId)
 		if err != nil {
 			inviteErrors = append(inviteErrors, fmt.Errorf("error checking membership for user %s: %v", targetEmail, err))
 			continue
 		}
 		if isMember {
-			inviteErrors = append(inviteErrors, fmt.Errorf("target user %s is already a member of the team", targetEmail))
+			invalidTargets = append(invalidTargets, domainerr.FieldError{Field: field, Message: fmt.Sprintf("target user %s is already a member of the team", targetEmail)})
 			continue
 		}
 
-		userTeam := entity.NewUserTeam(*entity.NewUserId(targetUser.GetId().Value()), *entity.NewTeamId(teamId), entity.Invited)
-		_, err = usecase.userTeamRepo.Save(userTeam)
+		// A user invited before, whether the invitation expired or not, is sent a new one
+		invitation := entity.NewInvitation(*entity.NewUserId(targetUser.GetId().Value()), *entity.NewTeamId(teamId), userId, now)
+		_, err = usecase.userTeamRepo.SaveInvitation(invitation)
+		if domainerr.IsKind(err, domainerr.KindConflict) {
+			invalidTargets = append(invalidTargets, domainerr.FieldError{Field: field, Message: fmt.Sprintf("target user %s is already a member of the team", targetEmail)})
+			continue
+		}
 		if err != nil {
 			inviteErrors = append(inviteErrors, fmt.Errorf("error inviting user %s: %v", targetEmail, err))
 			continue
@@ -151,6 +189,9 @@ func (usecase *teamUsecase) InviteUsers(teamId, userId string, targetUserEmails
 	if len(inviteErrors) > 0 {
 		return fmt.Errorf("one or more invitations failed: %v", inviteErrors)
 	}
+	if len(invalidTargets) > 0 {
+		return domainerr.Validation("one or more invitations failed", invalidTargets...)
+	}
 
 	return nil
 }
@@ -166,10 +207,17 @@ func (usecase *teamUsecase) AcceptInvitation(teamId, userId string) error {
 		return err
 	}
 
-	userTeam := entity.NewUserTeam(*entity.NewUserId(userId), *entity.NewTeamId(teamId), entity.Member)
+	invitation, err := usecase.findInvitation(userId, teamId)
+	if err != nil {
+		return err
+	}
+	if invitation.IsExpired(time.Now()) {
+		return domainerr.ErrInvitationExpired
+	}
 
 	// Update state of user-team
-	_, err = usecase.userTeamRepo.UpdateState(userTeam)
+	invitation.SetState(entity.Member)
+	_, err = usecase.userTeamRepo.UpdateState(invitation)
 	if err != nil {
 		return err
 	}
@@ -177,19 +225,79 @@ func (usecase *teamUsecase) AcceptInvitation(teamId, userId string) error {
 	return nil
 }
 
-func (usecase *teamUsecase) RemoveMember(teamId, userId string) error {
+// DeclineInvitation turns down an invitation, expired or not. The team may invite the user again later.
+func (usecase *teamUsecase) DeclineInvitation(teamId, userId string) error {
+	invitation, err := usecase.findInvitation(userId, teamId)
+	if err != nil {
+		return err
+	}
+
+	invitation.Decline(time.Now())
+	_, err = usecase.userTeamRepo.UpdateState(invitation)
+	return err
+}
+
+// RevokeInvitation withdraws the invitation of a user to the team. Only admins may do it.
+func (usecase *teamUsecase) RevokeInvitation(teamId, userId, inviteeId string) error {
+	if err := usecase.policy.CanManageTeam(userId, teamId); err != nil {
+		return err
+	}
+
+	invitation, err := usecase.findInvitation(inviteeId, teamId)
+	if err != nil {
+		return err
+	}
+
+	invitation.Revoke(time.Now())
+	_, err = usecase.userTeamRepo.UpdateState(invitation)
+	return err
+}
+
+// findInvitation returns the invitation of the user to the team. A membership is not an invitation.
+func (usecase *teamUsecase) findInvitation(userId, teamId string) (*entity.UserTeam, error) {
+	userTeam, err := usecase.userTeamRepo.Find(userId, teamId)
+	if errors.Is(err, domainerr.ErrUserTeamNotFound) {
+		return nil, domainerr.ErrInvitationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if userTeam.GetState() != entity.Invited {
+		return nil, domainerr.ErrInvitationNotFound
+	}
+	return userTeam, nil
+}
+
+// RemoveMember removes a user from the team. Admins may remove anyone but the owner, and every user but the owner
+// may leave a team. The owner has to transfer the team first.
+func (usecase *teamUsecase) RemoveMember(teamId, userId, memberId string) error {
+	if userId != memberId {
+		if err := usecase.policy.CanManageTeam(userId, teamId); err != nil {
+			return err
+		}
+	}
+
 	// Check existence of team and user
 	_, err := usecase.teamRepo.FindById(teamId)
 	if err != nil {
 		return err
 	}
-	_, err = usecase.userRepo.FindById(userId)
+	_, err = usecase.userRepo.FindById(memberId)
 	if err != nil {
 		return err
 	}
 
+	// The team must keep its owner
+	role, err := usecase.userTeamRepo.FindRole(memberId, teamId)
+	if err != nil {
+		return err
+	}
+	if role == entity.RoleOwner {
+		return domainerr.ErrOwnerMustTransfer
+	}
+
 	// Remove user from team
-	err = usecase.userTeamRepo.Delete(userId, teamId)
+	err = usecase.userTeamRepo.Delete(memberId, teamId)
 	if err != nil {
 		return err
 	}
@@ -215,33 +323,87 @@ func (usecase *teamUsecase) GetTeamsByUserId(userId string) ([]*entity.Team, err
 	return teams, nil
 }
 
-func (usecase *teamUsecase) GetInvitedTeams(userId string) ([]*entity.Team, error) {
-	teamIds, err := usecase.userTeamRepo.FindInvitedTeamsByUserId(userId)
+// GetInvitedTeams returns the invitations the user may still accept, with who sent them.
+func (usecase *teamUsecase) GetInvitedTeams(userId string) ([]Invitation, error) {
+	userTeams, err := usecase.userTeamRepo.FindInvitationsByUserId(userId)
 	if err != nil {
 		return nil, err
 	}
 
-	var teams []*entity.Team
-	for _, teamId := range teamIds {
+	return usecase.pendingInvitations(userTeams, map[string]*entity.Team{})
+}
+
+// pendingInvitations resolves the teams and users of the invitations, leaving out the expired ones.
+// teams holds the teams already found by id; the others are looked up once each.
+func (usecase *teamUsecase) pendingInvitations(userTeams []*entity.UserTeam, teams map[string]*entity.Team) ([]Invitation, error) {
+	users := map[string]*entity.User{}
+
+	findTeam := func(teamId string) (*entity.Team, error) {
+		if team, ok := teams[teamId]; ok {
+			return team, nil
+		}
 		team, err := usecase.teamRepo.FindById(teamId)
 		if err != nil {
 			return nil, err
 		}
-		teams = append(teams, team)
+		teams[teamId] = team
+		return team, nil
+	}
+	findUser := func(userId string) (*entity.User, error) {
+		if user, ok := users[userId]; ok {
+			return user, nil
+		}
+		user, err := usecase.userRepo.FindById(userId)
+		if err != nil {
+			return nil, err
+		}
+		users[userId] = user
+		return user, nil
 	}
 
-	return teams, nil
+	now := time.Now()
+	invitations := make([]Invitation, 0, len(userTeams))
+	for _, userTeam := range userTeams {
+		if userTeam.IsExpired(now) {
+			continue
+		}
+
+		team, err := findTeam(userTeam.GetTeamId().Value())
+		if err != nil {
+			return nil, err
+		}
+		user, err := findUser(userTeam.GetUserId().Value())
+		if err != nil {
+			return nil, err
+		}
+		invitation := Invitation{Team: team, User: user, InvitedAt: userTeam.GetInvitedAt(), ExpiresAt: userTeam.GetExpiresAt()}
+
+		if inviterId := userTeam.GetInvitedBy(); inviterId != "" {
+			inviter, err := findUser(inviterId)
+			if err != nil && !errors.Is(err, domainerr.ErrUserNotFound) {
+				return nil, err
+			}
+			invitation.InvitedBy = inviter
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	return invitations, nil
 }
 
-func (usecase *teamUsecase) GetMembersByTeamId(teamId string) ([]*entity.User, error) {
+func (usecase *teamUsecase) GetMembersByTeamId(teamId, userId string) ([]*entity.User, error) {
+	if err := usecase.policy.CanReadTeam(userId, teamId); err != nil {
+		return nil, err
+	}
+
 	userIds, err := usecase.userTeamRepo.FindMembersByTeamId(teamId)
 	if err != nil {
 		return nil, err
 	}
 
 	var users []*entity.User
-	for _, userId := range userIds {
-		user, err := usecase.userRepo.FindById(userId)
+	for _, memberId := range userIds {
+		user, err := usecase.userRepo.FindById(memberId)
 		if err != nil {
 			return nil, err
 		}
@@ -251,39 +413,89 @@ func (usecase *teamUsecase) GetMembersByTeamId(teamId string) ([]*entity.User, e
 	return users, nil
 }
 
-func (usecase *teamUsecase) GetInvitedUsersByTeamId(teamId string) ([]*entity.User, error) {
+// GetInvitedUsersByTeamId returns the invitations of the team which may still be accepted, with who sent them.
+func (usecase *teamUsecase) GetInvitedUsersByTeamId(teamId, userId string) ([]Invitation, error) {
+	if err := usecase.policy.CanReadTeam(userId, teamId); err != nil {
+		return nil, err
+	}
+
 	// Validate team existence
-	_, err := usecase.teamRepo.FindById(teamId)
+	team, err := usecase.teamRepo.FindById(teamId)
 	if err != nil {
 		return nil, fmt.Errorf("invalid teamId: %w", err)
 	}
 
-	userIds, err := usecase.userTeamRepo.FindInvitedUsersByTeamId(teamId)
+	userTeams, err := usecase.userTeamRepo.FindInvitationsByTeamId(teamId)
+	if err != nil {
+		return nil, err
+	}
+
+	return usecase.pendingInvitations(userTeams, map[string]*entity.Team{teamId: team})
+}
+
+func (usecase *teamUsecase) GetDetailsByTeamId(teamId, userId string) (*entity.Team, error) {
+	if err := usecase.policy.CanReadTeam(userId, teamId); err != nil {
+		return nil, err
+	}
+
+	teams, err := usecase.teamRepo.FindById(teamId)
 	if err != nil {
 		return nil, err
 	}
+	return teams, nil
+}
 
-	if len(userIds) == 0 {
-		return []*entity.User{}, nil
+func (usecase *teamUsecase) GetRolesByTeamId(teamId, userId string) ([]*entity.UserTeam, error) {
+	if err := usecase.policy.CanReadTeam(userId, teamId); err != nil {
+		return nil, err
 	}
 
-	users := make([]*entity.User, 0, len(userIds))
+	return usecase.userTeamRepo.FindMembershipsByTeamId(teamId)
+}
 
-	for _, userId := range userIds {
-		user, err := usecase.userRepo.FindById(userId)
-		if err != nil {
-			return nil, err
-		}
-		users = append(users, user)
+// SetRole gives a member of the team another role. Ownership only changes hands through TransferOwnership.
+func (usecase *teamUsecase) SetRole(teamId, userId, memberId string, role entity.TeamRole) error {
+	if err := usecase.policy.CanManageTeam(userId, teamId); err != nil {
+		return err
 	}
 
-	return users, nil
+	if !role.IsValid() || role == entity.RoleOwner {
+		return domainerr.Invalid("role", "the role must be ADMIN, MEMBER or VIEWER")
+	}
+
+	current, err := usecase.userTeamRepo.FindRole(memberId, teamId)
+	if err != nil {
+		return err
+	}
+	if current == entity.RoleNone {
+		return domainerr.ErrUserTeamNotFound.Errorf("user %s is not a member of the team", memberId)
+	}
+	if current == entity.RoleOwner {
+		return domainerr.ErrOwnerMustTransfer
+	}
+
+	userTeam := entity.NewUserTeam(*entity.NewUserId(memberId), *entity.NewTeamId(teamId), entity.Member)
+	userTeam.SetRole(role)
+	_, err = usecase.userTeamRepo.UpdateRole(userTeam)
+	return err
 }
 
-func (usecase *teamUsecase) GetDetailsByTeamId(teamId string) (*entity.Team, error) {
-	teams, err := usecase.teamRepo.FindById(teamId)
+// TransferOwnership makes another member the owner of the team. The previous owner stays on as an admin.
+func (usecase *teamUsecase) TransferOwnership(teamId, userId, newOwnerId string) error {
+	if err := usecase.policy.CanOwnTeam(userId, teamId); err != nil {
+		return err
+	}
+
+	if newOwnerId == userId {
+		return domainerr.Invalid("user_id", "the user already owns the team")
+	}
+	role, err := usecase.userTeamRepo.FindRole(newOwnerId, teamId)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return teams, nil
+	if role == entity.RoleNone {
+		return domainerr.Invalid("user_id", "the new owner must be a member of the team")
+	}
+
+	return usecase.userTeamRepo.TransferOwnership(teamId, userId, newOwnerId)
 }
@@ -3,7 +3,9 @@ package usecase_test
 import (
 	"errors"
 	"testing"
+	"time"
 
+	"CurlARC/internal/domain/domainerr"
 	"CurlARC/internal/domain/entity"
 	"CurlARC/internal/usecase"
 	"CurlARC/mock"
@@ -31,15 +33,18 @@ func TestCreateTeam(t *testing.T) {
 	userId := user.GetId().Value()
 
 	t.Run("正常系: チームが正常に作成される", func(t *testing.T) {
-		userTeam := entity.NewUserTeam(*entity.NewUserId(userId), *team.GetId(), entity.Member)
-
 		mockTeamRepo.EXPECT().Save(gomock.Any()).Return(team, nil)
 		mockUserRepo.EXPECT().FindById(userId).Return(user, nil)
-		mockUserTeamRepo.EXPECT().Save(gomock.Any()).Return(userTeam, nil)
+		var saved *entity.UserTeam
+		mockUserTeamRepo.EXPECT().Save(gomock.Any()).DoAndReturn(func(userTeam *entity.UserTeam) (*entity.UserTeam, error) {
+			saved = userTeam
+			return userTeam, nil
+		})
 
 		createdTeam, err := teamUsecase.CreateTeam("Team A", userId)
 		assert.NoError(t, err)
 		assert.Equal(t, team, createdTeam)
+		assert.Equal(t, entity.RoleOwner, saved.GetRole())
 	})
 
 	t.Run("異常系: dbへの保存に失敗する", func(t *testing.T) {
@@ -110,31 +115,47 @@ func TestUpdateTeam(t *testing.T) {
 	ToUpdateTeam := entity.NewTeam("Team A+")
 
 	t.Run("正常系: チームが正常に更新される", func(t *testing.T) {
-
+		mockUserTeamRepo.EXPECT().FindRole("user-123", "team-123").Return(entity.RoleAdmin, nil)
 		mockTeamRepo.EXPECT().FindById("team-123").Return(team, nil)
 		mockTeamRepo.EXPECT().Update(team).Return(ToUpdateTeam, nil)
 
-		updatedTeam, err := teamUsecase.UpdateTeam("team-123", "Team A+")
+		updatedTeam, err := teamUsecase.UpdateTeam("team-123", "user-123", "Team A+")
 		assert.NoError(t, err)
 		assert.Equal(t, updatedTeam, ToUpdateTeam)
 	})
 
 	t.Run("異常系: チームが見つからない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-123", "team-123").Return(entity.RoleAdmin, nil)
 		mockTeamRepo.EXPECT().FindById("team-123").Return(nil, errors.New("team not found"))
 
-		_, err := teamUsecase.UpdateTeam("team-123", "Team A")
+		_, err := teamUsecase.UpdateTeam("team-123", "user-123", "Team A")
 		assert.Error(t, err)
 		assert.Equal(t, "team not found", err.Error())
 	})
 
 	t.Run("異常系: チームの更新に失敗する", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-123", "team-123").Return(entity.RoleAdmin, nil)
 		mockTeamRepo.EXPECT().FindById("team-123").Return(team, nil)
 		mockTeamRepo.EXPECT().Update(team).Return(nil, errors.New("failed to update team"))
 
-		_, err := teamUsecase.UpdateTeam("team-123", "Team A")
+		_, err := teamUsecase.UpdateTeam("team-123", "user-123", "Team A")
 		assert.Error(t, err)
 		assert.Equal(t, "failed to update team", err.Error())
 	})
+
+	t.Run("異常系: チームのメンバーではない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-456", "team-123").Return(entity.RoleNone, nil)
+
+		_, err := teamUsecase.UpdateTeam("team-123", "user-456", "Team A")
+		assert.ErrorIs(t, err, domainerr.ErrNotTeamMember)
+	})
+
+	t.Run("異常系: 管理者でないメンバーはチームを更新できない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-456", "team-123").Return(entity.RoleMember, nil)
+
+		_, err := teamUsecase.UpdateTeam("team-123", "user-456", "Team A")
+		assert.ErrorIs(t, err, domainerr.ErrInsufficientRole)
+	})
 }
 
 func TestDeleteTeam(t *testing.T) {
@@ -151,29 +172,46 @@ func TestDeleteTeam(t *testing.T) {
 
 	t.Run("正常系: チームが正常に削除される", func(t *testing.T) {
 
+		mockUserTeamRepo.EXPECT().FindRole("user-123", "team-123").Return(entity.RoleOwner, nil)
 		mockTeamRepo.EXPECT().FindById("team-123").Return(team, nil)
 		mockTeamRepo.EXPECT().Delete("team-123").Return(nil)
 
-		err := teamUsecase.DeleteTeam("team-123")
+		err := teamUsecase.DeleteTeam("team-123", "user-123")
 		assert.NoError(t, err)
 	})
 
 	t.Run("異常系: チームが見つからない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-123", "team-123").Return(entity.RoleOwner, nil)
 		mockTeamRepo.EXPECT().FindById("team-123").Return(nil, errors.New("team not found"))
 
-		err := teamUsecase.DeleteTeam("team-123")
+		err := teamUsecase.DeleteTeam("team-123", "user-123")
 		assert.Error(t, err)
 		assert.Equal(t, "team not found", err.Error())
 	})
 
 	t.Run("異常系: チームの削除に失敗する", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-123", "team-123").Return(entity.RoleOwner, nil)
 		mockTeamRepo.EXPECT().FindById("team-123").Return(team, nil)
 		mockTeamRepo.EXPECT().Delete("team-123").Return(errors.New("failed to delete team"))
 
-		err := teamUsecase.DeleteTeam("team-123")
+		err := teamUsecase.DeleteTeam("team-123", "user-123")
 		assert.Error(t, err)
 		assert.Equal(t, "failed to delete team", err.Error())
 	})
+
+	t.Run("異常系: チームのメンバーではない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-456", "team-123").Return(entity.RoleNone, nil)
+
+		err := teamUsecase.DeleteTeam("team-123", "user-456")
+		assert.ErrorIs(t, err, domainerr.ErrNotTeamMember)
+	})
+
+	t.Run("異常系: オーナーでなければ管理者でもチームを削除できない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-456", "team-123").Return(entity.RoleAdmin, nil)
+
+		err := teamUsecase.DeleteTeam("team-123", "user-456")
+		assert.ErrorIs(t, err, domainerr.ErrInsufficientRole)
+	})
 }
 
 func TestInviteUsers(t *testing.T) {
@@ -194,8 +232,8 @@ func TestInviteUsers(t *testing.T) {
 	user1ID := user1.GetId().Value()
 	user2 := entity.NewUser("Newcommer 2", "newcommer2@gmail.com")
 	user2ID := user2.GetId().Value()
-	userTeam1 := entity.NewUserTeam(*user1.GetId(), *team.GetId(), entity.Invited)
-	userTeam2 := entity.NewUserTeam(*user2.GetId(), *team.GetId(), entity.Invited)
+	userTeam1 := entity.NewInvitation(*user1.GetId(), *team.GetId(), userID, time.Now())
+	userTeam2 := entity.NewInvitation(*user2.GetId(), *team.GetId(), userID, time.Now())
 	targetUserEmails := []string{
 		"newcommer1@gmail.com",
 		"newcommer2@gmail.com",
@@ -206,17 +244,21 @@ func TestInviteUsers(t *testing.T) {
 		// チームと招待者ユーザーの存在確認
 		mockTeamRepo.EXPECT().FindById(teamID).Return(team, nil)
 		mockUserRepo.EXPECT().FindById(userID).Return(user, nil)
-		mockUserTeamRepo.EXPECT().IsMember(userID, teamID).Return(true, nil)
+		mockUserTeamRepo.EXPECT().FindRole(userID, teamID).Return(entity.RoleAdmin, nil)
 
 		// 招待対象ユーザーの存在確認と招待
 		// 1人目
 		mockUserRepo.EXPECT().FindByEmail(user1.GetEmail()).Return(user1, nil)
 		mockUserTeamRepo.EXPECT().IsMember(user1ID, teamID).Return(false, nil)
-		mockUserTeamRepo.EXPECT().Save(gomock.Any()).Return(userTeam1, nil)
+		mockUserTeamRepo.EXPECT().SaveInvitation(gomock.Any()).DoAndReturn(func(invitation *entity.UserTeam) (*entity.UserTeam, error) {
+			assert.Equal(t, userID, invitation.GetInvitedBy())
+			assert.Equal(t, entity.InvitationTTL, invitation.GetExpiresAt().Sub(invitation.GetInvitedAt()))
+			return userTeam1, nil
+		})
 		// 2人目
 		mockUserRepo.EXPECT().FindByEmail(user2.GetEmail()).Return(user2, nil)
 		mockUserTeamRepo.EXPECT().IsMember(user2ID, teamID).Return(false, nil)
-		mockUserTeamRepo.EXPECT().Save(gomock.Any()).Return(userTeam2, nil)
+		mockUserTeamRepo.EXPECT().SaveInvitation(gomock.Any()).Return(userTeam2, nil)
 
 		err := teamUsecase.InviteUsers(teamID, userID, targetUserEmails)
 		assert.NoError(t, err)
@@ -242,11 +284,62 @@ func TestInviteUsers(t *testing.T) {
 	t.Run("異常系: 招待者がチームのメンバーではない", func(t *testing.T) {
 		mockTeamRepo.EXPECT().FindById("team-123").Return(team, nil)
 		mockUserRepo.EXPECT().FindById("user-123").Return(user, nil)
-		mockUserTeamRepo.EXPECT().IsMember("user-123", "team-123").Return(false, nil)
+		mockUserTeamRepo.EXPECT().FindRole("user-123", "team-123").Return(entity.RoleNone, nil)
 
 		err := teamUsecase.InviteUsers("team-123", "user-123", nil)
 		assert.Error(t, err)
 		assert.Equal(t, "inviter is not a member of the team", err.Error())
+		assert.ErrorIs(t, err, domainerr.ErrNotTeamMember)
+	})
+
+	t.Run("異常系: 管理者でないメンバーは招待できない", func(t *testing.T) {
+		mockTeamRepo.EXPECT().FindById("team-123").Return(team, nil)
+		mockUserRepo.EXPECT().FindById("user-123").Return(user, nil)
+		mockUserTeamRepo.EXPECT().FindRole("user-123", "team-123").Return(entity.RoleMember, nil)
+
+		err := teamUsecase.InviteUsers("team-123", "user-123", nil)
+		assert.ErrorIs(t, err, domainerr.ErrInsufficientRole)
+	})
+
+	t.Run("異常系: 招待できないユーザーが入力エラーとして返される", func(t *testing.T) {
+		mockTeamRepo.EXPECT().FindById(teamID).Return(team, nil)
+		mockUserRepo.EXPECT().FindById(userID).Return(user, nil)
+		mockUserTeamRepo.EXPECT().FindRole(userID, teamID).Return(entity.RoleAdmin, nil)
+
+		// 1人目は存在せず、2人目は既にメンバー
+		mockUserRepo.EXPECT().FindByEmail(user1.GetEmail()).Return(nil, domainerr.ErrUserNotFound)
+		mockUserRepo.EXPECT().FindByEmail(user2.GetEmail()).Return(user2, nil)
+		mockUserTeamRepo.EXPECT().IsMember(user2ID, teamID).Return(true, nil)
+
+		err := teamUsecase.InviteUsers(teamID, userID, targetUserEmails)
+		domainErr, ok := domainerr.As(err)
+		assert.True(t, ok)
+		assert.Equal(t, domainerr.KindValidation, domainErr.Kind)
+		assert.Equal(t, []string{"target_user_emails[0]", "target_user_emails[1]"},
+			[]string{domainErr.Fields[0].Field, domainErr.Fields[1].Field})
+	})
+
+	t.Run("異常系: 招待の保存中にメンバーになったユーザーは入力エラーとして返される", func(t *testing.T) {
+		mockTeamRepo.EXPECT().FindById(teamID).Return(team, nil)
+		mockUserRepo.EXPECT().FindById(userID).Return(user, nil)
+		mockUserTeamRepo.EXPECT().FindRole(userID, teamID).Return(entity.RoleAdmin, nil)
+		mockUserRepo.EXPECT().FindByEmail(user1.GetEmail()).Return(user1, nil)
+		mockUserTeamRepo.EXPECT().IsMember(user1ID, teamID).Return(false, nil)
+		mockUserTeamRepo.EXPECT().SaveInvitation(gomock.Any()).Return(nil, domainerr.Conflict("ALREADY_TEAM_MEMBER", "user is already a member of the team"))
+
+		err := teamUsecase.InviteUsers(teamID, userID, targetUserEmails[:1])
+		assert.True(t, domainerr.IsKind(err, domainerr.KindValidation))
+	})
+
+	t.Run("異常系: データベースのエラーは入力エラーとして扱わない", func(t *testing.T) {
+		mockTeamRepo.EXPECT().FindById(teamID).Return(team, nil)
+		mockUserRepo.EXPECT().FindById(userID).Return(user, nil)
+		mockUserTeamRepo.EXPECT().FindRole(userID, teamID).Return(entity.RoleAdmin, nil)
+		mockUserRepo.EXPECT().FindByEmail(user1.GetEmail()).Return(nil, errors.New("connection refused"))
+
+		err := teamUsecase.InviteUsers(teamID, userID, targetUserEmails[:1])
+		assert.Error(t, err)
+		assert.False(t, domainerr.IsKind(err, domainerr.KindValidation))
 	})
 }
 
@@ -263,16 +356,86 @@ func TestAcceptInvitation(t *testing.T) {
 	team := entity.NewTeam("Team A")
 	user := entity.NewUser("User A", "user-123@gmail.com")
 	acceptedUserTeam := entity.NewUserTeam(*user.GetId(), *team.GetId(), entity.Member)
+	invitation := entity.NewInvitation(*entity.NewUserId("user-123"), *entity.NewTeamId("team-123"), "user-456", time.Now())
+	expired := entity.NewInvitation(*entity.NewUserId("user-123"), *entity.NewTeamId("team-123"), "user-456", time.Now().Add(-entity.InvitationTTL))
 
 	t.Run("正常系: 招待を受け入れる", func(t *testing.T) {
 		mockTeamRepo.EXPECT().FindById("team-123").Return(team, nil)
 		mockUserRepo.EXPECT().FindById("user-123").Return(user, nil)
+		mockUserTeamRepo.EXPECT().Find("user-123", "team-123").Return(invitation, nil)
+		mockUserTeamRepo.EXPECT().UpdateState(gomock.Any()).DoAndReturn(func(userTeam *entity.UserTeam) (*entity.UserTeam, error) {
+			assert.Equal(t, entity.Member, userTeam.GetState())
+			assert.Equal(t, "user-456", userTeam.GetInvitedBy())
+			return acceptedUserTeam, nil
+		})
+
+		err := teamUsecase.AcceptInvitation("team-123", "user-123")
+		assert.NoError(t, err)
+	})
+
+	t.Run("異常系: 招待されていない", func(t *testing.T) {
+		mockTeamRepo.EXPECT().FindById("team-123").Return(team, nil)
+		mockUserRepo.EXPECT().FindById("user-123").Return(user, nil)
+		mockUserTeamRepo.EXPECT().Find("user-123", "team-123").Return(nil, domainerr.ErrUserTeamNotFound)
+
+		err := teamUsecase.AcceptInvitation("team-123", "user-123")
+		assert.ErrorIs(t, err, domainerr.ErrInvitationNotFound)
+	})
+
+	t.Run("異常系: 既にメンバーであれば招待はない", func(t *testing.T) {
+		mockTeamRepo.EXPECT().FindById("team-123").Return(team, nil)
+		mockUserRepo.EXPECT().FindById("user-123").Return(user, nil)
+		mockUserTeamRepo.EXPECT().Find("user-123", "team-123").Return(acceptedUserTeam, nil)
+
+		err := teamUsecase.AcceptInvitation("team-123", "user-123")
+		assert.ErrorIs(t, err, domainerr.ErrInvitationNotFound)
+	})
+
+	t.Run("異常系: 受け入れる間に取り消された招待は受け入れられない", func(t *testing.T) {
+		pending := entity.NewInvitation(*entity.NewUserId("user-123"), *entity.NewTeamId("team-123"), "user-456", time.Now())
+
+		mockTeamRepo.EXPECT().FindById("team-123").Return(team, nil)
+		mockUserRepo.EXPECT().FindById("user-123").Return(user, nil)
+		mockUserTeamRepo.EXPECT().Find("user-123", "team-123").Return(pending, nil)
+		mockUserTeamRepo.EXPECT().UpdateState(gomock.Any()).Return(nil, domainerr.ErrInvitationNotFound)
+
+		err := teamUsecase.AcceptInvitation("team-123", "user-123")
+		assert.ErrorIs(t, err, domainerr.ErrInvitationNotFound)
+	})
+
+	t.Run("異常系: 期限切れの招待は受け入れられない", func(t *testing.T) {
+		mockTeamRepo.EXPECT().FindById("team-123").Return(team, nil)
+		mockUserRepo.EXPECT().FindById("user-123").Return(user, nil)
+		mockUserTeamRepo.EXPECT().Find("user-123", "team-123").Return(expired, nil)
+
+		err := teamUsecase.AcceptInvitation("team-123", "user-123")
+		assert.ErrorIs(t, err, domainerr.ErrInvitationExpired)
+		assert.True(t, domainerr.IsKind(err, domainerr.KindConflict))
+	})
+
+	t.Run("正常系: 期限の直前なら受け入れられる", func(t *testing.T) {
+		expiring := entity.NewInvitation(*entity.NewUserId("user-123"), *entity.NewTeamId("team-123"), "user-456", time.Now().Add(-entity.InvitationTTL+time.Minute))
+
+		mockTeamRepo.EXPECT().FindById("team-123").Return(team, nil)
+		mockUserRepo.EXPECT().FindById("user-123").Return(user, nil)
+		mockUserTeamRepo.EXPECT().Find("user-123", "team-123").Return(expiring, nil)
 		mockUserTeamRepo.EXPECT().UpdateState(gomock.Any()).Return(acceptedUserTeam, nil)
 
 		err := teamUsecase.AcceptInvitation("team-123", "user-123")
 		assert.NoError(t, err)
 	})
 
+	t.Run("異常系: 期限の直後は受け入れられない", func(t *testing.T) {
+		justExpired := entity.NewInvitation(*entity.NewUserId("user-123"), *entity.NewTeamId("team-123"), "user-456", time.Now().Add(-entity.InvitationTTL-time.Second))
+
+		mockTeamRepo.EXPECT().FindById("team-123").Return(team, nil)
+		mockUserRepo.EXPECT().FindById("user-123").Return(user, nil)
+		mockUserTeamRepo.EXPECT().Find("user-123", "team-123").Return(justExpired, nil)
+
+		err := teamUsecase.AcceptInvitation("team-123", "user-123")
+		assert.ErrorIs(t, err, domainerr.ErrInvitationExpired)
+	})
+
 	t.Run("異常系: チームが見つからない", func(t *testing.T) {
 		mockTeamRepo.EXPECT().FindById("team-123").Return(nil, errors.New("team not found"))
 
@@ -291,6 +454,93 @@ func TestAcceptInvitation(t *testing.T) {
 	})
 }
 
+func TestDeclineInvitation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUserTeamRepo := mock.NewMockUserTeamRepository(ctrl)
+
+	teamUsecase := usecase.NewTeamUsecase(mockTeamRepo, mockUserRepo, mockUserTeamRepo)
+
+	expired := entity.NewInvitation(*entity.NewUserId("user-123"), *entity.NewTeamId("team-123"), "user-456", time.Now().Add(-2*entity.InvitationTTL))
+
+	t.Run("正常系: 期限切れでも招待を辞退できる", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().Find("user-123", "team-123").Return(expired, nil)
+		mockUserTeamRepo.EXPECT().UpdateState(gomock.Any()).DoAndReturn(func(userTeam *entity.UserTeam) (*entity.UserTeam, error) {
+			// the invitation is kept on record as declined
+			assert.Equal(t, entity.Declined, userTeam.GetState())
+			assert.False(t, userTeam.GetClosedAt().IsZero())
+			assert.Equal(t, "user-456", userTeam.GetInvitedBy())
+			return userTeam, nil
+		})
+
+		err := teamUsecase.DeclineInvitation("team-123", "user-123")
+		assert.NoError(t, err)
+	})
+
+	t.Run("異常系: 辞退済みの招待は辞退できない", func(t *testing.T) {
+		declined := entity.NewInvitation(*entity.NewUserId("user-123"), *entity.NewTeamId("team-123"), "user-456", time.Now())
+		declined.Decline(time.Now())
+		mockUserTeamRepo.EXPECT().Find("user-123", "team-123").Return(declined, nil)
+
+		err := teamUsecase.DeclineInvitation("team-123", "user-123")
+		assert.ErrorIs(t, err, domainerr.ErrInvitationNotFound)
+	})
+
+	t.Run("異常系: メンバーは辞退ではチームを抜けられない", func(t *testing.T) {
+		member := entity.NewUserTeam(*entity.NewUserId("user-123"), *entity.NewTeamId("team-123"), entity.Member)
+		mockUserTeamRepo.EXPECT().Find("user-123", "team-123").Return(member, nil)
+
+		err := teamUsecase.DeclineInvitation("team-123", "user-123")
+		assert.ErrorIs(t, err, domainerr.ErrInvitationNotFound)
+	})
+}
+
+func TestRevokeInvitation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUserTeamRepo := mock.NewMockUserTeamRepository(ctrl)
+
+	teamUsecase := usecase.NewTeamUsecase(mockTeamRepo, mockUserRepo, mockUserTeamRepo)
+
+	invitation := entity.NewInvitation(*entity.NewUserId("user-789"), *entity.NewTeamId("team-123"), "user-456", time.Now())
+
+	t.Run("正常系: 管理者が招待を取り消す", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-123", "team-123").Return(entity.RoleAdmin, nil)
+		mockUserTeamRepo.EXPECT().Find("user-789", "team-123").Return(invitation, nil)
+		mockUserTeamRepo.EXPECT().UpdateState(gomock.Any()).DoAndReturn(func(userTeam *entity.UserTeam) (*entity.UserTeam, error) {
+			// the invitation is kept on record as revoked
+			assert.Equal(t, entity.Revoked, userTeam.GetState())
+			assert.False(t, userTeam.GetClosedAt().IsZero())
+			return userTeam, nil
+		})
+
+		err := teamUsecase.RevokeInvitation("team-123", "user-123", "user-789")
+		assert.NoError(t, err)
+	})
+
+	t.Run("異常系: 管理者でないメンバーは招待を取り消せない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-123", "team-123").Return(entity.RoleMember, nil)
+
+		err := teamUsecase.RevokeInvitation("team-123", "user-123", "user-789")
+		assert.ErrorIs(t, err, domainerr.ErrInsufficientRole)
+	})
+
+	t.Run("異常系: 招待されていないユーザーの招待は取り消せない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-123", "team-123").Return(entity.RoleAdmin, nil)
+		mockUserTeamRepo.EXPECT().Find("user-789", "team-123").Return(nil, domainerr.ErrUserTeamNotFound)
+
+		err := teamUsecase.RevokeInvitation("team-123", "user-123", "user-789")
+		assert.ErrorIs(t, err, domainerr.ErrInvitationNotFound)
+		assert.True(t, domainerr.IsKind(err, domainerr.KindNotFound))
+	})
+}
+
 func TestRemoveMember(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -305,30 +555,78 @@ func TestRemoveMember(t *testing.T) {
 	user := entity.NewUser("User A", "user-123@gmail.com")
 
 	t.Run("正常系: メンバーが正常に削除される", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-456", "team-123").Return(entity.RoleAdmin, nil)
 		mockTeamRepo.EXPECT().FindById("team-123").Return(team, nil)
 		mockUserRepo.EXPECT().FindById("user-123").Return(user, nil)
+		mockUserTeamRepo.EXPECT().FindRole("user-123", "team-123").Return(entity.RoleMember, nil)
 		mockUserTeamRepo.EXPECT().Delete("user-123", "team-123").Return(nil)
 
-		err := teamUsecase.RemoveMember("team-123", "user-123")
+		err := teamUsecase.RemoveMember("team-123", "user-456", "user-123")
 		assert.NoError(t, err)
 	})
 
 	t.Run("異常系: チームが見つからない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-456", "team-123").Return(entity.RoleAdmin, nil)
 		mockTeamRepo.EXPECT().FindById("team-123").Return(nil, errors.New("team not found"))
 
-		err := teamUsecase.RemoveMember("team-123", "user-123")
+		err := teamUsecase.RemoveMember("team-123", "user-456", "user-123")
 		assert.Error(t, err)
 		assert.Equal(t, "team not found", err.Error())
 	})
 
 	t.Run("異常系: ユーザーが見つからない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-456", "team-123").Return(entity.RoleAdmin, nil)
 		mockTeamRepo.EXPECT().FindById("team-123").Return(team, nil)
 		mockUserRepo.EXPECT().FindById("user-123").Return(nil, errors.New("user not found"))
 
-		err := teamUsecase.RemoveMember("team-123", "user-123")
+		err := teamUsecase.RemoveMember("team-123", "user-456", "user-123")
 		assert.Error(t, err)
 		assert.Equal(t, "user not found", err.Error())
 	})
+
+	t.Run("正常系: 自分自身はメンバーでなくてもチームを抜けられる", func(t *testing.T) {
+		mockTeamRepo.EXPECT().FindById("team-123").Return(team, nil)
+		mockUserRepo.EXPECT().FindById("user-123").Return(user, nil)
+		mockUserTeamRepo.EXPECT().FindRole("user-123", "team-123").Return(entity.RoleNone, nil)
+		mockUserTeamRepo.EXPECT().Delete("user-123", "team-123").Return(nil)
+
+		err := teamUsecase.RemoveMember("team-123", "user-123", "user-123")
+		assert.NoError(t, err)
+	})
+
+	t.Run("異常系: チームのメンバーではないユーザーは他人を削除できない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-456", "team-123").Return(entity.RoleNone, nil)
+
+		err := teamUsecase.RemoveMember("team-123", "user-456", "user-123")
+		assert.ErrorIs(t, err, domainerr.ErrNotTeamMember)
+	})
+
+	t.Run("異常系: 管理者でないメンバーは他人を削除できない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-456", "team-123").Return(entity.RoleViewer, nil)
+
+		err := teamUsecase.RemoveMember("team-123", "user-456", "user-123")
+		assert.ErrorIs(t, err, domainerr.ErrInsufficientRole)
+	})
+
+	t.Run("異常系: オーナーは譲渡しなければチームを抜けられない", func(t *testing.T) {
+		mockTeamRepo.EXPECT().FindById("team-123").Return(team, nil)
+		mockUserRepo.EXPECT().FindById("user-123").Return(user, nil)
+		mockUserTeamRepo.EXPECT().FindRole("user-123", "team-123").Return(entity.RoleOwner, nil)
+
+		err := teamUsecase.RemoveMember("team-123", "user-123", "user-123")
+		assert.ErrorIs(t, err, domainerr.ErrOwnerMustTransfer)
+		assert.True(t, domainerr.IsKind(err, domainerr.KindConflict))
+	})
+
+	t.Run("異常系: 管理者はオーナーを削除できない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-456", "team-123").Return(entity.RoleAdmin, nil)
+		mockTeamRepo.EXPECT().FindById("team-123").Return(team, nil)
+		mockUserRepo.EXPECT().FindById("user-123").Return(user, nil)
+		mockUserTeamRepo.EXPECT().FindRole("user-123", "team-123").Return(entity.RoleOwner, nil)
+
+		err := teamUsecase.RemoveMember("team-123", "user-456", "user-123")
+		assert.ErrorIs(t, err, domainerr.ErrOwnerMustTransfer)
+	})
 }
 
 func TestGetTeamsByUserId(t *testing.T) {
@@ -385,22 +683,44 @@ func TestGetInvitedTeams(t *testing.T) {
 		entity.NewTeam("Team B"),
 	}
 	userId := "user-123"
+	invitee := entity.NewUser("Invitee", "invitee@gmail.com")
+	inviter := entity.NewUser("Inviter", "inviter@gmail.com")
+	invitedAt := time.Now().Add(-time.Hour)
+
+	t.Run("正常系: ユーザーが招待されているチームが招待者と期限とともに取得される", func(t *testing.T) {
+		invitations := []*entity.UserTeam{
+			entity.NewInvitation(*entity.NewUserId(userId), *entity.NewTeamId("team-123"), inviter.GetId().Value(), invitedAt),
+			entity.NewInvitation(*entity.NewUserId(userId), *entity.NewTeamId("team-456"), "", invitedAt),
+		}
 
-	t.Run("正常系: ユーザーが招待されているチームが正常に取得される", func(t *testing.T) {
-
-		mockUserTeamRepo.EXPECT().FindInvitedTeamsByUserId(userId).Return([]string{"team-123", "team-456"}, nil)
+		mockUserTeamRepo.EXPECT().FindInvitationsByUserId(userId).Return(invitations, nil)
 		mockTeamRepo.EXPECT().FindById("team-123").Return(teams[0], nil)
+		mockUserRepo.EXPECT().FindById(userId).Return(invitee, nil)
+		mockUserRepo.EXPECT().FindById(inviter.GetId().Value()).Return(inviter, nil)
 		mockTeamRepo.EXPECT().FindById("team-456").Return(teams[1], nil)
 
 		result, err := teamUsecase.GetInvitedTeams(userId)
 		assert.NoError(t, err)
-		assert.Equal(t, teams, result)
+		assert.Equal(t, []usecase.Invitation{
+			{Team: teams[0], User: invitee, InvitedBy: inviter, InvitedAt: invitedAt, ExpiresAt: invitedAt.Add(entity.InvitationTTL)},
+			{Team: teams[1], User: invitee, InvitedAt: invitedAt, ExpiresAt: invitedAt.Add(entity.InvitationTTL)},
+		}, result)
+	})
+
+	t.Run("正常系: 期限切れの招待は返されない", func(t *testing.T) {
+		expired := entity.NewInvitation(*entity.NewUserId(userId), *entity.NewTeamId("team-123"), inviter.GetId().Value(), invitedAt.Add(-entity.InvitationTTL))
+
+		mockUserTeamRepo.EXPECT().FindInvitationsByUserId(userId).Return([]*entity.UserTeam{expired}, nil)
+
+		result, err := teamUsecase.GetInvitedTeams(userId)
+		assert.NoError(t, err)
+		assert.Empty(t, result)
 	})
 
 	t.Run("異常系: チームの取得に失敗する", func(t *testing.T) {
 		userId := "user-123"
 
-		mockUserTeamRepo.EXPECT().FindInvitedTeamsByUserId(userId).Return(nil, errors.New("failed to get teams"))
+		mockUserTeamRepo.EXPECT().FindInvitationsByUserId(userId).Return(nil, errors.New("failed to get teams"))
 
 		result, err := teamUsecase.GetInvitedTeams(userId)
 		assert.Error(t, err)
@@ -409,6 +729,73 @@ func TestGetInvitedTeams(t *testing.T) {
 	})
 }
 
+func TestGetInvitedUsersByTeamId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUserTeamRepo := mock.NewMockUserTeamRepository(ctrl)
+
+	teamUsecase := usecase.NewTeamUsecase(mockTeamRepo, mockUserRepo, mockUserTeamRepo)
+
+	team := entity.NewTeam("Team A")
+	teamId := team.GetId().Value()
+	inviter := entity.NewUser("Inviter", "inviter@gmail.com")
+	invitees := []*entity.User{
+		entity.NewUser("User A", "user-a@gmail.com"),
+		entity.NewUser("User B", "user-b@gmail.com"),
+	}
+	invitedAt := time.Now().Add(-time.Hour)
+
+	t.Run("正常系: チームの招待が招待者と期限とともに取得される", func(t *testing.T) {
+		invitations := []*entity.UserTeam{
+			entity.NewInvitation(*invitees[0].GetId(), *team.GetId(), inviter.GetId().Value(), invitedAt),
+			entity.NewInvitation(*invitees[1].GetId(), *team.GetId(), inviter.GetId().Value(), invitedAt),
+		}
+
+		mockUserTeamRepo.EXPECT().FindRole("user-123", teamId).Return(entity.RoleMember, nil)
+		mockTeamRepo.EXPECT().FindById(teamId).Return(team, nil)
+		mockUserTeamRepo.EXPECT().FindInvitationsByTeamId(teamId).Return(invitations, nil)
+		mockUserRepo.EXPECT().FindById(invitees[0].GetId().Value()).Return(invitees[0], nil)
+		mockUserRepo.EXPECT().FindById(invitees[1].GetId().Value()).Return(invitees[1], nil)
+		// the inviter of both invitations is looked up once
+		mockUserRepo.EXPECT().FindById(inviter.GetId().Value()).Return(inviter, nil)
+
+		result, err := teamUsecase.GetInvitedUsersByTeamId(teamId, "user-123")
+		assert.NoError(t, err)
+		assert.Equal(t, []usecase.Invitation{
+			{Team: team, User: invitees[0], InvitedBy: inviter, InvitedAt: invitedAt, ExpiresAt: invitedAt.Add(entity.InvitationTTL)},
+			{Team: team, User: invitees[1], InvitedBy: inviter, InvitedAt: invitedAt, ExpiresAt: invitedAt.Add(entity.InvitationTTL)},
+		}, result)
+	})
+
+	t.Run("正常系: 期限切れの招待は返されない", func(t *testing.T) {
+		expired := entity.NewInvitation(*invitees[0].GetId(), *team.GetId(), inviter.GetId().Value(), invitedAt.Add(-entity.InvitationTTL))
+
+		mockUserTeamRepo.EXPECT().FindRole("user-123", teamId).Return(entity.RoleMember, nil)
+		mockTeamRepo.EXPECT().FindById(teamId).Return(team, nil)
+		mockUserTeamRepo.EXPECT().FindInvitationsByTeamId(teamId).Return([]*entity.UserTeam{expired}, nil)
+
+		result, err := teamUsecase.GetInvitedUsersByTeamId(teamId, "user-123")
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("異常系: 招待されたユーザーの取得に失敗する", func(t *testing.T) {
+		invitation := entity.NewInvitation(*invitees[0].GetId(), *team.GetId(), "", invitedAt)
+
+		mockUserTeamRepo.EXPECT().FindRole("user-123", teamId).Return(entity.RoleMember, nil)
+		mockTeamRepo.EXPECT().FindById(teamId).Return(team, nil)
+		mockUserTeamRepo.EXPECT().FindInvitationsByTeamId(teamId).Return([]*entity.UserTeam{invitation}, nil)
+		mockUserRepo.EXPECT().FindById(invitees[0].GetId().Value()).Return(nil, errors.New("user not found"))
+
+		result, err := teamUsecase.GetInvitedUsersByTeamId(teamId, "user-123")
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
 func TestGetMembersByTeamId(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -427,10 +814,11 @@ func TestGetMembersByTeamId(t *testing.T) {
 
 	t.Run("正常系: チームのメンバーが正常に取得される", func(t *testing.T) {
 
+		mockUserTeamRepo.EXPECT().FindRole("user-123", teamId).Return(entity.RoleViewer, nil)
 		mockUserTeamRepo.EXPECT().FindMembersByTeamId(teamId).Return(userIds, nil)
 		mockUserRepo.EXPECT().FindById("user-123").Return(users[0], nil)
 		mockUserRepo.EXPECT().FindById("user-456").Return(users[1], nil)
-		result, err := teamUsecase.GetMembersByTeamId(teamId)
+		result, err := teamUsecase.GetMembersByTeamId(teamId, "user-123")
 		assert.NoError(t, err)
 		assert.Equal(t, users, result)
 	})
@@ -438,11 +826,116 @@ func TestGetMembersByTeamId(t *testing.T) {
 	t.Run("異常系: メンバーの取得に失敗する", func(t *testing.T) {
 		teamId := "team-123"
 
+		mockUserTeamRepo.EXPECT().FindRole("user-123", teamId).Return(entity.RoleViewer, nil)
 		mockUserTeamRepo.EXPECT().FindMembersByTeamId(teamId).Return(nil, errors.New("failed to get members"))
 
-		result, err := teamUsecase.GetMembersByTeamId(teamId)
+		result, err := teamUsecase.GetMembersByTeamId(teamId, "user-123")
 		assert.Error(t, err)
 		assert.Nil(t, result)
 		assert.Equal(t, "failed to get members", err.Error())
 	})
+
+	t.Run("異常系: チームのメンバーではない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-789", teamId).Return(entity.RoleNone, nil)
+
+		result, err := teamUsecase.GetMembersByTeamId(teamId, "user-789")
+		assert.ErrorIs(t, err, domainerr.ErrNotTeamMember)
+		assert.Nil(t, result)
+	})
+}
+
+func TestSetRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUserTeamRepo := mock.NewMockUserTeamRepository(ctrl)
+
+	teamUsecase := usecase.NewTeamUsecase(mockTeamRepo, mockUserRepo, mockUserTeamRepo)
+	teamId := "team-123"
+
+	t.Run("正常系: 管理者がメンバーを閲覧者にする", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-123", teamId).Return(entity.RoleAdmin, nil)
+		mockUserTeamRepo.EXPECT().FindRole("user-456", teamId).Return(entity.RoleMember, nil)
+		mockUserTeamRepo.EXPECT().UpdateRole(gomock.Any()).DoAndReturn(func(userTeam *entity.UserTeam) (*entity.UserTeam, error) {
+			assert.Equal(t, "user-456", userTeam.GetUserId().Value())
+			assert.Equal(t, entity.RoleViewer, userTeam.GetRole())
+			return userTeam, nil
+		})
+
+		err := teamUsecase.SetRole(teamId, "user-123", "user-456", entity.RoleViewer)
+		assert.NoError(t, err)
+	})
+
+	t.Run("異常系: 管理者でないメンバーは役割を変更できない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-123", teamId).Return(entity.RoleMember, nil)
+
+		err := teamUsecase.SetRole(teamId, "user-123", "user-456", entity.RoleAdmin)
+		assert.ErrorIs(t, err, domainerr.ErrInsufficientRole)
+	})
+
+	t.Run("異常系: オーナーの役割は譲渡でしか変わらない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-123", teamId).Return(entity.RoleAdmin, nil)
+
+		err := teamUsecase.SetRole(teamId, "user-123", "user-456", entity.RoleOwner)
+		assert.True(t, domainerr.IsKind(err, domainerr.KindValidation))
+
+		mockUserTeamRepo.EXPECT().FindRole("user-123", teamId).Return(entity.RoleAdmin, nil)
+		mockUserTeamRepo.EXPECT().FindRole("user-789", teamId).Return(entity.RoleOwner, nil)
+
+		err = teamUsecase.SetRole(teamId, "user-123", "user-789", entity.RoleMember)
+		assert.ErrorIs(t, err, domainerr.ErrOwnerMustTransfer)
+	})
+
+	t.Run("異常系: 存在しない役割は指定できない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-123", teamId).Return(entity.RoleAdmin, nil)
+
+		err := teamUsecase.SetRole(teamId, "user-123", "user-456", entity.TeamRole("COACH"))
+		assert.True(t, domainerr.IsKind(err, domainerr.KindValidation))
+	})
+
+	t.Run("異常系: チームのメンバーでないユーザーには役割を与えられない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-123", teamId).Return(entity.RoleAdmin, nil)
+		mockUserTeamRepo.EXPECT().FindRole("user-456", teamId).Return(entity.RoleNone, nil)
+
+		err := teamUsecase.SetRole(teamId, "user-123", "user-456", entity.RoleMember)
+		assert.ErrorIs(t, err, domainerr.ErrUserTeamNotFound)
+	})
+}
+
+func TestTransferOwnership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTeamRepo := mock.NewMockTeamRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUserTeamRepo := mock.NewMockUserTeamRepository(ctrl)
+
+	teamUsecase := usecase.NewTeamUsecase(mockTeamRepo, mockUserRepo, mockUserTeamRepo)
+	teamId := "team-123"
+
+	t.Run("正常系: オーナーがメンバーにチームを譲渡する", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-123", teamId).Return(entity.RoleOwner, nil)
+		mockUserTeamRepo.EXPECT().FindRole("user-456", teamId).Return(entity.RoleViewer, nil)
+		mockUserTeamRepo.EXPECT().TransferOwnership(teamId, "user-123", "user-456").Return(nil)
+
+		err := teamUsecase.TransferOwnership(teamId, "user-123", "user-456")
+		assert.NoError(t, err)
+	})
+
+	t.Run("異常系: オーナーでなければ譲渡できない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-123", teamId).Return(entity.RoleAdmin, nil)
+
+		err := teamUsecase.TransferOwnership(teamId, "user-123", "user-456")
+		assert.ErrorIs(t, err, domainerr.ErrInsufficientRole)
+	})
+
+	t.Run("異常系: チームのメンバーでないユーザーには譲渡できない", func(t *testing.T) {
+		mockUserTeamRepo.EXPECT().FindRole("user-123", teamId).Return(entity.RoleOwner, nil)
+		mockUserTeamRepo.EXPECT().FindRole("user-456", teamId).Return(entity.RoleNone, nil)
+
+		err := teamUsecase.TransferOwnership(teamId, "user-123", "user-456")
+		assert.True(t, domainerr.IsKind(err, domainerr.KindValidation))
+	})
 }
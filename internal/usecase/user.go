@@ -1,9 +1,11 @@
 package usecase
 
 import (
+	"CurlARC/internal/domain/domainerr"
 	"CurlARC/internal/domain/entity"
 	"CurlARC/internal/domain/repository"
 	"CurlARC/internal/utils"
+	"errors"
 
 	"github.com/labstack/echo/v4"
 )
@@ -38,13 +40,13 @@ func (usecase *userUsecase) Authorize(c echo.Context, idToken string) (*entity.U
 
 	// Find the user by email
 	user, err := usecase.userRepo.FindByEmail(email)
-	if err != nil && err.Error() == "record not found" {
+	if errors.Is(err, domainerr.ErrUserNotFound) {
 		// If the user does not exist, create and save a new user
 		user = entity.NewUser(name, email)
 		user, err = usecase.userRepo.Save(user)
-		if err != nil {
-			return nil, nil, err
-		}
+	}
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Generate a backend access token
@@ -18,6 +18,7 @@ func main() {
 	utils.LoadEnv()
 
 	e := echo.New()
+	e.HTTPErrorHandler = handler.ErrorHandler
 
 	// Middleware
 	// e.Use(middleware.Logger())
@@ -39,8 +40,12 @@ func main() {
 	userHandler := injector.InjectUserHandler()
 	recordHandler := injector.InjectRecordHandler()
 	teamHandler := injector.InjectTeamHandler()
+	statsHandler := injector.InjectStatsHandler()
+	eventHandler := injector.InjectEventHandler()
+	seasonHandler := injector.InjectSeasonHandler()
+	practiceHandler := injector.InjectPracticeHandler()
 
 	// Routing
-	handler.InitRouting(e, userHandler, teamHandler, recordHandler)
+	handler.InitRouting(e, userHandler, teamHandler, recordHandler, statsHandler, eventHandler, seasonHandler, practiceHandler)
 	e.Logger.Fatal(e.Start(":8080"))
 }
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE "public"."records" ADD COLUMN "status" varchar(16) NOT NULL DEFAULT 'COMPLETED';
+
+-- +goose Down
+ALTER TABLE "public"."records" DROP COLUMN IF EXISTS "status";
@@ -0,0 +1,29 @@
+-- +goose Up
+CREATE TABLE "events" (
+  "id" uuid NOT NULL,
+  "team_id" text NOT NULL,
+  "name" character varying(255) NOT NULL,
+  "venue" character varying(255) NULL,
+  "start_date" timestamp NULL,
+  "end_date" timestamp NULL,
+  "format" character varying(16) NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_teams_events" FOREIGN KEY ("team_id") REFERENCES "teams" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+CREATE INDEX "idx_events_team_id" ON "events" ("team_id");
+
+CREATE TABLE "event_games" (
+  "event_id" uuid NOT NULL,
+  "record_id" uuid NOT NULL,
+  "round" character varying(64) NULL,
+  "friend_lsd" double precision NULL,
+  "enemy_lsd" double precision NULL,
+  PRIMARY KEY ("event_id", "record_id"),
+  CONSTRAINT "uni_event_games_record_id" UNIQUE ("record_id"),
+  CONSTRAINT "fk_events_games" FOREIGN KEY ("event_id") REFERENCES "events" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "fk_event_games_record" FOREIGN KEY ("record_id") REFERENCES "records" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE "event_games";
+DROP TABLE "events";
@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE "seasons" (
+  "id" uuid NOT NULL,
+  "team_id" text NOT NULL,
+  "name" character varying(255) NOT NULL,
+  "start_date" date NOT NULL,
+  "end_date" date NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_teams_seasons" FOREIGN KEY ("team_id") REFERENCES "teams" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+CREATE INDEX "idx_seasons_team_id" ON "seasons" ("team_id");
+
+-- +goose Down
+DROP TABLE "seasons";
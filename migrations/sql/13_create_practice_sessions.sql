@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE "practice_sessions" (
+  "id" uuid NOT NULL,
+  "team_id" text NOT NULL,
+  "date" timestamp NOT NULL,
+  "place" character varying(255) NULL,
+  "attendees_json" jsonb NULL,
+  "drills_json" jsonb NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_teams_practice_sessions" FOREIGN KEY ("team_id") REFERENCES "teams" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+CREATE INDEX "idx_practice_sessions_team_id" ON "practice_sessions" ("team_id");
+
+-- +goose Down
+DROP TABLE "practice_sessions";
@@ -0,0 +1,5 @@
+-- +goose Up
+CREATE INDEX "idx_records_team_id_date" ON "records" ("team_id", (COALESCE("date", '0001-01-01')), "id");
+
+-- +goose Down
+DROP INDEX "idx_records_team_id_date";
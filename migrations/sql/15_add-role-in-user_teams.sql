@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE "public"."user_teams" ADD COLUMN "role" varchar(16) NOT NULL DEFAULT 'MEMBER';
+-- Existing members stay plain members; the owner hands out admin rights from here on.
+-- Each team gets its creator as its single owner. user_teams keeps no timestamps, but the creator's row is
+-- inserted as a member when the team is created, while an invited member's row is rewritten on accepting,
+-- so the creator's row is the one written by the oldest transaction. The user id only breaks ties.
+UPDATE "public"."user_teams" AS ut SET "role" = 'OWNER'
+FROM (
+  SELECT DISTINCT ON ("team_id") "user_id", "team_id"
+  FROM "public"."user_teams"
+  WHERE "state" = 'MEMBER'
+  ORDER BY "team_id", "xmin"::text::bigint, "user_id"
+) AS creator
+WHERE ut."user_id" = creator."user_id" AND ut."team_id" = creator."team_id";
+
+-- +goose Down
+ALTER TABLE "public"."user_teams" DROP COLUMN IF EXISTS "role";
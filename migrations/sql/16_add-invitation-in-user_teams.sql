@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE "public"."user_teams" ADD COLUMN "invited_by" text NULL;
+ALTER TABLE "public"."user_teams" ADD COLUMN "invited_at" timestamp NULL;
+ALTER TABLE "public"."user_teams" ADD COLUMN "expires_at" timestamp NULL;
+ALTER TABLE "public"."user_teams" ADD COLUMN "closed_at" timestamp NULL;
+ALTER TABLE "public"."user_teams" ADD CONSTRAINT "fk_user_teams_inviter" FOREIGN KEY ("invited_by") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE SET NULL;
+-- Pending invitations sent before expiry existed may still be accepted for a week
+UPDATE "public"."user_teams" SET "invited_at" = NOW(), "expires_at" = NOW() + INTERVAL '7 days' WHERE "state" = 'INVITED';
+
+-- +goose Down
+ALTER TABLE "public"."user_teams" DROP CONSTRAINT IF EXISTS "fk_user_teams_inviter";
+ALTER TABLE "public"."user_teams" DROP COLUMN IF EXISTS "closed_at";
+ALTER TABLE "public"."user_teams" DROP COLUMN IF EXISTS "expires_at";
+ALTER TABLE "public"."user_teams" DROP COLUMN IF EXISTS "invited_at";
+ALTER TABLE "public"."user_teams" DROP COLUMN IF EXISTS "invited_by";
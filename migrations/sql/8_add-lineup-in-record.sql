@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE "public"."records" ADD COLUMN "lineup_json" jsonb NULL;
+
+-- +goose Down
+ALTER TABLE "public"."records" DROP COLUMN IF EXISTS "lineup_json";
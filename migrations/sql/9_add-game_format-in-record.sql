@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE "public"."records" ADD COLUMN "game_format" varchar(32) NOT NULL DEFAULT 'FOUR_PLAYER_8_ENDS';
+
+-- +goose Down
+ALTER TABLE "public"."records" DROP COLUMN IF EXISTS "game_format";
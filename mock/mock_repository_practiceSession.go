@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/domain/repository/practiceSession.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "CurlARC/internal/domain/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPracticeSessionRepository is a mock of PracticeSessionRepository interface.
+type MockPracticeSessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPracticeSessionRepositoryMockRecorder
+}
+
+// MockPracticeSessionRepositoryMockRecorder is the mock recorder for MockPracticeSessionRepository.
+type MockPracticeSessionRepositoryMockRecorder struct {
+	mock *MockPracticeSessionRepository
+}
+
+// NewMockPracticeSessionRepository creates a new mock instance.
+func NewMockPracticeSessionRepository(ctrl *gomock.Controller) *MockPracticeSessionRepository {
+	mock := &MockPracticeSessionRepository{ctrl: ctrl}
+	mock.recorder = &MockPracticeSessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPracticeSessionRepository) EXPECT() *MockPracticeSessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockPracticeSessionRepository) Delete(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPracticeSessionRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPracticeSessionRepository)(nil).Delete), id)
+}
+
+// FindById mocks base method.
+func (m *MockPracticeSessionRepository) FindById(id string) (*entity.PracticeSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*entity.PracticeSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockPracticeSessionRepositoryMockRecorder) FindById(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockPracticeSessionRepository)(nil).FindById), id)
+}
+
+// FindByTeamId mocks base method.
+func (m *MockPracticeSessionRepository) FindByTeamId(teamId string) ([]*entity.PracticeSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTeamId", teamId)
+	ret0, _ := ret[0].([]*entity.PracticeSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTeamId indicates an expected call of FindByTeamId.
+func (mr *MockPracticeSessionRepositoryMockRecorder) FindByTeamId(teamId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTeamId", reflect.TypeOf((*MockPracticeSessionRepository)(nil).FindByTeamId), teamId)
+}
+
+// Save mocks base method.
+func (m *MockPracticeSessionRepository) Save(session *entity.PracticeSession) (*entity.PracticeSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", session)
+	ret0, _ := ret[0].(*entity.PracticeSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockPracticeSessionRepositoryMockRecorder) Save(session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPracticeSessionRepository)(nil).Save), session)
+}
+
+// Update mocks base method.
+func (m *MockPracticeSessionRepository) Update(session *entity.PracticeSession) (*entity.PracticeSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", session)
+	ret0, _ := ret[0].(*entity.PracticeSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPracticeSessionRepositoryMockRecorder) Update(session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPracticeSessionRepository)(nil).Update), session)
+}
@@ -6,7 +6,7 @@ package mock
 
 import (
 	entity "CurlARC/internal/domain/entity"
-	response "CurlARC/internal/handler/response"
+	repository "CurlARC/internal/domain/repository"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -49,6 +49,35 @@ func (mr *MockRecordRepositoryMockRecorder) Delete(recordId interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRecordRepository)(nil).Delete), recordId)
 }
 
+// EachWithEndsData mocks base method.
+func (m *MockRecordRepository) EachWithEndsData(teamId string, includePublic bool, fn func(entity.Record) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EachWithEndsData", teamId, includePublic, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EachWithEndsData indicates an expected call of EachWithEndsData.
+func (mr *MockRecordRepositoryMockRecorder) EachWithEndsData(teamId, includePublic, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EachWithEndsData", reflect.TypeOf((*MockRecordRepository)(nil).EachWithEndsData), teamId, includePublic, fn)
+}
+
+// FindByIds mocks base method.
+func (m *MockRecordRepository) FindByIds(recordIds []string) (*[]entity.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIds", recordIds)
+	ret0, _ := ret[0].(*[]entity.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIds indicates an expected call of FindByIds.
+func (mr *MockRecordRepositoryMockRecorder) FindByIds(recordIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIds", reflect.TypeOf((*MockRecordRepository)(nil).FindByIds), recordIds)
+}
+
 // FindByRecordId mocks base method.
 func (m *MockRecordRepository) FindByRecordId(recordId string) (*entity.Record, error) {
 	m.ctrl.T.Helper()
@@ -80,18 +109,18 @@ func (mr *MockRecordRepositoryMockRecorder) FindByTeamId(teamId interface{}) *go
 }
 
 // FindIndicesByTeamId mocks base method.
-func (m *MockRecordRepository) FindIndicesByTeamId(teamId string) (*[]response.RecordIndex, error) {
+func (m *MockRecordRepository) FindIndicesByTeamId(teamId string, query repository.RecordQuery) (*repository.RecordIndexPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindIndicesByTeamId", teamId)
-	ret0, _ := ret[0].(*[]response.RecordIndex)
+	ret := m.ctrl.Call(m, "FindIndicesByTeamId", teamId, query)
+	ret0, _ := ret[0].(*repository.RecordIndexPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindIndicesByTeamId indicates an expected call of FindIndicesByTeamId.
-func (mr *MockRecordRepositoryMockRecorder) FindIndicesByTeamId(teamId interface{}) *gomock.Call {
+func (mr *MockRecordRepositoryMockRecorder) FindIndicesByTeamId(teamId, query interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindIndicesByTeamId", reflect.TypeOf((*MockRecordRepository)(nil).FindIndicesByTeamId), teamId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindIndicesByTeamId", reflect.TypeOf((*MockRecordRepository)(nil).FindIndicesByTeamId), teamId, query)
 }
 
 // Save mocks base method.
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/domain/repository/season.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "CurlARC/internal/domain/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSeasonRepository is a mock of SeasonRepository interface.
+type MockSeasonRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeasonRepositoryMockRecorder
+}
+
+// MockSeasonRepositoryMockRecorder is the mock recorder for MockSeasonRepository.
+type MockSeasonRepositoryMockRecorder struct {
+	mock *MockSeasonRepository
+}
+
+// NewMockSeasonRepository creates a new mock instance.
+func NewMockSeasonRepository(ctrl *gomock.Controller) *MockSeasonRepository {
+	mock := &MockSeasonRepository{ctrl: ctrl}
+	mock.recorder = &MockSeasonRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeasonRepository) EXPECT() *MockSeasonRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSeasonRepository) Delete(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSeasonRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSeasonRepository)(nil).Delete), id)
+}
+
+// FindById mocks base method.
+func (m *MockSeasonRepository) FindById(id string) (*entity.Season, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*entity.Season)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockSeasonRepositoryMockRecorder) FindById(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockSeasonRepository)(nil).FindById), id)
+}
+
+// FindByTeamId mocks base method.
+func (m *MockSeasonRepository) FindByTeamId(teamId string) ([]*entity.Season, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTeamId", teamId)
+	ret0, _ := ret[0].([]*entity.Season)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTeamId indicates an expected call of FindByTeamId.
+func (mr *MockSeasonRepositoryMockRecorder) FindByTeamId(teamId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTeamId", reflect.TypeOf((*MockSeasonRepository)(nil).FindByTeamId), teamId)
+}
+
+// Save mocks base method.
+func (m *MockSeasonRepository) Save(season *entity.Season) (*entity.Season, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", season)
+	ret0, _ := ret[0].(*entity.Season)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSeasonRepositoryMockRecorder) Save(season interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSeasonRepository)(nil).Save), season)
+}
+
+// Update mocks base method.
+func (m *MockSeasonRepository) Update(season *entity.Season) (*entity.Season, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", season)
+	ret0, _ := ret[0].(*entity.Season)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSeasonRepositoryMockRecorder) Update(season interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSeasonRepository)(nil).Update), season)
+}
@@ -48,34 +48,49 @@ func (mr *MockUserTeamRepositoryMockRecorder) Delete(userId, teamId interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserTeamRepository)(nil).Delete), userId, teamId)
 }
 
-// FindInvitedTeamsByUserId mocks base method.
-func (m *MockUserTeamRepository) FindInvitedTeamsByUserId(userId string) ([]string, error) {
+// Find mocks base method.
+func (m *MockUserTeamRepository) Find(userId, teamId string) (*entity.UserTeam, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindInvitedTeamsByUserId", userId)
-	ret0, _ := ret[0].([]string)
+	ret := m.ctrl.Call(m, "Find", userId, teamId)
+	ret0, _ := ret[0].(*entity.UserTeam)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindInvitedTeamsByUserId indicates an expected call of FindInvitedTeamsByUserId.
-func (mr *MockUserTeamRepositoryMockRecorder) FindInvitedTeamsByUserId(userId interface{}) *gomock.Call {
+// Find indicates an expected call of Find.
+func (mr *MockUserTeamRepositoryMockRecorder) Find(userId, teamId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInvitedTeamsByUserId", reflect.TypeOf((*MockUserTeamRepository)(nil).FindInvitedTeamsByUserId), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockUserTeamRepository)(nil).Find), userId, teamId)
 }
 
-// FindInvitedUsersByTeamId mocks base method.
-func (m *MockUserTeamRepository) FindInvitedUsersByTeamId(teamId string) ([]string, error) {
+// FindInvitationsByTeamId mocks base method.
+func (m *MockUserTeamRepository) FindInvitationsByTeamId(teamId string) ([]*entity.UserTeam, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindInvitedUsersByTeamId", teamId)
-	ret0, _ := ret[0].([]string)
+	ret := m.ctrl.Call(m, "FindInvitationsByTeamId", teamId)
+	ret0, _ := ret[0].([]*entity.UserTeam)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindInvitationsByTeamId indicates an expected call of FindInvitationsByTeamId.
+func (mr *MockUserTeamRepositoryMockRecorder) FindInvitationsByTeamId(teamId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInvitationsByTeamId", reflect.TypeOf((*MockUserTeamRepository)(nil).FindInvitationsByTeamId), teamId)
+}
+
+// FindInvitationsByUserId mocks base method.
+func (m *MockUserTeamRepository) FindInvitationsByUserId(userId string) ([]*entity.UserTeam, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInvitationsByUserId", userId)
+	ret0, _ := ret[0].([]*entity.UserTeam)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindInvitedUsersByTeamId indicates an expected call of FindInvitedUsersByTeamId.
-func (mr *MockUserTeamRepositoryMockRecorder) FindInvitedUsersByTeamId(teamId interface{}) *gomock.Call {
+// FindInvitationsByUserId indicates an expected call of FindInvitationsByUserId.
+func (mr *MockUserTeamRepositoryMockRecorder) FindInvitationsByUserId(userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInvitedUsersByTeamId", reflect.TypeOf((*MockUserTeamRepository)(nil).FindInvitedUsersByTeamId), teamId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInvitationsByUserId", reflect.TypeOf((*MockUserTeamRepository)(nil).FindInvitationsByUserId), userId)
 }
 
 // FindMembersByTeamId mocks base method.
@@ -93,6 +108,36 @@ func (mr *MockUserTeamRepositoryMockRecorder) FindMembersByTeamId(teamId interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMembersByTeamId", reflect.TypeOf((*MockUserTeamRepository)(nil).FindMembersByTeamId), teamId)
 }
 
+// FindMembershipsByTeamId mocks base method.
+func (m *MockUserTeamRepository) FindMembershipsByTeamId(teamId string) ([]*entity.UserTeam, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMembershipsByTeamId", teamId)
+	ret0, _ := ret[0].([]*entity.UserTeam)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMembershipsByTeamId indicates an expected call of FindMembershipsByTeamId.
+func (mr *MockUserTeamRepositoryMockRecorder) FindMembershipsByTeamId(teamId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMembershipsByTeamId", reflect.TypeOf((*MockUserTeamRepository)(nil).FindMembershipsByTeamId), teamId)
+}
+
+// FindRole mocks base method.
+func (m *MockUserTeamRepository) FindRole(userId, teamId string) (entity.TeamRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRole", userId, teamId)
+	ret0, _ := ret[0].(entity.TeamRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRole indicates an expected call of FindRole.
+func (mr *MockUserTeamRepositoryMockRecorder) FindRole(userId, teamId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRole", reflect.TypeOf((*MockUserTeamRepository)(nil).FindRole), userId, teamId)
+}
+
 // FindTeamsByUserId mocks base method.
 func (m *MockUserTeamRepository) FindTeamsByUserId(userId string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -153,6 +198,50 @@ func (mr *MockUserTeamRepositoryMockRecorder) Save(userTeam interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockUserTeamRepository)(nil).Save), userTeam)
 }
 
+// SaveInvitation mocks base method.
+func (m *MockUserTeamRepository) SaveInvitation(userTeam *entity.UserTeam) (*entity.UserTeam, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveInvitation", userTeam)
+	ret0, _ := ret[0].(*entity.UserTeam)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveInvitation indicates an expected call of SaveInvitation.
+func (mr *MockUserTeamRepositoryMockRecorder) SaveInvitation(userTeam interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveInvitation", reflect.TypeOf((*MockUserTeamRepository)(nil).SaveInvitation), userTeam)
+}
+
+// TransferOwnership mocks base method.
+func (m *MockUserTeamRepository) TransferOwnership(teamId, fromUserId, toUserId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferOwnership", teamId, fromUserId, toUserId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransferOwnership indicates an expected call of TransferOwnership.
+func (mr *MockUserTeamRepositoryMockRecorder) TransferOwnership(teamId, fromUserId, toUserId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferOwnership", reflect.TypeOf((*MockUserTeamRepository)(nil).TransferOwnership), teamId, fromUserId, toUserId)
+}
+
+// UpdateRole mocks base method.
+func (m *MockUserTeamRepository) UpdateRole(userTeam *entity.UserTeam) (*entity.UserTeam, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", userTeam)
+	ret0, _ := ret[0].(*entity.UserTeam)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockUserTeamRepositoryMockRecorder) UpdateRole(userTeam interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockUserTeamRepository)(nil).UpdateRole), userTeam)
+}
+
 // UpdateState mocks base method.
 func (m *MockUserTeamRepository) UpdateState(userTeam *entity.UserTeam) (*entity.UserTeam, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/event.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "CurlARC/internal/domain/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEventUsecase is a mock of EventUsecase interface.
+type MockEventUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventUsecaseMockRecorder
+}
+
+// MockEventUsecaseMockRecorder is the mock recorder for MockEventUsecase.
+type MockEventUsecaseMockRecorder struct {
+	mock *MockEventUsecase
+}
+
+// NewMockEventUsecase creates a new mock instance.
+func NewMockEventUsecase(ctrl *gomock.Controller) *MockEventUsecase {
+	mock := &MockEventUsecase{ctrl: ctrl}
+	mock.recorder = &MockEventUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventUsecase) EXPECT() *MockEventUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AttachRecord mocks base method.
+func (m *MockEventUsecase) AttachRecord(userId, teamId, eventId string, game entity.EventGame) (*entity.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachRecord", userId, teamId, eventId, game)
+	ret0, _ := ret[0].(*entity.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AttachRecord indicates an expected call of AttachRecord.
+func (mr *MockEventUsecaseMockRecorder) AttachRecord(userId, teamId, eventId, game interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachRecord", reflect.TypeOf((*MockEventUsecase)(nil).AttachRecord), userId, teamId, eventId, game)
+}
+
+// CreateEvent mocks base method.
+func (m *MockEventUsecase) CreateEvent(userId, teamId, name, venue string, format entity.EventFormat, startDate, endDate time.Time) (*entity.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEvent", userId, teamId, name, venue, format, startDate, endDate)
+	ret0, _ := ret[0].(*entity.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEvent indicates an expected call of CreateEvent.
+func (mr *MockEventUsecaseMockRecorder) CreateEvent(userId, teamId, name, venue, format, startDate, endDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockEventUsecase)(nil).CreateEvent), userId, teamId, name, venue, format, startDate, endDate)
+}
+
+// DeleteEvent mocks base method.
+func (m *MockEventUsecase) DeleteEvent(userId, teamId, eventId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEvent", userId, teamId, eventId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEvent indicates an expected call of DeleteEvent.
+func (mr *MockEventUsecaseMockRecorder) DeleteEvent(userId, teamId, eventId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEvent", reflect.TypeOf((*MockEventUsecase)(nil).DeleteEvent), userId, teamId, eventId)
+}
+
+// DetachRecord mocks base method.
+func (m *MockEventUsecase) DetachRecord(userId, teamId, eventId, recordId string) (*entity.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachRecord", userId, teamId, eventId, recordId)
+	ret0, _ := ret[0].(*entity.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetachRecord indicates an expected call of DetachRecord.
+func (mr *MockEventUsecaseMockRecorder) DetachRecord(userId, teamId, eventId, recordId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachRecord", reflect.TypeOf((*MockEventUsecase)(nil).DetachRecord), userId, teamId, eventId, recordId)
+}
+
+// GetEvent mocks base method.
+func (m *MockEventUsecase) GetEvent(userId, teamId, eventId string) (*entity.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvent", userId, teamId, eventId)
+	ret0, _ := ret[0].(*entity.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvent indicates an expected call of GetEvent.
+func (mr *MockEventUsecaseMockRecorder) GetEvent(userId, teamId, eventId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvent", reflect.TypeOf((*MockEventUsecase)(nil).GetEvent), userId, teamId, eventId)
+}
+
+// GetEventsByTeamId mocks base method.
+func (m *MockEventUsecase) GetEventsByTeamId(userId, teamId string) ([]*entity.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventsByTeamId", userId, teamId)
+	ret0, _ := ret[0].([]*entity.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventsByTeamId indicates an expected call of GetEventsByTeamId.
+func (mr *MockEventUsecaseMockRecorder) GetEventsByTeamId(userId, teamId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventsByTeamId", reflect.TypeOf((*MockEventUsecase)(nil).GetEventsByTeamId), userId, teamId)
+}
+
+// GetStandings mocks base method.
+func (m *MockEventUsecase) GetStandings(userId, teamId, eventId string) (*entity.Standings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStandings", userId, teamId, eventId)
+	ret0, _ := ret[0].(*entity.Standings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStandings indicates an expected call of GetStandings.
+func (mr *MockEventUsecaseMockRecorder) GetStandings(userId, teamId, eventId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStandings", reflect.TypeOf((*MockEventUsecase)(nil).GetStandings), userId, teamId, eventId)
+}
+
+// UpdateEvent mocks base method.
+func (m *MockEventUsecase) UpdateEvent(userId, teamId, eventId string, name, venue *string, format *entity.EventFormat, startDate, endDate *time.Time) (*entity.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEvent", userId, teamId, eventId, name, venue, format, startDate, endDate)
+	ret0, _ := ret[0].(*entity.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEvent indicates an expected call of UpdateEvent.
+func (mr *MockEventUsecaseMockRecorder) UpdateEvent(userId, teamId, eventId, name, venue, format, startDate, endDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEvent", reflect.TypeOf((*MockEventUsecase)(nil).UpdateEvent), userId, teamId, eventId, name, venue, format, startDate, endDate)
+}
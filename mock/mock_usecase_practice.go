@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/practice.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "CurlARC/internal/domain/entity"
+	usecase "CurlARC/internal/usecase"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPracticeUsecase is a mock of PracticeUsecase interface.
+type MockPracticeUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPracticeUsecaseMockRecorder
+}
+
+// MockPracticeUsecaseMockRecorder is the mock recorder for MockPracticeUsecase.
+type MockPracticeUsecaseMockRecorder struct {
+	mock *MockPracticeUsecase
+}
+
+// NewMockPracticeUsecase creates a new mock instance.
+func NewMockPracticeUsecase(ctrl *gomock.Controller) *MockPracticeUsecase {
+	mock := &MockPracticeUsecase{ctrl: ctrl}
+	mock.recorder = &MockPracticeUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPracticeUsecase) EXPECT() *MockPracticeUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreatePracticeSession mocks base method.
+func (m *MockPracticeUsecase) CreatePracticeSession(userId, teamId string, date time.Time, place string, attendees []entity.LineupPlayer, drills []entity.Drill) (*entity.PracticeSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePracticeSession", userId, teamId, date, place, attendees, drills)
+	ret0, _ := ret[0].(*entity.PracticeSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePracticeSession indicates an expected call of CreatePracticeSession.
+func (mr *MockPracticeUsecaseMockRecorder) CreatePracticeSession(userId, teamId, date, place, attendees, drills interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePracticeSession", reflect.TypeOf((*MockPracticeUsecase)(nil).CreatePracticeSession), userId, teamId, date, place, attendees, drills)
+}
+
+// DeletePracticeSession mocks base method.
+func (m *MockPracticeUsecase) DeletePracticeSession(userId, teamId, sessionId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePracticeSession", userId, teamId, sessionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePracticeSession indicates an expected call of DeletePracticeSession.
+func (mr *MockPracticeUsecaseMockRecorder) DeletePracticeSession(userId, teamId, sessionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePracticeSession", reflect.TypeOf((*MockPracticeUsecase)(nil).DeletePracticeSession), userId, teamId, sessionId)
+}
+
+// GetPlayerReports mocks base method.
+func (m *MockPracticeUsecase) GetPlayerReports(userId, teamId string, filter usecase.StatsFilter) ([]usecase.PlayerReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlayerReports", userId, teamId, filter)
+	ret0, _ := ret[0].([]usecase.PlayerReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlayerReports indicates an expected call of GetPlayerReports.
+func (mr *MockPracticeUsecaseMockRecorder) GetPlayerReports(userId, teamId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlayerReports", reflect.TypeOf((*MockPracticeUsecase)(nil).GetPlayerReports), userId, teamId, filter)
+}
+
+// GetPracticeSession mocks base method.
+func (m *MockPracticeUsecase) GetPracticeSession(userId, teamId, sessionId string) (*entity.PracticeSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPracticeSession", userId, teamId, sessionId)
+	ret0, _ := ret[0].(*entity.PracticeSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPracticeSession indicates an expected call of GetPracticeSession.
+func (mr *MockPracticeUsecaseMockRecorder) GetPracticeSession(userId, teamId, sessionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPracticeSession", reflect.TypeOf((*MockPracticeUsecase)(nil).GetPracticeSession), userId, teamId, sessionId)
+}
+
+// GetPracticeSessionsByTeamId mocks base method.
+func (m *MockPracticeUsecase) GetPracticeSessionsByTeamId(userId, teamId string) ([]*entity.PracticeSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPracticeSessionsByTeamId", userId, teamId)
+	ret0, _ := ret[0].([]*entity.PracticeSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPracticeSessionsByTeamId indicates an expected call of GetPracticeSessionsByTeamId.
+func (mr *MockPracticeUsecaseMockRecorder) GetPracticeSessionsByTeamId(userId, teamId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPracticeSessionsByTeamId", reflect.TypeOf((*MockPracticeUsecase)(nil).GetPracticeSessionsByTeamId), userId, teamId)
+}
+
+// GetPracticeStats mocks base method.
+func (m *MockPracticeUsecase) GetPracticeStats(userId, teamId string, filter usecase.StatsFilter) (*usecase.PracticeStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPracticeStats", userId, teamId, filter)
+	ret0, _ := ret[0].(*usecase.PracticeStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPracticeStats indicates an expected call of GetPracticeStats.
+func (mr *MockPracticeUsecaseMockRecorder) GetPracticeStats(userId, teamId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPracticeStats", reflect.TypeOf((*MockPracticeUsecase)(nil).GetPracticeStats), userId, teamId, filter)
+}
+
+// UpdatePracticeSession mocks base method.
+func (m *MockPracticeUsecase) UpdatePracticeSession(userId, teamId, sessionId string, date *time.Time, place *string, attendees *[]entity.LineupPlayer, drills *[]entity.Drill) (*entity.PracticeSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePracticeSession", userId, teamId, sessionId, date, place, attendees, drills)
+	ret0, _ := ret[0].(*entity.PracticeSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePracticeSession indicates an expected call of UpdatePracticeSession.
+func (mr *MockPracticeUsecaseMockRecorder) UpdatePracticeSession(userId, teamId, sessionId, date, place, attendees, drills interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePracticeSession", reflect.TypeOf((*MockPracticeUsecase)(nil).UpdatePracticeSession), userId, teamId, sessionId, date, place, attendees, drills)
+}
@@ -6,7 +6,7 @@ package mock
 
 import (
 	entity "CurlARC/internal/domain/entity"
-	response "CurlARC/internal/handler/response"
+	repository "CurlARC/internal/domain/repository"
 	reflect "reflect"
 	time "time"
 
@@ -36,93 +36,138 @@ func (m *MockRecordUsecase) EXPECT() *MockRecordUsecaseMockRecorder {
 	return m.recorder
 }
 
+// AddSubstitution mocks base method.
+func (m *MockRecordUsecase) AddSubstitution(recordId, userId string, substitution entity.Substitution) (*entity.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSubstitution", recordId, userId, substitution)
+	ret0, _ := ret[0].(*entity.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSubstitution indicates an expected call of AddSubstitution.
+func (mr *MockRecordUsecaseMockRecorder) AddSubstitution(recordId, userId, substitution interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubstitution", reflect.TypeOf((*MockRecordUsecase)(nil).AddSubstitution), recordId, userId, substitution)
+}
+
 // AppendEndData mocks base method.
-func (m *MockRecordUsecase) AppendEndData(recordId, userId string, endsData []entity.DataPerEnd) (*entity.Record, error) {
+func (m *MockRecordUsecase) AppendEndData(recordId, userId string, endsData []entity.DataPerEnd, fgzPolicy entity.FreeGuardZonePolicy) (*entity.Record, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AppendEndData", recordId, userId, endsData)
+	ret := m.ctrl.Call(m, "AppendEndData", recordId, userId, endsData, fgzPolicy)
 	ret0, _ := ret[0].(*entity.Record)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AppendEndData indicates an expected call of AppendEndData.
-func (mr *MockRecordUsecaseMockRecorder) AppendEndData(recordId, userId, endsData interface{}) *gomock.Call {
+func (mr *MockRecordUsecaseMockRecorder) AppendEndData(recordId, userId, endsData, fgzPolicy interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendEndData", reflect.TypeOf((*MockRecordUsecase)(nil).AppendEndData), recordId, userId, endsData)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendEndData", reflect.TypeOf((*MockRecordUsecase)(nil).AppendEndData), recordId, userId, endsData, fgzPolicy)
 }
 
 // CreateRecord mocks base method.
-func (m *MockRecordUsecase) CreateRecord(userId, teamId, enemyTeamName, place string, result entity.Result, date time.Time) (*entity.Record, error) {
+func (m *MockRecordUsecase) CreateRecord(userId, teamId, enemyTeamName, place string, result entity.Result, date time.Time, gameFormat entity.GameFormat) (*entity.Record, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRecord", userId, teamId, enemyTeamName, place, result, date)
+	ret := m.ctrl.Call(m, "CreateRecord", userId, teamId, enemyTeamName, place, result, date, gameFormat)
 	ret0, _ := ret[0].(*entity.Record)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateRecord indicates an expected call of CreateRecord.
-func (mr *MockRecordUsecaseMockRecorder) CreateRecord(userId, teamId, enemyTeamName, place, result, date interface{}) *gomock.Call {
+func (mr *MockRecordUsecaseMockRecorder) CreateRecord(userId, teamId, enemyTeamName, place, result, date, gameFormat interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecord", reflect.TypeOf((*MockRecordUsecase)(nil).CreateRecord), userId, teamId, enemyTeamName, place, result, date)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecord", reflect.TypeOf((*MockRecordUsecase)(nil).CreateRecord), userId, teamId, enemyTeamName, place, result, date, gameFormat)
 }
 
 // DeleteRecord mocks base method.
-func (m *MockRecordUsecase) DeleteRecord(id string) error {
+func (m *MockRecordUsecase) DeleteRecord(id, userId string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteRecord", id)
+	ret := m.ctrl.Call(m, "DeleteRecord", id, userId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteRecord indicates an expected call of DeleteRecord.
-func (mr *MockRecordUsecaseMockRecorder) DeleteRecord(id interface{}) *gomock.Call {
+func (mr *MockRecordUsecaseMockRecorder) DeleteRecord(id, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecord", reflect.TypeOf((*MockRecordUsecase)(nil).DeleteRecord), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecord", reflect.TypeOf((*MockRecordUsecase)(nil).DeleteRecord), id, userId)
 }
 
 // GetRecordDetailsByRecordId mocks base method.
-func (m *MockRecordUsecase) GetRecordDetailsByRecordId(recordId string) (*entity.Record, error) {
+func (m *MockRecordUsecase) GetRecordDetailsByRecordId(recordId, userId string) (*entity.Record, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRecordDetailsByRecordId", recordId)
+	ret := m.ctrl.Call(m, "GetRecordDetailsByRecordId", recordId, userId)
 	ret0, _ := ret[0].(*entity.Record)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRecordDetailsByRecordId indicates an expected call of GetRecordDetailsByRecordId.
-func (mr *MockRecordUsecaseMockRecorder) GetRecordDetailsByRecordId(recordId interface{}) *gomock.Call {
+func (mr *MockRecordUsecaseMockRecorder) GetRecordDetailsByRecordId(recordId, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecordDetailsByRecordId", reflect.TypeOf((*MockRecordUsecase)(nil).GetRecordDetailsByRecordId), recordId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecordDetailsByRecordId", reflect.TypeOf((*MockRecordUsecase)(nil).GetRecordDetailsByRecordId), recordId, userId)
 }
 
 // GetRecordIndicesByTeamId mocks base method.
-func (m *MockRecordUsecase) GetRecordIndicesByTeamId(teamId string) (*[]response.RecordIndex, error) {
+func (m *MockRecordUsecase) GetRecordIndicesByTeamId(userId, teamId string, query repository.RecordQuery) (*repository.RecordIndexPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRecordIndicesByTeamId", teamId)
-	ret0, _ := ret[0].(*[]response.RecordIndex)
+	ret := m.ctrl.Call(m, "GetRecordIndicesByTeamId", userId, teamId, query)
+	ret0, _ := ret[0].(*repository.RecordIndexPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRecordIndicesByTeamId indicates an expected call of GetRecordIndicesByTeamId.
-func (mr *MockRecordUsecaseMockRecorder) GetRecordIndicesByTeamId(teamId interface{}) *gomock.Call {
+func (mr *MockRecordUsecaseMockRecorder) GetRecordIndicesByTeamId(userId, teamId, query interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecordIndicesByTeamId", reflect.TypeOf((*MockRecordUsecase)(nil).GetRecordIndicesByTeamId), teamId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecordIndicesByTeamId", reflect.TypeOf((*MockRecordUsecase)(nil).GetRecordIndicesByTeamId), userId, teamId, query)
 }
 
 // GetRecordsByTeamId mocks base method.
-func (m *MockRecordUsecase) GetRecordsByTeamId(teamId string) (*[]entity.Record, error) {
+func (m *MockRecordUsecase) GetRecordsByTeamId(userId, teamId string) (*[]entity.Record, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRecordsByTeamId", teamId)
+	ret := m.ctrl.Call(m, "GetRecordsByTeamId", userId, teamId)
 	ret0, _ := ret[0].(*[]entity.Record)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRecordsByTeamId indicates an expected call of GetRecordsByTeamId.
-func (mr *MockRecordUsecaseMockRecorder) GetRecordsByTeamId(teamId interface{}) *gomock.Call {
+func (mr *MockRecordUsecaseMockRecorder) GetRecordsByTeamId(userId, teamId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecordsByTeamId", reflect.TypeOf((*MockRecordUsecase)(nil).GetRecordsByTeamId), userId, teamId)
+}
+
+// GetScoreboard mocks base method.
+func (m *MockRecordUsecase) GetScoreboard(recordId, userId string) (*entity.Scoreboard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScoreboard", recordId, userId)
+	ret0, _ := ret[0].(*entity.Scoreboard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScoreboard indicates an expected call of GetScoreboard.
+func (mr *MockRecordUsecaseMockRecorder) GetScoreboard(recordId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScoreboard", reflect.TypeOf((*MockRecordUsecase)(nil).GetScoreboard), recordId, userId)
+}
+
+// SetLineup mocks base method.
+func (m *MockRecordUsecase) SetLineup(recordId, userId string, lineup entity.Lineup) (*entity.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLineup", recordId, userId, lineup)
+	ret0, _ := ret[0].(*entity.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetLineup indicates an expected call of SetLineup.
+func (mr *MockRecordUsecaseMockRecorder) SetLineup(recordId, userId, lineup interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecordsByTeamId", reflect.TypeOf((*MockRecordUsecase)(nil).GetRecordsByTeamId), teamId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLineup", reflect.TypeOf((*MockRecordUsecase)(nil).SetLineup), recordId, userId, lineup)
 }
 
 // SetVisibility mocks base method.
@@ -141,16 +186,16 @@ func (mr *MockRecordUsecaseMockRecorder) SetVisibility(recordId, userId, isPubli
 }
 
 // UpdateRecord mocks base method.
-func (m *MockRecordUsecase) UpdateRecord(recordId, userId, enemyTeamName, place string, endsData []entity.DataPerEnd, date time.Time, isFirst, isPublic bool) (*entity.Record, error) {
+func (m *MockRecordUsecase) UpdateRecord(recordId, userId string, result entity.Result, enemyTeamName, place string, endsData []entity.DataPerEnd, date time.Time, isRed, isFirst, isPublic bool, status entity.GameStatus, fgzPolicy entity.FreeGuardZonePolicy) (*entity.Record, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateRecord", recordId, userId, enemyTeamName, place, endsData, date, isFirst, isPublic)
+	ret := m.ctrl.Call(m, "UpdateRecord", recordId, userId, result, enemyTeamName, place, endsData, date, isRed, isFirst, isPublic, status, fgzPolicy)
 	ret0, _ := ret[0].(*entity.Record)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateRecord indicates an expected call of UpdateRecord.
-func (mr *MockRecordUsecaseMockRecorder) UpdateRecord(recordId, userId, enemyTeamName, place, endsData, date, isFirst, isPublic interface{}) *gomock.Call {
+func (mr *MockRecordUsecaseMockRecorder) UpdateRecord(recordId, userId, result, enemyTeamName, place, endsData, date, isRed, isFirst, isPublic, status, fgzPolicy interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecord", reflect.TypeOf((*MockRecordUsecase)(nil).UpdateRecord), recordId, userId, enemyTeamName, place, endsData, date, isFirst, isPublic)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecord", reflect.TypeOf((*MockRecordUsecase)(nil).UpdateRecord), recordId, userId, result, enemyTeamName, place, endsData, date, isRed, isFirst, isPublic, status, fgzPolicy)
 }
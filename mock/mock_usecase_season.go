@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/season.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "CurlARC/internal/domain/entity"
+	usecase "CurlARC/internal/usecase"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSeasonUsecase is a mock of SeasonUsecase interface.
+type MockSeasonUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeasonUsecaseMockRecorder
+}
+
+// MockSeasonUsecaseMockRecorder is the mock recorder for MockSeasonUsecase.
+type MockSeasonUsecaseMockRecorder struct {
+	mock *MockSeasonUsecase
+}
+
+// NewMockSeasonUsecase creates a new mock instance.
+func NewMockSeasonUsecase(ctrl *gomock.Controller) *MockSeasonUsecase {
+	mock := &MockSeasonUsecase{ctrl: ctrl}
+	mock.recorder = &MockSeasonUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeasonUsecase) EXPECT() *MockSeasonUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateSeason mocks base method.
+func (m *MockSeasonUsecase) CreateSeason(userId, teamId, name string, startDate, endDate time.Time) (*entity.Season, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSeason", userId, teamId, name, startDate, endDate)
+	ret0, _ := ret[0].(*entity.Season)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSeason indicates an expected call of CreateSeason.
+func (mr *MockSeasonUsecaseMockRecorder) CreateSeason(userId, teamId, name, startDate, endDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeason", reflect.TypeOf((*MockSeasonUsecase)(nil).CreateSeason), userId, teamId, name, startDate, endDate)
+}
+
+// DeleteSeason mocks base method.
+func (m *MockSeasonUsecase) DeleteSeason(userId, teamId, seasonId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeason", userId, teamId, seasonId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSeason indicates an expected call of DeleteSeason.
+func (mr *MockSeasonUsecaseMockRecorder) DeleteSeason(userId, teamId, seasonId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeason", reflect.TypeOf((*MockSeasonUsecase)(nil).DeleteSeason), userId, teamId, seasonId)
+}
+
+// GetSeasonSummary mocks base method.
+func (m *MockSeasonUsecase) GetSeasonSummary(userId, teamId, seasonId string) (*usecase.SeasonSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeasonSummary", userId, teamId, seasonId)
+	ret0, _ := ret[0].(*usecase.SeasonSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeasonSummary indicates an expected call of GetSeasonSummary.
+func (mr *MockSeasonUsecaseMockRecorder) GetSeasonSummary(userId, teamId, seasonId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeasonSummary", reflect.TypeOf((*MockSeasonUsecase)(nil).GetSeasonSummary), userId, teamId, seasonId)
+}
+
+// GetSeasonsByTeamId mocks base method.
+func (m *MockSeasonUsecase) GetSeasonsByTeamId(userId, teamId string) ([]*entity.Season, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeasonsByTeamId", userId, teamId)
+	ret0, _ := ret[0].([]*entity.Season)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeasonsByTeamId indicates an expected call of GetSeasonsByTeamId.
+func (mr *MockSeasonUsecaseMockRecorder) GetSeasonsByTeamId(userId, teamId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeasonsByTeamId", reflect.TypeOf((*MockSeasonUsecase)(nil).GetSeasonsByTeamId), userId, teamId)
+}
+
+// UpdateSeason mocks base method.
+func (m *MockSeasonUsecase) UpdateSeason(userId, teamId, seasonId string, name *string, startDate, endDate *time.Time) (*entity.Season, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSeason", userId, teamId, seasonId, name, startDate, endDate)
+	ret0, _ := ret[0].(*entity.Season)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSeason indicates an expected call of UpdateSeason.
+func (mr *MockSeasonUsecaseMockRecorder) UpdateSeason(userId, teamId, seasonId, name, startDate, endDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSeason", reflect.TypeOf((*MockSeasonUsecase)(nil).UpdateSeason), userId, teamId, seasonId, name, startDate, endDate)
+}
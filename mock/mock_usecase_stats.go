@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/stats.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "CurlARC/internal/domain/entity"
+	usecase "CurlARC/internal/usecase"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStatsUsecase is a mock of StatsUsecase interface.
+type MockStatsUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsUsecaseMockRecorder
+}
+
+// MockStatsUsecaseMockRecorder is the mock recorder for MockStatsUsecase.
+type MockStatsUsecaseMockRecorder struct {
+	mock *MockStatsUsecase
+}
+
+// NewMockStatsUsecase creates a new mock instance.
+func NewMockStatsUsecase(ctrl *gomock.Controller) *MockStatsUsecase {
+	mock := &MockStatsUsecase{ctrl: ctrl}
+	mock.recorder = &MockStatsUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsUsecase) EXPECT() *MockStatsUsecaseMockRecorder {
+	return m.recorder
+}
+
+// FindSimilarPositions mocks base method.
+func (m *MockStatsUsecase) FindSimilarPositions(userId, teamId string, query usecase.PositionQuery) ([]usecase.SimilarPosition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSimilarPositions", userId, teamId, query)
+	ret0, _ := ret[0].([]usecase.SimilarPosition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSimilarPositions indicates an expected call of FindSimilarPositions.
+func (mr *MockStatsUsecaseMockRecorder) FindSimilarPositions(userId, teamId, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSimilarPositions", reflect.TypeOf((*MockStatsUsecase)(nil).FindSimilarPositions), userId, teamId, query)
+}
+
+// GetHammerStats mocks base method.
+func (m *MockStatsUsecase) GetHammerStats(userId, teamId string, filter usecase.StatsFilter) (*usecase.HammerStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHammerStats", userId, teamId, filter)
+	ret0, _ := ret[0].(*usecase.HammerStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHammerStats indicates an expected call of GetHammerStats.
+func (mr *MockStatsUsecaseMockRecorder) GetHammerStats(userId, teamId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHammerStats", reflect.TypeOf((*MockStatsUsecase)(nil).GetHammerStats), userId, teamId, filter)
+}
+
+// GetHeadToHead mocks base method.
+func (m *MockStatsUsecase) GetHeadToHead(userId, teamId string, filter usecase.StatsFilter) ([]usecase.HeadToHead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeadToHead", userId, teamId, filter)
+	ret0, _ := ret[0].([]usecase.HeadToHead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeadToHead indicates an expected call of GetHeadToHead.
+func (mr *MockStatsUsecaseMockRecorder) GetHeadToHead(userId, teamId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeadToHead", reflect.TypeOf((*MockStatsUsecase)(nil).GetHeadToHead), userId, teamId, filter)
+}
+
+// GetHeatmap mocks base method.
+func (m *MockStatsUsecase) GetHeatmap(userId, teamId string, filter usecase.StatsFilter, query usecase.HeatmapQuery) (*usecase.Heatmap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeatmap", userId, teamId, filter, query)
+	ret0, _ := ret[0].(*usecase.Heatmap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeatmap indicates an expected call of GetHeatmap.
+func (mr *MockStatsUsecaseMockRecorder) GetHeatmap(userId, teamId, filter, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeatmap", reflect.TypeOf((*MockStatsUsecase)(nil).GetHeatmap), userId, teamId, filter, query)
+}
+
+// GetShooterStats mocks base method.
+func (m *MockStatsUsecase) GetShooterStats(userId, teamId string, filter usecase.StatsFilter) ([]usecase.ShooterStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShooterStats", userId, teamId, filter)
+	ret0, _ := ret[0].([]usecase.ShooterStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShooterStats indicates an expected call of GetShooterStats.
+func (mr *MockStatsUsecaseMockRecorder) GetShooterStats(userId, teamId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShooterStats", reflect.TypeOf((*MockStatsUsecase)(nil).GetShooterStats), userId, teamId, filter)
+}
+
+// GetWinProbability mocks base method.
+func (m *MockStatsUsecase) GetWinProbability(userId, teamId string, format entity.GameFormat, end, scoreDifference int, hammer bool) (*usecase.WinProbability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWinProbability", userId, teamId, format, end, scoreDifference, hammer)
+	ret0, _ := ret[0].(*usecase.WinProbability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWinProbability indicates an expected call of GetWinProbability.
+func (mr *MockStatsUsecaseMockRecorder) GetWinProbability(userId, teamId, format, end, scoreDifference, hammer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWinProbability", reflect.TypeOf((*MockStatsUsecase)(nil).GetWinProbability), userId, teamId, format, end, scoreDifference, hammer)
+}
+
+// GetWinProbabilityCurve mocks base method.
+func (m *MockStatsUsecase) GetWinProbabilityCurve(userId, recordId string) (*usecase.WinProbabilityCurve, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWinProbabilityCurve", userId, recordId)
+	ret0, _ := ret[0].(*usecase.WinProbabilityCurve)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWinProbabilityCurve indicates an expected call of GetWinProbabilityCurve.
+func (mr *MockStatsUsecaseMockRecorder) GetWinProbabilityCurve(userId, recordId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWinProbabilityCurve", reflect.TypeOf((*MockStatsUsecase)(nil).GetWinProbabilityCurve), userId, recordId)
+}
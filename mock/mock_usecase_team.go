@@ -6,6 +6,7 @@ package mock
 
 import (
 	entity "CurlARC/internal/domain/entity"
+	usecase "CurlARC/internal/usecase"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -63,18 +64,32 @@ func (mr *MockTeamUsecaseMockRecorder) CreateTeam(name, userId interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeam", reflect.TypeOf((*MockTeamUsecase)(nil).CreateTeam), name, userId)
 }
 
+// DeclineInvitation mocks base method.
+func (m *MockTeamUsecase) DeclineInvitation(teamId, userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineInvitation", teamId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineInvitation indicates an expected call of DeclineInvitation.
+func (mr *MockTeamUsecaseMockRecorder) DeclineInvitation(teamId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineInvitation", reflect.TypeOf((*MockTeamUsecase)(nil).DeclineInvitation), teamId, userId)
+}
+
 // DeleteTeam mocks base method.
-func (m *MockTeamUsecase) DeleteTeam(id string) error {
+func (m *MockTeamUsecase) DeleteTeam(id, userId string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTeam", id)
+	ret := m.ctrl.Call(m, "DeleteTeam", id, userId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTeam indicates an expected call of DeleteTeam.
-func (mr *MockTeamUsecaseMockRecorder) DeleteTeam(id interface{}) *gomock.Call {
+func (mr *MockTeamUsecaseMockRecorder) DeleteTeam(id, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTeam", reflect.TypeOf((*MockTeamUsecase)(nil).DeleteTeam), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTeam", reflect.TypeOf((*MockTeamUsecase)(nil).DeleteTeam), id, userId)
 }
 
 // GetAllTeams mocks base method.
@@ -93,25 +108,25 @@ func (mr *MockTeamUsecaseMockRecorder) GetAllTeams() *gomock.Call {
 }
 
 // GetDetailsByTeamId mocks base method.
-func (m *MockTeamUsecase) GetDetailsByTeamId(teamId string) (*entity.Team, error) {
+func (m *MockTeamUsecase) GetDetailsByTeamId(teamId, userId string) (*entity.Team, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDetailsByTeamId", teamId)
+	ret := m.ctrl.Call(m, "GetDetailsByTeamId", teamId, userId)
 	ret0, _ := ret[0].(*entity.Team)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetDetailsByTeamId indicates an expected call of GetDetailsByTeamId.
-func (mr *MockTeamUsecaseMockRecorder) GetDetailsByTeamId(teamId interface{}) *gomock.Call {
+func (mr *MockTeamUsecaseMockRecorder) GetDetailsByTeamId(teamId, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetailsByTeamId", reflect.TypeOf((*MockTeamUsecase)(nil).GetDetailsByTeamId), teamId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetailsByTeamId", reflect.TypeOf((*MockTeamUsecase)(nil).GetDetailsByTeamId), teamId, userId)
 }
 
 // GetInvitedTeams mocks base method.
-func (m *MockTeamUsecase) GetInvitedTeams(userId string) ([]*entity.Team, error) {
+func (m *MockTeamUsecase) GetInvitedTeams(userId string) ([]usecase.Invitation, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetInvitedTeams", userId)
-	ret0, _ := ret[0].([]*entity.Team)
+	ret0, _ := ret[0].([]usecase.Invitation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -123,33 +138,48 @@ func (mr *MockTeamUsecaseMockRecorder) GetInvitedTeams(userId interface{}) *gomo
 }
 
 // GetInvitedUsersByTeamId mocks base method.
-func (m *MockTeamUsecase) GetInvitedUsersByTeamId(teamId string) ([]*entity.User, error) {
+func (m *MockTeamUsecase) GetInvitedUsersByTeamId(teamId, userId string) ([]usecase.Invitation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetInvitedUsersByTeamId", teamId)
-	ret0, _ := ret[0].([]*entity.User)
+	ret := m.ctrl.Call(m, "GetInvitedUsersByTeamId", teamId, userId)
+	ret0, _ := ret[0].([]usecase.Invitation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetInvitedUsersByTeamId indicates an expected call of GetInvitedUsersByTeamId.
-func (mr *MockTeamUsecaseMockRecorder) GetInvitedUsersByTeamId(teamId interface{}) *gomock.Call {
+func (mr *MockTeamUsecaseMockRecorder) GetInvitedUsersByTeamId(teamId, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitedUsersByTeamId", reflect.TypeOf((*MockTeamUsecase)(nil).GetInvitedUsersByTeamId), teamId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitedUsersByTeamId", reflect.TypeOf((*MockTeamUsecase)(nil).GetInvitedUsersByTeamId), teamId, userId)
 }
 
 // GetMembersByTeamId mocks base method.
-func (m *MockTeamUsecase) GetMembersByTeamId(teamId string) ([]*entity.User, error) {
+func (m *MockTeamUsecase) GetMembersByTeamId(teamId, userId string) ([]*entity.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMembersByTeamId", teamId)
+	ret := m.ctrl.Call(m, "GetMembersByTeamId", teamId, userId)
 	ret0, _ := ret[0].([]*entity.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetMembersByTeamId indicates an expected call of GetMembersByTeamId.
-func (mr *MockTeamUsecaseMockRecorder) GetMembersByTeamId(teamId interface{}) *gomock.Call {
+func (mr *MockTeamUsecaseMockRecorder) GetMembersByTeamId(teamId, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembersByTeamId", reflect.TypeOf((*MockTeamUsecase)(nil).GetMembersByTeamId), teamId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembersByTeamId", reflect.TypeOf((*MockTeamUsecase)(nil).GetMembersByTeamId), teamId, userId)
+}
+
+// GetRolesByTeamId mocks base method.
+func (m *MockTeamUsecase) GetRolesByTeamId(teamId, userId string) ([]*entity.UserTeam, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRolesByTeamId", teamId, userId)
+	ret0, _ := ret[0].([]*entity.UserTeam)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRolesByTeamId indicates an expected call of GetRolesByTeamId.
+func (mr *MockTeamUsecaseMockRecorder) GetRolesByTeamId(teamId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolesByTeamId", reflect.TypeOf((*MockTeamUsecase)(nil).GetRolesByTeamId), teamId, userId)
 }
 
 // GetTeamsByUserId mocks base method.
@@ -182,30 +212,72 @@ func (mr *MockTeamUsecaseMockRecorder) InviteUsers(teamId, userId, targetUserEma
 }
 
 // RemoveMember mocks base method.
-func (m *MockTeamUsecase) RemoveMember(teamId, userId string) error {
+func (m *MockTeamUsecase) RemoveMember(teamId, userId, memberId string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveMember", teamId, userId)
+	ret := m.ctrl.Call(m, "RemoveMember", teamId, userId, memberId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveMember indicates an expected call of RemoveMember.
-func (mr *MockTeamUsecaseMockRecorder) RemoveMember(teamId, userId interface{}) *gomock.Call {
+func (mr *MockTeamUsecaseMockRecorder) RemoveMember(teamId, userId, memberId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockTeamUsecase)(nil).RemoveMember), teamId, userId, memberId)
+}
+
+// RevokeInvitation mocks base method.
+func (m *MockTeamUsecase) RevokeInvitation(teamId, userId, inviteeId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeInvitation", teamId, userId, inviteeId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeInvitation indicates an expected call of RevokeInvitation.
+func (mr *MockTeamUsecaseMockRecorder) RevokeInvitation(teamId, userId, inviteeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeInvitation", reflect.TypeOf((*MockTeamUsecase)(nil).RevokeInvitation), teamId, userId, inviteeId)
+}
+
+// SetRole mocks base method.
+func (m *MockTeamUsecase) SetRole(teamId, userId, memberId string, role entity.TeamRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", teamId, userId, memberId, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockTeamUsecaseMockRecorder) SetRole(teamId, userId, memberId, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockTeamUsecase)(nil).SetRole), teamId, userId, memberId, role)
+}
+
+// TransferOwnership mocks base method.
+func (m *MockTeamUsecase) TransferOwnership(teamId, userId, newOwnerId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferOwnership", teamId, userId, newOwnerId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransferOwnership indicates an expected call of TransferOwnership.
+func (mr *MockTeamUsecaseMockRecorder) TransferOwnership(teamId, userId, newOwnerId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockTeamUsecase)(nil).RemoveMember), teamId, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferOwnership", reflect.TypeOf((*MockTeamUsecase)(nil).TransferOwnership), teamId, userId, newOwnerId)
 }
 
 // UpdateTeam mocks base method.
-func (m *MockTeamUsecase) UpdateTeam(id, name string) (*entity.Team, error) {
+func (m *MockTeamUsecase) UpdateTeam(id, userId, name string) (*entity.Team, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTeam", id, name)
+	ret := m.ctrl.Call(m, "UpdateTeam", id, userId, name)
 	ret0, _ := ret[0].(*entity.Team)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateTeam indicates an expected call of UpdateTeam.
-func (mr *MockTeamUsecaseMockRecorder) UpdateTeam(id, name interface{}) *gomock.Call {
+func (mr *MockTeamUsecaseMockRecorder) UpdateTeam(id, userId, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeam", reflect.TypeOf((*MockTeamUsecase)(nil).UpdateTeam), id, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeam", reflect.TypeOf((*MockTeamUsecase)(nil).UpdateTeam), id, userId, name)
 }